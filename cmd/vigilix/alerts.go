@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/notify"
+	"vigilix/internal/systemd"
+	"vigilix/internal/watch"
+)
+
+const alertsUsage = "alerts [--json] [--interval 10s]"
+
+// runAlerts evaluates the alert rules of the config file until interrupted,
+// printing every alert and sending it to the rule's channels.
+func runAlerts(args []string) int {
+	fs := flag.NewFlagSet("alerts", flag.ContinueOnError)
+	interval := fs.Duration("interval", 10*time.Second, "how often states, restart counts and memory are polled")
+	asJSON := fs.Bool("json", false, "print one JSON object per alert")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *interval <= 0 {
+		fmt.Fprintln(os.Stderr, "vigilix: --interval must be positive")
+		return exitUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: reading config:", err)
+		return exitError
+	}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		fmt.Fprintln(os.Stderr, "vigilix: no alert rules; add some under \"rules\" in the config file")
+		return exitUsage
+	}
+	engine, err := notify.NewEngine(cfg.Rules, cfg.Channels)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: alert rules:", err)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Journal lines are only followed in full when a rule looks at them.
+	priority := 3
+	if engine.NeedsLogs() {
+		priority = 7
+	}
+	events := make(chan watch.Event)
+	done := make(chan error, 1)
+	go func() {
+		done <- watch.Run(ctx, watch.Options{Interval: *interval, MaxPriority: priority, Initial: true}, events)
+	}()
+
+	var metrics <-chan time.Time
+	if engine.NeedsMetrics() {
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+		metrics = ticker.C
+	}
+
+	report := func(alerts []notify.Alert) {
+		for _, a := range alerts {
+			emitAlert(engine, a, *asJSON)
+		}
+	}
+	watched := make(map[string]bool)
+	for {
+		select {
+		case e := <-events:
+			switch e.Kind {
+			case watch.KindState:
+				if engine.Watches(e.Unit) {
+					watched[e.Unit] = true
+					report(engine.State(e.Unit, e.To.Active, e.Time))
+				}
+			case watch.KindLog:
+				report(engine.Log(e.Unit, e.Message, e.Time))
+			}
+
+		case now := <-metrics:
+			names := make([]string, 0, len(watched))
+			for name := range watched {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			usage, err := systemd.UnitsUsage(names)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "vigilix: reading metrics:", explainFailure(err))
+				continue
+			}
+			for _, u := range usage {
+				report(engine.Metrics(u.Unit, u.Restarts, u.Memory, now))
+			}
+
+		case err := <-done:
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "vigilix:", explainFailure(err))
+				return exitError
+			}
+			return exitOK
+		}
+	}
+}
+
+// emitAlert prints an alert and delivers it to its channels, unless the
+// unit's alerts are silenced.
+func emitAlert(engine *notify.Engine, a notify.Alert, asJSON bool) {
+	silences, err := notify.LoadSilences()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: loading silences:", err)
+	}
+	if notify.Silenced(silences, a.Unit, a.Time) {
+		return
+	}
+	if asJSON {
+		json.NewEncoder(os.Stdout).Encode(a)
+	} else {
+		fmt.Printf("%s %s %s\n", watchTime.Render(a.Time.Format("15:04:05")), watchBad.Render("["+a.Rule+"]"), a.Message)
+	}
+	// A slow webhook must not hold up watching.
+	for _, c := range engine.Channels(a) {
+		go func() {
+			if err := notify.Deliver(c, a); err != nil {
+				fmt.Fprintln(os.Stderr, "vigilix:", err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"vigilix/internal/bootreport"
+)
+
+const bootReportUsage = "boot-report [--json]"
+
+// runBootReport prints the report of the current boot, e.g. for a morning
+// check mailed from cron. It fails only when no section could be read.
+func runBootReport(args []string) int {
+	fs := flag.NewFlagSet("boot-report", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(os.Stderr, "usage: vigilix "+bootReportUsage)
+		return exitUsage
+	}
+
+	r := bootreport.Generate()
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			fmt.Fprintln(os.Stderr, "vigilix:", err)
+			return exitError
+		}
+	} else {
+		fmt.Print(r.Markdown())
+	}
+	if len(r.Errors) == bootreport.Sections {
+		return exitError
+	}
+	return exitOK
+}
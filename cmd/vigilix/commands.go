@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"vigilix/internal/systemd"
+)
+
+// command is a non-interactive subcommand, e.g. `vigilix restart nginx`.
+type command struct {
+	usage string
+	run   func(args []string) int
+}
+
+var commands = map[string]command{
+	"start":       unitAction("start", "Started", systemd.StartUnit, false),
+	"stop":        unitAction("stop", "Stopped", systemd.StopUnit, true),
+	"restart":     unitAction("restart", "Restarted", systemd.RestartUnit, true),
+	"enable":      unitAction("enable", "Enabled", systemd.EnableUnit, false),
+	"disable":     unitAction("disable", "Disabled", systemd.DisableUnit, true),
+	"failed":      {usage: "failed [--restart] [--yes]", run: runFailed},
+	"watch":       {usage: watchUsage, run: runWatch},
+	"events":      {usage: eventsUsage, run: runEvents},
+	"alerts":      {usage: alertsUsage, run: runAlerts},
+	"history":     {usage: historyUsage, run: runHistory},
+	"maintain":    {usage: maintainUsage, run: runMaintain},
+	"self-update": {usage: selfUpdateUsage, run: runSelfUpdate},
+	"kiosk":       {usage: kioskUsage, run: runKiosk},
+	"replay":      {usage: replayUsage, run: runReplay},
+	"boot-report": {usage: bootReportUsage, run: runBootReport},
+}
+
+// Exit codes of subcommands.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// unitAction builds a subcommand applying fn to one or more units. Actions
+// that take a unit down ask for confirmation first, like the TUI does for
+// batch actions.
+func unitAction(verb, done string, fn func(string) error, confirm bool) command {
+	usage := verb + " <unit>..."
+	if confirm {
+		usage = verb + " [--yes] <unit>..."
+	}
+	return command{usage: usage, run: func(args []string) int {
+		fs := flag.NewFlagSet(verb, flag.ContinueOnError)
+		yes := fs.Bool("yes", false, "do not ask for confirmation")
+		fs.BoolVar(yes, "y", false, "shorthand for --yes")
+		if err := fs.Parse(args); err != nil {
+			return exitUsage
+		}
+		units := fs.Args()
+		if len(units) == 0 {
+			fmt.Fprintln(os.Stderr, "usage: vigilix "+usage)
+			return exitUsage
+		}
+
+		if confirm && !*yes {
+			ok, err := ask(fmt.Sprintf("%s %s?", capitalize(verb), strings.Join(units, ", ")))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "vigilix:", err)
+				return exitUsage
+			}
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Aborted.")
+				return exitError
+			}
+		}
+		return applyEach(verb, done, units, fn)
+	}}
+}
+
+// applyEach runs fn on every unit, reporting each outcome, and fails if any
+// of them failed.
+func applyEach(verb, done string, units []string, fn func(string) error) int {
+	code := exitOK
+	for _, u := range units {
+		if err := fn(u); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s %s: %v\n", verb, u, explainFailure(err))
+			code = exitError
+			continue
+		}
+		fmt.Printf("✓ %s %s\n", done, u)
+	}
+	return code
+}
+
+func runFailed(args []string) int {
+	fs := flag.NewFlagSet("failed", flag.ContinueOnError)
+	restart := fs.Bool("restart", false, "restart every failed unit")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	fs.BoolVar(yes, "y", false, "shorthand for --yes")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	all, err := systemd.ListUnitsFiltered(systemd.Filter{States: []string{"failed"}})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: listing units:", explainFailure(err))
+		return exitError
+	}
+	var failed []systemd.Unit
+	for _, u := range all {
+		if u.ActiveState == "failed" {
+			failed = append(failed, u)
+		}
+	}
+	if len(failed) == 0 {
+		fmt.Println("No units are in the failed state.")
+		return exitOK
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, u := range failed {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", u.Name, u.SubState, u.Description)
+	}
+	tw.Flush()
+	if !*restart {
+		return exitOK
+	}
+
+	names := make([]string, len(failed))
+	for i, u := range failed {
+		names[i] = u.Name
+	}
+	if !*yes {
+		ok, err := ask(fmt.Sprintf("Restart these %d unit(s)?", len(names)))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "vigilix:", err)
+			return exitUsage
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return exitError
+		}
+	}
+	return applyEach("restart", "Restarted", names, systemd.RestartUnit)
+}
+
+// ask asks a yes/no question on the terminal. Without a terminal to answer
+// on it refuses rather than guessing, so scripts must pass --yes.
+func ask(question string) (bool, error) {
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return false, errors.New("not a terminal; pass --yes to confirm")
+	}
+	fmt.Fprint(os.Stderr, question+" [y/N] ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// explainFailure adds a hint when a system-scope action most likely failed
+// for lack of privileges.
+func explainFailure(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && os.Geteuid() != 0 && systemd.CurrentScope() == systemd.ScopeSystem {
+		return err.Error() + " (are you root? try sudo, or --user for your own services)"
+	}
+	return err.Error()
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func printCommandUsage() {
+	fmt.Fprintln(flag.CommandLine.Output(), "\nCommands (without one, the interactive UI starts):")
+	for _, name := range []string{"start", "stop", "restart", "enable", "disable", "failed", "watch", "events", "alerts", "history", "maintain", "kiosk", "replay", "boot-report", "self-update"} {
+		fmt.Fprintln(flag.CommandLine.Output(), "  vigilix [flags] "+commands[name].usage)
+	}
+}
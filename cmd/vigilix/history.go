@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+	"vigilix/internal/history"
+	"vigilix/internal/systemd"
+)
+
+const historyUsage = "history [--since 24h] [--samples] [--json] <unit>"
+
+// historyRecord is one line of `vigilix history`, whatever its kind.
+type historyRecord struct {
+	Time   time.Time       `json:"time"`
+	Type   string          `json:"type"` // "state", "action" or "sample"
+	Event  *history.Event  `json:"event,omitempty"`
+	Audit  *history.Audit  `json:"action,omitempty"`
+	Sample *history.Sample `json:"sample,omitempty"`
+}
+
+// runHistory prints what the history store recorded about a unit. It works
+// without systemd, e.g. on a copy of the state directory.
+func runHistory(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	since := fs.Duration("since", 24*time.Hour, "how far back to look")
+	samples := fs.Bool("samples", false, "include resource samples")
+	asJSON := fs.Bool("json", false, "print one JSON object per line")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: vigilix "+historyUsage)
+		return exitUsage
+	}
+	unit := systemd.UnitName(fs.Arg(0))
+
+	store, err := history.Open(true)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, `vigilix: no history recorded; set "history": true in the config and run the UI`)
+		return exitError
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: opening history:", err)
+		return exitError
+	}
+	defer store.Close()
+
+	from := time.Now().Add(-*since)
+	var records []historyRecord
+	events, err := store.Events(unit, from)
+	if err == nil {
+		for _, e := range events {
+			records = append(records, historyRecord{Time: e.Time, Type: "state", Event: &e})
+		}
+		var audits []history.Audit
+		audits, err = store.Audits(unit, from)
+		for _, a := range audits {
+			records = append(records, historyRecord{Time: a.Time, Type: "action", Audit: &a})
+		}
+	}
+	if err == nil && *samples {
+		var found []history.Sample
+		found, err = store.Samples(unit, from)
+		for _, s := range found {
+			records = append(records, historyRecord{Time: s.Time, Type: "sample", Sample: &s})
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: reading history:", err)
+		return exitError
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, r := range records {
+			enc.Encode(r)
+		}
+		return exitOK
+	}
+	if len(records) == 0 {
+		fmt.Printf("Nothing recorded for %s in the last %s.\n", unit, *since)
+		return exitOK
+	}
+	for _, r := range records {
+		stamp := watchTime.Render(r.Time.Format("2006-01-02 15:04:05"))
+		switch {
+		case r.Event != nil:
+			to := r.Event.To
+			if to == "failed" {
+				to = watchBad.Render(to)
+			}
+			fmt.Printf("%s %s → %s\n", stamp, r.Event.From, to)
+		case r.Audit != nil:
+			if r.Audit.Error != "" {
+				fmt.Printf("%s %s %s\n", stamp, r.Audit.Action, watchBad.Render("failed: "+r.Audit.Error))
+			} else {
+				fmt.Printf("%s %s\n", stamp, watchChange.Render(r.Audit.Action))
+			}
+		case r.Sample != nil:
+			s := r.Sample
+			fmt.Printf("%s cpu %s · memory %d MiB · io %d/%d KiB · net %d/%d KiB\n", stamp, s.CPU.Round(time.Millisecond),
+				s.Memory>>20, s.IORead>>10, s.IOWrite>>10, s.IPIn>>10, s.IPOut>>10)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const kioskUsage = "kiosk [--rotate 15s]"
+
+// runKiosk shows the read-only dashboard for a wall monitor until q is
+// pressed, switching between its pages on a timer.
+func runKiosk(args []string) int {
+	fs := flag.NewFlagSet("kiosk", flag.ContinueOnError)
+	rotate := fs.Duration("rotate", 15*time.Second, "how long each page is shown")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *rotate < time.Second {
+		fmt.Fprintln(os.Stderr, "vigilix: --rotate must be at least 1s")
+		return exitUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: reading config:", err)
+		return exitError
+	}
+	if cfg == nil {
+		defaults := config.Default()
+		cfg = &defaults
+	}
+	if os.Getenv("TERM") == "linux" {
+		cfg.ASCII = true
+	}
+
+	final, err := tea.NewProgram(ui.Guard(ui.NewKiosk(*cfg, *rotate)), tea.WithAltScreen()).Run()
+	if path, crashed, reportErr := ui.CrashReport(final); crashed {
+		if reportErr != nil {
+			fmt.Fprintf(os.Stderr, "vigilix crashed and the crash report could not be saved: %v\n", reportErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "vigilix crashed; a report was saved to %s\nPlease attach it when filing an issue.\n", path)
+		}
+		return exitError
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix:", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"vigilix/internal/config"
+)
+
+// setupLogging sends vigilix's own diagnostics (backend calls, stream
+// restarts, errors) to a file, since the terminal belongs to the interface.
+// Without --log-level and --log-file nothing is recorded; a level alone
+// logs to vigilix.log in the state directory. Writes are unbuffered, so the
+// file is complete even if vigilix is killed.
+func setupLogging(level, path string) error {
+	if level == "" && path == "" {
+		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		return nil
+	}
+
+	var lvl slog.Level
+	if level == "" {
+		level = "info"
+	}
+	if err := lvl.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return fmt.Errorf("unknown log level %q (want debug, info, warn or error)", level)
+	}
+	if path == "" {
+		dir, err := config.StateDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(dir, "vigilix.log")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: lvl})))
+	return nil
+}
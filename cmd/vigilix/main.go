@@ -1,16 +1,156 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/i18n"
+	"vigilix/internal/recording"
+	"vigilix/internal/supervise"
+	"vigilix/internal/systemd"
 	"vigilix/internal/ui"
+	"vigilix/internal/update"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// shutdownTimeout bounds how long exiting waits for background work.
+const shutdownTimeout = 2 * time.Second
+
 func main() {
-	p := tea.NewProgram(ui.NewModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	userScope := flag.Bool("user", false, "manage the user service manager instead of the system one")
+	journalDir := flag.String("journal-dir", "", "browse exported journal files in `dir` read-only (journalctl -D)")
+	notesFile := flag.String("notes", "", "read and write unit notes in `file` (e.g. shared with your team)")
+	setup := flag.Bool("setup", false, "run the setup wizard again and rewrite the config file")
+	ascii := flag.Bool("ascii", false, "draw with plain ASCII and 16 colors (default on the Linux console)")
+	inline := flag.Bool("inline", false, "show only the unit list in 15 rows of the normal screen, e.g. in a tmux pane")
+	screenReader := flag.Bool("screen-reader", false, "render plain sentences and announce changes as lines, for screen readers")
+	version := flag.Bool("version", false, "print the version and exit")
+	logLevel := flag.String("log-level", "", "record diagnostics at `level` (debug, info, warn or error) for bug reports")
+	logFile := flag.String("log-file", "", "write diagnostics to `file` (default vigilix.log in the state directory)")
+	record := flag.String("record", "", "record keys, events and screens to `file` (e.g. session.vgx) for vigilix replay")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: vigilix [flags] [command]\n\nFlags:\n")
+		flag.PrintDefaults()
+		printCommandUsage()
+	}
+	flag.Parse()
+
+	if *version {
+		fmt.Println("vigilix", update.Current())
+		return
+	}
+
+	if err := setupLogging(*logLevel, *logFile); err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: logging:", err)
+		os.Exit(exitUsage)
+	}
+
+	var cmd *command
+	if flag.NArg() > 0 {
+		c, ok := commands[flag.Arg(0)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "vigilix: unknown command %q\n", flag.Arg(0))
+			flag.Usage()
+			os.Exit(exitUsage)
+		}
+		cmd = &c
+	}
+
+	if err := i18n.Load(i18n.Language()); err != nil {
+		fmt.Fprintf(os.Stderr, "vigilix: loading translations: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vigilix: reading config: %v; using defaults\n", err)
+	}
+	// Scripts never get the wizard; they run on the defaults until it is done.
+	if (cfg == nil && err == nil && cmd == nil) || *setup {
+		answers, ok, err := ui.RunWizard()
+		if !ok {
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "vigilix: saving config: %v\n", err)
+		}
+		cfg = &answers
+	}
+	if cfg == nil {
+		defaults := config.Default()
+		cfg = &defaults
+	}
+
+	if *ascii || os.Getenv("TERM") == "linux" {
+		cfg.ASCII = true
+	}
+	if *screenReader {
+		cfg.ScreenReader = true
+	}
+	if *inline {
+		cfg.Inline = true
+	}
+	if *userScope || cfg.Scope == "user" {
+		systemd.SetScope(systemd.ScopeUser)
+	}
+	if *journalDir != "" {
+		systemd.SetJournalDir(*journalDir)
+	}
+	if *notesFile != "" {
+		config.SetNotesPath(*notesFile)
+	}
+
+	// Unknown versions fall back to the most compatible invocations.
+	systemd.DetectVersion()
+	slog.Info("starting", "version", update.Current(), "args", os.Args[1:], "scope", systemd.CurrentScope(), "systemd", systemd.Version())
+
+	if cmd != nil {
+		code := cmd.run(flag.Args()[1:])
+		supervise.Shutdown(shutdownTimeout)
+		os.Exit(code)
+	}
+
+	// Screen reader mode stays in the normal screen so announcements remain
+	// in the scrollback; inline mode so it fits below the prompt.
+	// Focus reports let vigilix slow down while the terminal is in the
+	// background.
+	opts := []tea.ProgramOption{tea.WithReportFocus()}
+	if !cfg.ScreenReader && !cfg.Inline {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	model := ui.Guard(ui.NewModel(*cfg))
+	var rec *recording.Writer
+	if *record != "" {
+		if rec, err = recording.Create(*record, update.Current()); err != nil {
+			fmt.Fprintln(os.Stderr, "vigilix: recording:", err)
+			os.Exit(1)
+		}
+		model = ui.Record(model, rec)
+	}
+	p := tea.NewProgram(model, opts...)
+	final, err := p.Run()
+	supervise.Shutdown(shutdownTimeout)
+	if rec != nil {
+		if err := rec.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, "vigilix: recording:", err)
+		}
+	}
+	if path, crashed, reportErr := ui.CrashReport(final); crashed {
+		if reportErr != nil {
+			fmt.Fprintf(os.Stderr, "vigilix crashed and the crash report could not be saved: %v\n", reportErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "vigilix crashed; a report was saved to %s\nPlease attach it when filing an issue.\n", path)
+		}
+		os.Exit(1)
+	}
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
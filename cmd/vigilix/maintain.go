@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/history"
+)
+
+const maintainUsage = "maintain [--dry-run]"
+
+// runMaintain applies the history retention policy and compacts the
+// database file.
+func runMaintain(args []string) int {
+	fs := flag.NewFlagSet("maintain", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only report what would be removed")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: reading config:", err)
+		return exitError
+	}
+	if cfg == nil {
+		defaults := config.Default()
+		cfg = &defaults
+	}
+
+	store, err := history.Open(false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: opening history:", err)
+		return exitError
+	}
+	st, err := store.Prune(cfg.Retention, time.Now(), *dryRun)
+	store.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: pruning history:", err)
+		return exitError
+	}
+	verb := "Removed"
+	if *dryRun {
+		verb = "Would remove"
+	}
+	fmt.Printf("%s %d state changes, %d actions and %d samples; %d samples thinned to hourly.\n",
+		verb, st.Events, st.Audits, st.Samples, st.Downsampled)
+	if *dryRun {
+		return exitOK
+	}
+
+	before, after, err := history.Compact()
+	if errors.Is(err, history.ErrLocked) {
+		fmt.Fprintln(os.Stderr, "vigilix: the UI is writing the history; try again")
+		return exitError
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: compacting history:", err)
+		return exitError
+	}
+	fmt.Printf("Compacted history.db from %d KiB to %d KiB.\n", before>>10, after>>10)
+	return exitOK
+}
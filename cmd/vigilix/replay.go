@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"vigilix/internal/config"
+	"vigilix/internal/recording"
+	"vigilix/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const replayUsage = "replay [--speed 1] <file.vgx>"
+
+// runReplay plays back a session written with --record.
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	speed := fs.Float64("speed", 1, "playback speed, from 0.25 to 16")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: vigilix "+replayUsage)
+		return exitUsage
+	}
+	if *speed < 0.25 || *speed > 16 {
+		fmt.Fprintln(os.Stderr, "vigilix: --speed must be between 0.25 and 16")
+		return exitUsage
+	}
+
+	header, entries, err := recording.Read(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix:", err)
+		return exitError
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg == nil {
+		defaults := config.Default()
+		cfg = &defaults
+	}
+	if os.Getenv("TERM") == "linux" {
+		cfg.ASCII = true
+	}
+
+	if _, err := tea.NewProgram(ui.NewReplay(*cfg, header, entries, *speed), tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix:", err)
+		return exitError
+	}
+	return exitOK
+}
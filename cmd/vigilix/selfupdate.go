@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"vigilix/internal/update"
+)
+
+const selfUpdateUsage = "self-update [--check] [--yes]"
+
+func runSelfUpdate(args []string) int {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	check := fs.Bool("check", false, "only report whether a newer release exists")
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	fs.BoolVar(yes, "y", false, "shorthand for --yes")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	current := update.Current()
+	release, err := update.Latest(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix:", err)
+		return exitError
+	}
+	if current == "dev" {
+		fmt.Printf("This is a development build; the latest release is %s.\n", release.Tag)
+		return exitOK
+	}
+	if !update.Newer(current, release.Tag) {
+		fmt.Printf("vigilix %s is up to date.\n", current)
+		return exitOK
+	}
+	fmt.Printf("vigilix %s is available (running %s): %s\n", release.Tag, current, release.URL)
+	if *check {
+		return exitOK
+	}
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: locating the running binary:", err)
+		return exitError
+	}
+	if !*yes {
+		ok, err := ask(fmt.Sprintf("Replace %s with %s?", exe, release.Tag))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "vigilix:", err)
+			return exitUsage
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return exitError
+		}
+	}
+	if err := release.Apply(context.Background(), exe); err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix: update failed:", err)
+		if os.IsPermission(err) {
+			fmt.Fprintln(os.Stderr, "  (the binary is not writable by you; try sudo)")
+		}
+		return exitError
+	}
+	fmt.Printf("✓ Updated %s to %s\n", exe, release.Tag)
+	return exitOK
+}
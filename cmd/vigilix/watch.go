@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"vigilix/internal/systemd"
+	"vigilix/internal/watch"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Colors for watch output. They are dropped automatically when stdout is not
+// a terminal.
+var (
+	watchTime   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	watchUnit   = lipgloss.NewStyle().Bold(true)
+	watchGood   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	watchBad    = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	watchChange = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	watchWarn   = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+)
+
+// watchFlags are shared by the watch and events commands.
+type watchFlags struct {
+	interval time.Duration
+	priority string
+}
+
+func (f *watchFlags) register(fs *flag.FlagSet) {
+	fs.DurationVar(&f.interval, "interval", 2*time.Second, "how often unit states are polled")
+	fs.StringVar(&f.priority, "priority", "warning", "report journal lines at this `level` or more severe (err, warning, notice, …)")
+}
+
+// follow runs the watch engine until interrupted, handing every event to fn.
+func follow(opts watch.Options, fn func(watch.Event)) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	events := make(chan watch.Event)
+	done := make(chan error, 1)
+	go func() { done <- watch.Run(ctx, opts, events) }()
+
+	for {
+		select {
+		case e := <-events:
+			fn(e)
+		case err := <-done:
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "vigilix:", explainFailure(err))
+				return exitError
+			}
+			return exitOK
+		}
+	}
+}
+
+const watchUsage = "watch [--interval 2s] [--priority warning] <unit>..."
+
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	var wf watchFlags
+	wf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: vigilix "+watchUsage)
+		return exitUsage
+	}
+	if wf.interval <= 0 {
+		fmt.Fprintln(os.Stderr, "vigilix: --interval must be positive")
+		return exitUsage
+	}
+	priority, err := systemd.ParsePriority(wf.priority)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix:", err)
+		return exitUsage
+	}
+
+	opts := watch.Options{Units: fs.Args(), Interval: wf.interval, MaxPriority: priority, Initial: true}
+	return follow(opts, printEvent)
+}
+
+// printEvent writes one human-readable, colorized line per event.
+func printEvent(e watch.Event) {
+	stamp := watchTime.Render(e.Time.Format("15:04:05"))
+	unit := watchUnit.Render(e.Unit)
+
+	if e.Kind == watch.KindLog {
+		style := watchWarn
+		if e.Priority <= 3 {
+			style = watchBad
+		}
+		fmt.Printf("%s %s %s %s\n", stamp, unit, style.Render(systemd.PriorityName(e.Priority)+":"), e.Message)
+		return
+	}
+
+	to := e.To.String()
+	switch e.To.Active {
+	case "active":
+		to = watchGood.Render(to)
+	case "failed":
+		to = watchBad.Render(to)
+	default:
+		to = watchChange.Render(to)
+	}
+	if e.From == (watch.State{}) {
+		fmt.Printf("%s %s is %s\n", stamp, unit, to)
+		return
+	}
+	fmt.Printf("%s %s %s → %s\n", stamp, unit, e.From, to)
+}
+
+const eventsUsage = "events [--json] [--interval 2s] [--priority warning] [<unit>...]"
+
+// runEvents streams events for the named units, or every unit, for
+// consumption by other programs.
+func runEvents(args []string) int {
+	fs := flag.NewFlagSet("events", flag.ContinueOnError)
+	var wf watchFlags
+	wf.register(fs)
+	asJSON := fs.Bool("json", false, "print one JSON object per line")
+	initial := fs.Bool("initial", false, "report the current state of every unit first")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if wf.interval <= 0 {
+		fmt.Fprintln(os.Stderr, "vigilix: --interval must be positive")
+		return exitUsage
+	}
+	priority, err := systemd.ParsePriority(wf.priority)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vigilix:", err)
+		return exitUsage
+	}
+
+	opts := watch.Options{Units: fs.Args(), Interval: wf.interval, MaxPriority: priority, Initial: *initial}
+	if !*asJSON {
+		return follow(opts, printEvent)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	return follow(opts, func(e watch.Event) {
+		enc.Encode(e)
+	})
+}
@@ -0,0 +1,210 @@
+// Package baseline compares the units on a host with a declared baseline:
+// a YAML manifest listing the units that should exist and whether they
+// should be enabled and running. It is desired-state management for pet
+// servers, small enough to keep next to the config:
+//
+//	units:
+//	  nginx.service: {enabled: true, active: true}
+//	  backup.timer: {enabled: true}
+//	  telnet.socket: {enabled: false, active: false}
+//	strict:
+//	  - "*.service"
+//
+// Units matching a strict pattern that are enabled or running without
+// being listed are reported as extra.
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"vigilix/internal/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is the declared baseline.
+type Manifest struct {
+	Units  map[string]State `yaml:"units"`
+	Strict []string         `yaml:"strict"`
+}
+
+// State is what a unit should look like; nil fields are not checked.
+type State struct {
+	Enabled *bool `yaml:"enabled"`
+	Active  *bool `yaml:"active"`
+}
+
+// Kind classifies a drift.
+type Kind string
+
+const (
+	Missing Kind = "missing" // listed, but no such unit is installed
+	Extra   Kind = "extra"   // not listed, but enabled or running
+	Wrong   Kind = "state"   // listed, in the wrong state
+)
+
+// Drift is one difference between the baseline and the host.
+type Drift struct {
+	Unit string
+	Kind Kind
+	Want string
+	Have string
+	// Fixes are the systemctl verbs ("enable", "stop", ...) that reconcile
+	// the unit, in order. Missing units have none.
+	Fixes []string
+}
+
+// Unit is the observed state of a unit.
+type Unit struct {
+	Name   string
+	Loaded bool
+	Active string // ActiveState
+	File   string // unit file state from list-unit-files, "" if none
+}
+
+// DefaultPath is baseline.yaml in the config directory.
+func DefaultPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "baseline.yaml"), nil
+}
+
+// Load reads the manifest at path. It returns nil without an error when
+// the file does not exist.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, pattern := range m.Strict {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: strict pattern %q: %w", path, pattern, err)
+		}
+	}
+	return &m, nil
+}
+
+// enabledStates are unit file states that pull a unit in at boot or that
+// cannot be disabled, so they count as enabled.
+var enabledStates = map[string]bool{
+	"enabled": true, "enabled-runtime": true, "linked": true, "linked-runtime": true,
+	"alias": true, "static": true, "indirect": true, "generated": true, "transient": true,
+}
+
+// toggleable are the states systemctl enable/disable can change.
+var toggleable = map[string]bool{
+	"enabled": true, "enabled-runtime": true, "disabled": true, "indirect": true,
+}
+
+func running(active string) bool {
+	return active == "active" || active == "activating" || active == "reloading"
+}
+
+// Check compares the manifest with the observed units, sorted by unit
+// name.
+func Check(m *Manifest, units []Unit) []Drift {
+	byName := make(map[string]Unit, len(units))
+	for _, u := range units {
+		byName[u.Name] = u
+	}
+
+	var drifts []Drift
+	for name, want := range m.Units {
+		u, ok := byName[name]
+		if !ok || (!u.Loaded && u.File == "") {
+			drifts = append(drifts, Drift{Unit: name, Kind: Missing, Want: describe(want), Have: "not installed"})
+			continue
+		}
+		if d, drifted := compare(name, want, u); drifted {
+			drifts = append(drifts, d)
+		}
+	}
+	for _, u := range units {
+		if _, listed := m.Units[u.Name]; listed || !m.strict(u.Name) {
+			continue
+		}
+		enabled := u.File == "enabled" || u.File == "enabled-runtime"
+		if !enabled && !running(u.Active) {
+			continue
+		}
+		d := Drift{Unit: u.Name, Kind: Extra, Want: "not listed", Have: have(u)}
+		if toggleable[u.File] && enabled {
+			d.Fixes = append(d.Fixes, "disable")
+		}
+		if running(u.Active) {
+			d.Fixes = append(d.Fixes, "stop")
+		}
+		drifts = append(drifts, d)
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Unit < drifts[j].Unit })
+	return drifts
+}
+
+func compare(name string, want State, u Unit) (Drift, bool) {
+	d := Drift{Unit: name, Kind: Wrong, Want: describe(want), Have: have(u)}
+	drifted := false
+	if want.Enabled != nil && *want.Enabled != enabledStates[u.File] {
+		drifted = true
+		if toggleable[u.File] {
+			if *want.Enabled {
+				d.Fixes = append(d.Fixes, "enable")
+			} else {
+				d.Fixes = append(d.Fixes, "disable")
+			}
+		}
+	}
+	if want.Active != nil && *want.Active != running(u.Active) {
+		drifted = true
+		if *want.Active {
+			d.Fixes = append(d.Fixes, "start")
+		} else {
+			d.Fixes = append(d.Fixes, "stop")
+		}
+	}
+	return d, drifted
+}
+
+func (m *Manifest) strict(name string) bool {
+	for _, pattern := range m.Strict {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(s State) string {
+	var parts []string
+	if s.Enabled != nil {
+		parts = append(parts, map[bool]string{true: "enabled", false: "disabled"}[*s.Enabled])
+	}
+	if s.Active != nil {
+		parts = append(parts, map[bool]string{true: "active", false: "inactive"}[*s.Active])
+	}
+	if len(parts) == 0 {
+		return "installed"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func have(u Unit) string {
+	file := u.File
+	if file == "" {
+		file = "no unit file"
+	}
+	return file + ", " + u.Active
+}
@@ -0,0 +1,197 @@
+// Package bootreport summarizes the current boot: how long it took, which
+// units failed, which were slowest and what the kernel warned about.
+package bootreport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"vigilix/internal/explain"
+	"vigilix/internal/systemd"
+)
+
+const (
+	// Sections is how many parts a report gathers.
+	Sections = 4
+	// slowestCount is how many of the slowest units are listed.
+	slowestCount = 10
+	// kernelLimit bounds the kernel warnings listed.
+	kernelLimit = 50
+)
+
+// Report is the summary of a boot. Sections that could not be read are
+// left empty and explained in Errors.
+type Report struct {
+	Host      string    `json:"host"`
+	Generated time.Time `json:"generated"`
+	BootedAt  time.Time `json:"booted_at"`
+	// Finished is false while units are still starting; Total and the
+	// userspace phase are then incomplete.
+	Finished       bool      `json:"finished"`
+	TotalSeconds   float64   `json:"total_seconds"`
+	Phases         []Phase   `json:"phases"`
+	Failed         []Failure `json:"failed"`
+	Slowest        []Slow    `json:"slowest"`
+	KernelWarnings []Message `json:"kernel_warnings"`
+	Errors         []string  `json:"errors,omitempty"`
+}
+
+// Phase is one part of the boot, as systemd-analyze time splits it.
+type Phase struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// Failure is a unit in the failed state, with why it failed.
+type Failure struct {
+	Unit  string `json:"unit"`
+	Cause string `json:"cause"`
+}
+
+// Slow is a unit and how long it took to start.
+type Slow struct {
+	Unit    string  `json:"unit"`
+	Seconds float64 `json:"seconds"`
+}
+
+// Message is a kernel log line.
+type Message struct {
+	Time     time.Time `json:"time"`
+	Priority string    `json:"priority"`
+	Text     string    `json:"text"`
+}
+
+// Generate gathers the report of the current boot.
+func Generate() Report {
+	r := Report{Generated: time.Now()}
+	r.Host, _ = os.Hostname()
+	fail := func(section string, err error) {
+		// The tools' own message says more than their exit status.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+			line, _, _ := strings.Cut(string(bytes.TrimSpace(exitErr.Stderr)), "\n")
+			err = errors.New(line)
+		}
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", section, err))
+	}
+
+	if b, err := systemd.GetBootTimes(); err != nil {
+		fail("boot times", err)
+	} else {
+		r.BootedAt, r.Finished, r.TotalSeconds = b.Started, b.Finished, b.Total().Seconds()
+		for _, p := range []struct {
+			name string
+			d    time.Duration
+		}{{"firmware", b.Firmware}, {"loader", b.Loader}, {"kernel", b.Kernel}, {"initrd", b.InitRD}, {"userspace", b.Userspace}} {
+			if p.d > 0 {
+				r.Phases = append(r.Phases, Phase{Name: p.name, Seconds: p.d.Seconds()})
+			}
+		}
+	}
+
+	if units, err := systemd.ListUnitsFiltered(systemd.Filter{States: []string{"failed"}}); err != nil {
+		fail("failed units", err)
+	} else {
+		for _, u := range units {
+			if u.ActiveState == "failed" {
+				r.Failed = append(r.Failed, Failure{Unit: u.Name, Cause: explain.Failure(u.Name)})
+			}
+		}
+	}
+
+	if times, err := systemd.Blame(); err != nil {
+		fail("unit start times", err)
+	} else {
+		for _, t := range times[:min(len(times), slowestCount)] {
+			r.Slowest = append(r.Slowest, Slow{Unit: t.Unit, Seconds: t.Time.Seconds()})
+		}
+	}
+
+	if lines, err := systemd.KernelWarnings(kernelLimit); err != nil {
+		fail("kernel warnings", err)
+	} else {
+		for _, l := range lines {
+			text := l.Text
+			if _, msg, ok := strings.Cut(text, "kernel: "); ok {
+				text = msg
+			}
+			r.KernelWarnings = append(r.KernelWarnings, Message{Time: l.Time, Priority: l.Priority, Text: text})
+		}
+	}
+	return r
+}
+
+// Markdown renders the report as Markdown, which also reads well as plain
+// text.
+func (r Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Boot report for %s\n\n", r.Host)
+	fmt.Fprintf(&b, "Generated %s.", r.Generated.Format("2006-01-02 15:04:05"))
+	if !r.BootedAt.IsZero() {
+		fmt.Fprintf(&b, " Booted %s.", r.BootedAt.Format("2006-01-02 15:04:05"))
+	}
+	b.WriteString("\n\n")
+	if len(r.Phases) > 0 {
+		b.WriteString(r.Summary() + "\n\n")
+	}
+
+	fmt.Fprintf(&b, "## Failed units (%d)\n\n", len(r.Failed))
+	for _, f := range r.Failed {
+		fmt.Fprintf(&b, "- %s: %s\n", f.Unit, f.Cause)
+	}
+	if len(r.Failed) == 0 {
+		b.WriteString("None.\n")
+	}
+
+	b.WriteString("\n## Slowest units\n\n")
+	for _, s := range r.Slowest {
+		fmt.Fprintf(&b, "- %s %s\n", Seconds(s.Seconds), s.Unit)
+	}
+
+	fmt.Fprintf(&b, "\n## Kernel warnings (%d)\n\n", len(r.KernelWarnings))
+	for _, m := range r.KernelWarnings {
+		fmt.Fprintf(&b, "- %s %s\n", m.Time.Format("15:04:05"), m.Text)
+	}
+	if len(r.KernelWarnings) == 0 {
+		b.WriteString("None.\n")
+	}
+
+	if len(r.Errors) > 0 {
+		b.WriteString("\n## Not available\n\n")
+		for _, e := range r.Errors {
+			fmt.Fprintf(&b, "- %s\n", e)
+		}
+	}
+	return b.String()
+}
+
+// Summary is the total boot time and its phases in one sentence, e.g.
+// "Boot took 31.2s: kernel 2.5s + initrd 3.1s + userspace 25.6s".
+func (r Report) Summary() string {
+	phases := make([]string, len(r.Phases))
+	for i, p := range r.Phases {
+		phases[i] = p.Name + " " + Seconds(p.Seconds)
+	}
+	s := fmt.Sprintf("Boot took %s: %s", Seconds(r.TotalSeconds), strings.Join(phases, " + "))
+	if !r.Finished {
+		s += " (still starting units)"
+	}
+	return s
+}
+
+// Seconds formats a duration in seconds the way systemd-analyze does,
+// e.g. "712ms", "2.345s" or "1min 2.3s".
+func Seconds(s float64) string {
+	d := time.Duration(s * float64(time.Second))
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.3fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dmin %.1fs", int(d.Minutes()), (d % time.Minute).Seconds())
+}
@@ -0,0 +1,123 @@
+// Package certs reads the expiry of TLS certificates, either served by an
+// endpoint or stored in a PEM file.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"vigilix/internal/supervise"
+)
+
+// How long an endpoint may take to complete the handshake.
+const dialTimeout = 5 * time.Second
+
+// Cert is the result of checking one source.
+type Cert struct {
+	Source   string // host:port or file path
+	Subject  string
+	NotAfter time.Time
+	Err      error
+}
+
+// Left is the time until the certificate expires, negative once it has.
+func (c Cert) Left(now time.Time) time.Duration {
+	return c.NotAfter.Sub(now)
+}
+
+// IsFile reports whether a source names a file rather than an endpoint.
+func IsFile(source string) bool {
+	return strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~/")
+}
+
+// Check reads the leaf certificate of a source.
+func Check(source string) Cert {
+	c := Cert{Source: source}
+	var leaf *x509.Certificate
+	if IsFile(source) {
+		leaf, c.Err = readFile(source)
+	} else {
+		leaf, c.Err = fetch(source)
+	}
+	if leaf != nil {
+		c.Subject = leaf.Subject.CommonName
+		if c.Subject == "" && len(leaf.DNSNames) > 0 {
+			c.Subject = leaf.DNSNames[0]
+		}
+		c.NotAfter = leaf.NotAfter
+	}
+	return c
+}
+
+// CheckAll checks every source of every unit concurrently.
+func CheckAll(sources map[string][]string) map[string][]Cert {
+	out := make(map[string][]Cert, len(sources))
+	var wg sync.WaitGroup
+	for unit, list := range sources {
+		results := make([]Cert, len(list))
+		for i, src := range list {
+			wg.Add(1)
+			supervise.Go("certificate check", func() {
+				defer wg.Done()
+				results[i] = Check(src)
+			})
+		}
+		out[unit] = results
+	}
+	wg.Wait()
+	return out
+}
+
+// fetch completes a handshake to read the served certificate. It does not
+// verify the chain: an expired or self-signed certificate is exactly what
+// should be reported rather than refused.
+func fetch(addr string) (*x509.Certificate, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "443")
+	}
+	host, _, _ := net.SplitHostPort(addr)
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", addr, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	peers := conn.ConnectionState().PeerCertificates
+	if len(peers) == 0 {
+		return nil, errors.New("no certificate presented")
+	}
+	return peers[0], nil
+}
+
+// readFile returns the first certificate in a PEM file.
+func readFile(path string) (*x509.Certificate, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = home + "/" + rest
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no PEM certificate in file")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package compose lists the Docker containers on the host grouped by their
+// Docker Compose project and runs project-level commands. Projects are found
+// from the labels Compose puts on every container it creates, so stopped
+// projects show up as long as their containers exist.
+package compose
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Labels set by Docker Compose.
+const (
+	labelProject     = "com.docker.compose.project"
+	labelService     = "com.docker.compose.service"
+	labelWorkingDir  = "com.docker.compose.project.working_dir"
+	labelConfigFiles = "com.docker.compose.project.config_files"
+)
+
+// Container is one container as `docker ps` reports it.
+type Container struct {
+	ID, Name, Image string
+	State           string // running, exited, paused, …
+	Status          string // e.g. "Up 2 hours (healthy)"
+	Project         string // empty outside Compose
+	Service         string
+}
+
+// Project is a Compose project and its containers.
+type Project struct {
+	Name        string
+	WorkingDir  string
+	ConfigFiles []string
+	Containers  []Container
+}
+
+// Running counts the project's running containers.
+func (p Project) Running() int {
+	n := 0
+	for _, c := range p.Containers {
+		if c.State == "running" {
+			n++
+		}
+	}
+	return n
+}
+
+// psLine is one line of `docker ps --format '{{json .}}'`.
+type psLine struct {
+	ID, Names, Image, State, Status string
+	Labels                          string // comma-separated key=value
+}
+
+func docker(ctx context.Context, args ...string) *exec.Cmd {
+	slog.Debug("exec", "cmd", "docker", "args", args)
+	return exec.CommandContext(ctx, "docker", args...)
+}
+
+// run runs docker and returns its output, with stderr as the error.
+func run(args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, errors.New("docker is not installed")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := docker(context.Background(), args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// List returns the Compose projects sorted by name and the containers that
+// belong to none.
+func List() ([]Project, []Container, error) {
+	out, err := run("ps", "--all", "--no-trunc", "--format", "{{json .}}")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byName := make(map[string]*Project)
+	var standalone []Container
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var l psLine
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, nil, fmt.Errorf("parsing docker ps: %w", err)
+		}
+		labels := parseLabels(l.Labels)
+		c := Container{
+			ID:      l.ID,
+			Name:    l.Names,
+			Image:   l.Image,
+			State:   l.State,
+			Status:  l.Status,
+			Project: labels[labelProject],
+			Service: labels[labelService],
+		}
+		if c.Project == "" {
+			standalone = append(standalone, c)
+			continue
+		}
+		p := byName[c.Project]
+		if p == nil {
+			p = &Project{Name: c.Project, WorkingDir: labels[labelWorkingDir]}
+			if files := labels[labelConfigFiles]; files != "" {
+				p.ConfigFiles = strings.Split(files, ",")
+			}
+			byName[c.Project] = p
+		}
+		p.Containers = append(p.Containers, c)
+	}
+
+	projects := make([]Project, 0, len(byName))
+	for _, p := range byName {
+		sort.Slice(p.Containers, func(i, j int) bool { return p.Containers[i].Service < p.Containers[j].Service })
+		projects = append(projects, *p)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, standalone, scanner.Err()
+}
+
+// parseLabels splits docker's "k=v,k=v" label list. Values holding commas
+// (config_files) are rejoined onto the previous label.
+func parseLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	last := ""
+	for _, part := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok || strings.ContainsAny(k, "/ ") {
+			if last != "" {
+				labels[last] += "," + part
+			}
+			continue
+		}
+		labels[k] = v
+		last = k
+	}
+	return labels
+}
+
+// composeArgs addresses the project by name, and by its files where known
+// so that `up` can recreate missing containers.
+func composeArgs(p Project, args ...string) []string {
+	out := []string{"compose", "--project-name", p.Name}
+	if p.WorkingDir != "" {
+		out = append(out, "--project-directory", p.WorkingDir)
+	}
+	for _, f := range p.ConfigFiles {
+		out = append(out, "--file", f)
+	}
+	return append(out, args...)
+}
+
+// Up creates and starts the project's containers in the background.
+func Up(p Project) error {
+	if len(p.ConfigFiles) == 0 {
+		return fmt.Errorf("compose project %s has no known compose file", p.Name)
+	}
+	_, err := run(composeArgs(p, "up", "--detach")...)
+	return err
+}
+
+// Down stops and removes the project's containers and networks.
+func Down(p Project) error {
+	_, err := run("compose", "--project-name", p.Name, "down")
+	return err
+}
+
+// Restart restarts the project's containers.
+func Restart(p Project) error {
+	_, err := run("compose", "--project-name", p.Name, "restart")
+	return err
+}
+
+// StreamLogs follows the logs of every container in the project, starting
+// with the last 200 lines, until ctx is cancelled or docker exits.
+func StreamLogs(ctx context.Context, project string, out chan<- string) error {
+	cmd := docker(ctx, "compose", "--project-name", project, "logs", "--follow", "--tail", "200", "--no-color")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+		case out <- scanner.Text():
+			continue
+		}
+		break
+	}
+	// Always reap the process; cancelling ctx kills it.
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
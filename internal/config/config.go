@@ -0,0 +1,333 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Config holds the user's preferences, written by the first-run wizard.
+type Config struct {
+	Theme          string   `json:"theme"`
+	Scope          string   `json:"scope"` // "system" or "user"
+	DevKeywords    []string `json:"dev_keywords"`
+	RefreshSeconds int      `json:"refresh_seconds"`
+	Notifications  bool     `json:"notifications"`
+	CheckUpdates   bool     `json:"check_updates"`
+	ASCII          bool     `json:"ascii"`         // no emoji or box drawing
+	ScreenReader   bool     `json:"screen_reader"` // linear text, no panels
+	Inline         bool     `json:"-"`             // list only, in a few rows of the normal screen
+	// StallSeconds flags units activating or deactivating for longer than
+	// this even when their own timeout is longer or infinite; 0 relies on
+	// the unit's TimeoutStartSec/TimeoutStopSec alone.
+	StallSeconds int       `json:"stall_seconds,omitempty"`
+	History      bool      `json:"history,omitempty"`       // keep events, samples and actions in history.db
+	UnitVersions bool      `json:"unit_versions,omitempty"` // keep unit file versions in a git repository
+	Retention    Retention `json:"history_retention,omitzero"`
+	Plugins      []Plugin  `json:"plugins,omitempty"`
+	// LogFiles lists extra log files to tail in a unit's log view, by unit
+	// name, for services that write to files instead of the journal.
+	LogFiles map[string][]string `json:"log_files,omitempty"`
+	Rules    []Rule              `json:"rules,omitempty"`
+	Channels []Channel           `json:"channels,omitempty"`
+	// Databases holds the credentials for the quick stats panels of
+	// database units.
+	Databases []Database `json:"databases,omitempty"`
+	// Certificates lists the TLS certificates to watch by unit name, as
+	// host:port endpoints or PEM file paths; CertWarnDays is how long
+	// before expiry they are flagged (14 by default).
+	Certificates map[string][]string `json:"certificates,omitempty"`
+	CertWarnDays int                 `json:"cert_warn_days,omitempty"`
+	// Probes maps unit names to an HTTP URL whose latency is measured
+	// every ProbeSeconds (10 by default).
+	Probes       map[string]string `json:"probes,omitempty"`
+	ProbeSeconds int               `json:"probe_seconds,omitempty"`
+	// Columns chooses the unit list's columns, in order: "state", "sub",
+	// "enabled", "memory", "uptime" and "description". Empty means state,
+	// uptime and description.
+	Columns []Column `json:"columns,omitempty"`
+	// Compact shows each unit on one line instead of two.
+	Compact bool `json:"compact,omitempty"`
+	// Icons sets the unit list icons. Rules are tried in order before the
+	// built-in ones; the first match wins.
+	Icons []Icon `json:"icons,omitempty"`
+	// Baseline is the YAML manifest of expected units checked for drift;
+	// baseline.yaml in the config directory by default.
+	Baseline string `json:"baseline,omitempty"`
+	// Watch lists glob patterns of the units refreshed at every interval.
+	// When it is set, the rest are only refreshed every FullRefreshSeconds
+	// (300 by default), which keeps hosts with thousands of units cheap.
+	Watch              []string `json:"watch,omitempty"`
+	FullRefreshSeconds int      `json:"full_refresh_seconds,omitempty"`
+	// Drains maps unit names to how they are taken out of service before
+	// a graceful stop.
+	Drains map[string]Drain `json:"drains,omitempty"`
+	// Groups are units restarted together in dependency order, e.g. a
+	// database, a cache, an app and its web server.
+	Groups []Group `json:"groups,omitempty"`
+	// TracePattern is a regular expression finding trace or request IDs in
+	// log lines; its first group, if it has one, is the ID. It defaults to
+	// DefaultTracePattern.
+	TracePattern string `json:"trace_pattern,omitempty"`
+}
+
+// DefaultTracePattern finds IDs logged as trace_id=…, "requestId": "…",
+// X-Correlation-ID: … and the like.
+const DefaultTracePattern = `(?i)(?:trace|request|correlation)[_-]?id["']?\s*[:=]\s*["']?([\w.:-]{6,})`
+
+// Plugin registers an external executable that adds a panel or a per-unit
+// action to the command palette. See package plugin for the protocol.
+type Plugin struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`    // "panel" or "action"
+	Command []string `json:"command"` // program and arguments
+	// Units restricts the plugin to units matching any of these glob
+	// patterns, e.g. "redis*.service". Empty means every unit.
+	Units []string `json:"units,omitempty"`
+}
+
+// Column is one column of the unit list. Width pads or cuts its values to
+// line them up; 0 leaves them at their natural width.
+type Column struct {
+	Name  string `json:"name"`
+	Width int    `json:"width,omitempty"`
+}
+
+// Icon is a list icon for the units matching Pattern: a glob matched
+// against the whole unit name ("redis@*.service"), or else a word that must
+// appear in it, delimited by "-", "_", "." or "@" ("redis"). ASCII is the
+// two-letter stand-in for --ascii; without one the generic icon is shown.
+type Icon struct {
+	Pattern string `json:"pattern"`
+	Emoji   string `json:"emoji"`
+	ASCII   string `json:"ascii,omitempty"`
+}
+
+// Drain is the graceful shutdown of a worker-style unit: send Signal to its
+// processes and run Command, then wait until no connection to Port is
+// open and Probe answers with success, and only then stop the unit. Unset
+// steps are skipped. Waiting gives up after TimeoutSeconds (300 by
+// default) and leaves the unit running.
+type Drain struct {
+	Signal         string   `json:"signal,omitempty"`  // e.g. "SIGUSR1"
+	Command        []string `json:"command,omitempty"` // program and arguments
+	Port           int      `json:"port,omitempty"`    // local TCP port
+	Probe          string   `json:"probe,omitempty"`   // URL that answers once drained
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"`
+}
+
+// Timeout is how long a drain waits for its conditions.
+func (d Drain) Timeout() time.Duration {
+	if d.TimeoutSeconds <= 0 {
+		return 300 * time.Second
+	}
+	return time.Duration(d.TimeoutSeconds) * time.Second
+}
+
+// Group restarts Units one after the other, each only once the one before
+// it is active and passes its probe. When a unit does not become healthy
+// within HealthSeconds (60 by default), the restart stops there; Rollback,
+// if set, is run and the units restarted so far are restarted again, last
+// first, to bring back what it restored.
+type Group struct {
+	Name          string   `json:"name"`
+	Units         []string `json:"units"`
+	Rollback      []string `json:"rollback,omitempty"` // program and arguments
+	HealthSeconds int      `json:"health_seconds,omitempty"`
+}
+
+// HealthTimeout is how long each unit of the group gets to become healthy.
+func (g Group) HealthTimeout() time.Duration {
+	if g.HealthSeconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(g.HealthSeconds) * time.Second
+}
+
+// Retention limits how long the history store keeps records, in days.
+// Zero fields use the defaults.
+type Retention struct {
+	EventsDays  int `json:"events_days,omitempty"`  // state changes and actions (90)
+	SamplesDays int `json:"samples_days,omitempty"` // samples at full resolution (7)
+	HourlyDays  int `json:"hourly_days,omitempty"`  // samples thinned to one per hour (90)
+}
+
+func days(n, fallback int) time.Duration {
+	if n <= 0 {
+		n = fallback
+	}
+	return time.Duration(n) * 24 * time.Hour
+}
+
+// Events is how long state changes and actions are kept.
+func (r Retention) Events() time.Duration { return days(r.EventsDays, 90) }
+
+// Samples is how long samples are kept at full resolution.
+func (r Retention) Samples() time.Duration { return days(r.SamplesDays, 7) }
+
+// Hourly is how long thinned samples are kept; never shorter than Samples.
+func (r Retention) Hourly() time.Duration { return max(days(r.HourlyDays, 90), r.Samples()) }
+
+// Rule raises an alert when a unit matching Units meets every condition
+// that is set. See package notify for how rules are evaluated.
+type Rule struct {
+	Name  string   `json:"name"`
+	Units []string `json:"units,omitempty"` // glob patterns; empty means every unit
+	// Conditions.
+	State    string `json:"state,omitempty"`     // active state, e.g. "failed"
+	Restarts int    `json:"restarts,omitempty"`  // restarted at least this often
+	MemoryMB int    `json:"memory_mb,omitempty"` // using at least this much memory
+	Log      string `json:"log,omitempty"`       // regular expression on journal lines
+	// Channels names the channels alerts are sent to.
+	Channels []string `json:"channels,omitempty"`
+}
+
+// Database tells vigilix how to query a database unit for its quick stats
+// panel. Socket is a Unix socket path (for PostgreSQL also its directory) or
+// host:port; empty uses the server's default.
+type Database struct {
+	Units    []string `json:"units"`          // glob patterns, e.g. "postgresql*"
+	Kind     string   `json:"kind,omitempty"` // "postgres", "mysql", "redis" or "mongo"; guessed from the unit name
+	Socket   string   `json:"socket,omitempty"`
+	User     string   `json:"user,omitempty"`
+	Password string   `json:"password,omitempty"`
+	Database string   `json:"database,omitempty"`
+}
+
+// Channel is a destination for alerts.
+type Channel struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`              // "desktop", "webhook", "command", "email", "ntfy" or "gotify"
+	URL     string   `json:"url,omitempty"`     // webhook, ntfy topic or gotify server
+	Command []string `json:"command,omitempty"` // command: program and arguments
+
+	// Push services. Token is an ntfy access token or a gotify app token;
+	// Priority is the service's message priority (ntfy 1-5, gotify 0-10).
+	Token    string `json:"token,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+
+	// Email. Subject and Body are Go templates over the alert's Rule, Unit,
+	// Time and Message; they default to a one-line summary.
+	SMTP     string   `json:"smtp,omitempty"` // server as host:port; port 465 uses TLS
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+	Subject  string   `json:"subject,omitempty"`
+	Body     string   `json:"body,omitempty"`
+}
+
+// DefaultDevKeywords are the name fragments Dev Mode filters on unless the
+// config overrides them.
+var DefaultDevKeywords = []string{
+	"docker", "mongo", "postgres", "mysql", "redis", "nginx", "apache", "node",
+	"python", "go", "java", "php", "ruby", "rust", "app", "api", "service",
+	"web", "worker", "db",
+}
+
+// Default returns the configuration used when no config file exists.
+func Default() Config {
+	return Config{
+		Theme:          "dracula",
+		Scope:          "system",
+		DevKeywords:    DefaultDevKeywords,
+		RefreshSeconds: 5,
+		Notifications:  true,
+	}
+}
+
+// RefreshInterval is how often the unit list is refreshed in the background.
+func (c Config) RefreshInterval() time.Duration {
+	if c.RefreshSeconds <= 0 {
+		return time.Duration(Default().RefreshSeconds) * time.Second
+	}
+	return time.Duration(c.RefreshSeconds) * time.Second
+}
+
+// FullRefreshInterval is how often every unit is refreshed when a watch
+// list is set.
+func (c Config) FullRefreshInterval() time.Duration {
+	if c.FullRefreshSeconds <= 0 {
+		return 300 * time.Second
+	}
+	return time.Duration(c.FullRefreshSeconds) * time.Second
+}
+
+// TraceRegexp compiles the trace ID pattern.
+func (c Config) TraceRegexp() (*regexp.Regexp, error) {
+	if c.TracePattern == "" {
+		return regexp.MustCompile(DefaultTracePattern), nil
+	}
+	return regexp.Compile(c.TracePattern)
+}
+
+// StallThreshold is how long a unit may take to start or stop before it is
+// flagged as stalled regardless of its own timeout; zero means no limit.
+func (c Config) StallThreshold() time.Duration {
+	return time.Duration(max(c.StallSeconds, 0)) * time.Second
+}
+
+// CertWarning returns how long before expiry a certificate is flagged.
+func (c Config) CertWarning() time.Duration {
+	days := c.CertWarnDays
+	if days <= 0 {
+		days = 14
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// ProbeInterval returns how often HTTP probes run.
+func (c Config) ProbeInterval() time.Duration {
+	if c.ProbeSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.ProbeSeconds) * time.Second
+}
+
+// Path returns the location of the config file.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load reads the config file. It returns nil without an error when the file
+// does not exist yet, i.e. on first run. Fields missing from the file keep
+// their defaults.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := Default()
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Save writes c to the config file.
+func Save(c Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, data)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// notesPath overrides the default notes location, e.g. with a file shared
+// by a team on a network mount.
+var notesPath string
+
+// SetNotesPath stores unit notes in path instead of the state directory.
+func SetNotesPath(path string) {
+	notesPath = path
+}
+
+func notesFile() (string, error) {
+	if notesPath != "" {
+		return notesPath, nil
+	}
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "notes.json"), nil
+}
+
+// LoadNotes returns the free-text notes attached to units, keyed by unit name.
+func LoadNotes() (map[string]string, error) {
+	path, err := notesFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	notes := map[string]string{}
+	if err := json.Unmarshal(data, &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// SetNote attaches a note to a unit, or removes it when note is blank. The
+// file is re-read first so concurrent edits to other units in a shared file
+// are kept. It returns the notes as written.
+func SetNote(unit, note string) (map[string]string, error) {
+	notes, err := LoadNotes()
+	if err != nil {
+		return nil, err
+	}
+	if note = strings.TrimSpace(note); note == "" {
+		delete(notes, unit)
+	} else {
+		notes[unit] = note
+	}
+
+	path, err := notesFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return notes, WriteFileAtomic(path, data)
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Session is the UI state saved on exit and restored on the next launch.
+type Session struct {
+	SelectedUnit  string `json:"selected_unit"`
+	ViewMode      string `json:"view_mode"`
+	ContentPane   bool   `json:"content_pane"`
+	DevMode       bool   `json:"dev_mode"`
+	Filter        string `json:"filter"`
+	ContentOffset int    `json:"content_offset"`
+}
+
+func sessionPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session.json"), nil
+}
+
+// LoadSession reads the last saved session. It returns nil without an error
+// when no session has been saved yet.
+func LoadSession() (*Session, error) {
+	path, err := sessionPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveSession writes s to the state directory.
+func SaveSession(s Session) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, data)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// How many measurements are kept per unit.
+const timingHistory = 20
+
+// Timing is one measured start, stop or restart of a unit.
+type Timing struct {
+	Action       string        `json:"action"`
+	At           time.Time     `json:"at"`
+	Total        time.Duration `json:"total"`
+	Queued       time.Duration `json:"queued"`
+	Deactivating time.Duration `json:"deactivating,omitempty"`
+	Activating   time.Duration `json:"activating,omitempty"`
+}
+
+func timingsFile() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "timings.json"), nil
+}
+
+// LoadTimings returns the recorded action timings per unit, oldest first.
+func LoadTimings() (map[string][]Timing, error) {
+	path, err := timingsFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string][]Timing{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	timings := map[string][]Timing{}
+	if err := json.Unmarshal(data, &timings); err != nil {
+		return nil, err
+	}
+	return timings, nil
+}
+
+// AddTiming records a measurement for unit, dropping the oldest beyond the
+// history limit. It returns the timings as written.
+func AddTiming(unit string, t Timing) (map[string][]Timing, error) {
+	timings, err := LoadTimings()
+	if err != nil {
+		return nil, err
+	}
+	history := append(timings[unit], t)
+	if len(history) > timingHistory {
+		history = history[len(history)-timingHistory:]
+	}
+	timings[unit] = history
+
+	path, err := timingsFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return timings, WriteFileAtomic(path, data)
+}
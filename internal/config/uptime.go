@@ -0,0 +1,130 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// UptimeRetention is how far back unit availability is remembered.
+const UptimeRetention = 30 * 24 * time.Hour
+
+// Span is a stretch of time a unit was continuously observed up or down.
+type Span struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Up   bool      `json:"up"`
+}
+
+// Uptime holds the observed spans of every unit, oldest first. Time nobody
+// was watching is not covered by any span and so counts neither way.
+type Uptime map[string][]Span
+
+func uptimeFile() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "uptime.json"), nil
+}
+
+// LoadUptime returns the recorded availability history.
+func LoadUptime() (Uptime, error) {
+	path, err := uptimeFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Uptime{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uptime := Uptime{}
+	if err := json.Unmarshal(data, &uptime); err != nil {
+		return nil, err
+	}
+	return uptime, nil
+}
+
+// SaveUptime writes the history, dropping what is older than the retention.
+func SaveUptime(u Uptime, now time.Time) error {
+	path, err := uptimeFile()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(u.Pruned(now))
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, data)
+}
+
+// Pruned returns the history without the spans older than the retention.
+func (u Uptime) Pruned(now time.Time) Uptime {
+	cutoff := now.Add(-UptimeRetention)
+	kept := make(Uptime, len(u))
+	for unit, spans := range u {
+		i := 0
+		for i < len(spans) && spans[i].To.Before(cutoff) {
+			i++
+		}
+		if i < len(spans) {
+			kept[unit] = spans[i:]
+		}
+	}
+	return kept
+}
+
+// Observe records that unit was up or down at now. It extends the last span
+// when the state is unchanged and the previous observation is at most
+// maxGap old; otherwise a new span starts. Spans are never modified in
+// place, so slices handed out earlier stay valid.
+func (u Uptime) Observe(unit string, up bool, now time.Time, maxGap time.Duration) {
+	spans := u[unit]
+	if n := len(spans); n > 0 && now.Sub(spans[n-1].To) <= maxGap {
+		last := spans[n-1]
+		spans = slices.Clip(spans[:n-1])
+		if last.Up == up {
+			last.To = now
+			u[unit] = append(spans, last)
+			return
+		}
+		// The change happened some time since the last observation; the
+		// gap is counted in the old state.
+		last.To = now
+		u[unit] = append(spans, last, Span{From: now, To: now, Up: up})
+		return
+	}
+	u[unit] = append(slices.Clip(spans), Span{From: now, To: now, Up: up})
+}
+
+// Availability is the share of the observed time within window before now
+// that the unit was up, and how much time was observed at all.
+func Availability(spans []Span, window time.Duration, now time.Time) (ratio float64, observed time.Duration) {
+	start := now.Add(-window)
+	var up time.Duration
+	for _, s := range spans {
+		from, to := s.From, s.To
+		if to.Before(start) {
+			continue
+		}
+		if from.Before(start) {
+			from = start
+		}
+		d := to.Sub(from)
+		observed += d
+		if s.Up {
+			up += d
+		}
+	}
+	if observed == 0 {
+		return 0, 0
+	}
+	return float64(up) / float64(observed), observed
+}
@@ -0,0 +1,318 @@
+// Package dbstats reads a few health figures from the databases behind
+// recognized units: connections, slow queries and memory. PostgreSQL, MySQL
+// and MongoDB are queried through their command-line clients, Redis over its
+// own protocol, all on the local socket unless configured otherwise.
+package dbstats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+)
+
+// Supported database kinds.
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	Redis    = "redis"
+	Mongo    = "mongo"
+)
+
+// How long a query may take before it is abandoned.
+const timeout = 10 * time.Second
+
+// Stat is one labelled figure.
+type Stat struct {
+	Label, Value string
+}
+
+// kindFragments guesses the kind from the unit name, with the same name
+// fragments the unit list picks icons by.
+var kindFragments = []struct {
+	kind      string
+	fragments []string
+}{
+	{Postgres, []string{"postgres", "psql"}},
+	{MySQL, []string{"mysql", "mariadb"}},
+	{Redis, []string{"redis"}},
+	{Mongo, []string{"mongo"}},
+}
+
+// KindOf returns the database kind a unit name suggests, or "".
+func KindOf(unit string) string {
+	unit = strings.ToLower(unit)
+	for _, k := range kindFragments {
+		for _, f := range k.fragments {
+			if strings.Contains(unit, f) {
+				return k.kind
+			}
+		}
+	}
+	return ""
+}
+
+// Find returns the configured database for a unit.
+func Find(dbs []config.Database, unit string) (config.Database, bool) {
+	for _, d := range dbs {
+		for _, pattern := range d.Units {
+			if ok, _ := path.Match(pattern, unit); ok {
+				return d, true
+			}
+		}
+	}
+	return config.Database{}, false
+}
+
+// Kind returns the configured kind, or the one guessed from the unit name.
+func Kind(d config.Database, unit string) string {
+	if d.Kind != "" {
+		return d.Kind
+	}
+	return KindOf(unit)
+}
+
+// Collect queries the database behind unit.
+func Collect(d config.Database, unit string) ([]Stat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stats []Stat
+	var err error
+	switch kind := Kind(d, unit); kind {
+	case Postgres:
+		stats, err = postgresStats(ctx, d)
+	case MySQL:
+		stats, err = mysqlStats(ctx, d)
+	case Redis:
+		stats, err = redisStats(ctx, d)
+	case Mongo:
+		stats, err = mongoStats(ctx, d)
+	case "":
+		return nil, fmt.Errorf("cannot tell what database %s runs; set its kind", unit)
+	default:
+		return nil, fmt.Errorf("unknown database kind %q", kind)
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("no answer within %s", timeout)
+	}
+	return stats, err
+}
+
+// client runs a database's command-line client and returns its output.
+func client(ctx context.Context, env []string, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s is not installed", name)
+	}
+	// Arguments can carry credentials, so only the client is logged.
+	slog.Debug("exec", "cmd", name)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// hostPort splits a host:port socket setting; Unix socket paths contain a
+// slash.
+func hostPort(socket string) (host, port string, ok bool) {
+	if socket == "" || strings.Contains(socket, "/") {
+		return "", "", false
+	}
+	i := strings.LastIndex(socket, ":")
+	if i < 0 {
+		return socket, "", true
+	}
+	return socket[:i], socket[i+1:], true
+}
+
+func postgresStats(ctx context.Context, d config.Database) ([]Stat, error) {
+	var env []string
+	if host, port, ok := hostPort(d.Socket); ok {
+		env = append(env, "PGHOST="+host)
+		if port != "" {
+			env = append(env, "PGPORT="+port)
+		}
+	} else if d.Socket != "" {
+		dir := d.Socket
+		if strings.Contains(path.Base(dir), ".s.PGSQL.") {
+			dir = path.Dir(dir)
+		}
+		env = append(env, "PGHOST="+dir)
+	}
+	if d.User != "" {
+		env = append(env, "PGUSER="+d.User)
+	}
+	if d.Password != "" {
+		env = append(env, "PGPASSWORD="+d.Password)
+	}
+	database := d.Database
+	if database == "" {
+		database = "postgres"
+	}
+	env = append(env, "PGDATABASE="+database, "PGCONNECT_TIMEOUT=5")
+
+	const query = `SELECT
+  (SELECT count(*) FROM pg_stat_activity WHERE backend_type = 'client backend'),
+  current_setting('max_connections'),
+  (SELECT count(*) FROM pg_stat_activity WHERE state = 'active' AND now() - query_start > interval '5 seconds'),
+  (SELECT coalesce(max(extract(epoch FROM now() - query_start))::int, 0) FROM pg_stat_activity WHERE state = 'active' AND backend_type = 'client backend'),
+  current_setting('shared_buffers'),
+  current_setting('work_mem'),
+  pg_size_pretty(pg_database_size(current_database()))`
+	out, err := client(ctx, env, "psql", "-X", "-A", "-t", "-F", "|", "-c", query)
+	if err != nil {
+		return nil, err
+	}
+	f := strings.Split(strings.TrimSpace(out), "|")
+	if len(f) != 7 {
+		return nil, fmt.Errorf("unexpected psql output %q", strings.TrimSpace(out))
+	}
+	return []Stat{
+		{"Connections", f[0] + " of " + f[1]},
+		{"Queries running > 5s", f[2]},
+		{"Longest running query", f[3] + "s"},
+		{"Shared buffers", f[4]},
+		{"Work memory", f[5] + " per operation"},
+		{"Database size", f[6] + " (" + database + ")"},
+	}, nil
+}
+
+func mysqlStats(ctx context.Context, d config.Database) ([]Stat, error) {
+	var env []string
+	args := []string{"-N", "-B", "--connect-timeout=5"}
+	if host, port, ok := hostPort(d.Socket); ok {
+		args = append(args, "--protocol=TCP", "--host="+host)
+		if port != "" {
+			args = append(args, "--port="+port)
+		}
+	} else if d.Socket != "" {
+		args = append(args, "--socket="+d.Socket)
+	}
+	if d.User != "" {
+		args = append(args, "--user="+d.User)
+	}
+	if d.Password != "" {
+		env = append(env, "MYSQL_PWD="+d.Password)
+	}
+	args = append(args, "-e", `SHOW GLOBAL STATUS WHERE Variable_name IN ('Threads_connected', 'Threads_running', 'Max_used_connections', 'Slow_queries', 'Questions', 'Uptime');
+SHOW GLOBAL VARIABLES WHERE Variable_name IN ('max_connections', 'long_query_time', 'innodb_buffer_pool_size')`)
+	out, err := client(ctx, env, "mysql", args...)
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		if name, value, ok := strings.Cut(line, "\t"); ok {
+			v[strings.ToLower(name)] = value
+		}
+	}
+	if v["threads_connected"] == "" {
+		return nil, fmt.Errorf("unexpected mysql output %q", strings.TrimSpace(out))
+	}
+	return []Stat{
+		{"Connections", v["threads_connected"] + " of " + v["max_connections"] + " (peak " + v["max_used_connections"] + ")"},
+		{"Running threads", v["threads_running"]},
+		{"Slow queries", v["slow_queries"] + " since start (over " + v["long_query_time"] + "s)"},
+		{"Queries", v["questions"] + " in " + uptime(v["uptime"])},
+		{"InnoDB buffer pool", bytesOf(v["innodb_buffer_pool_size"])},
+	}, nil
+}
+
+func mongoStats(ctx context.Context, d config.Database) ([]Stat, error) {
+	uri := "mongodb://"
+	switch {
+	case d.Socket == "":
+		uri += "127.0.0.1:27017"
+	case strings.Contains(d.Socket, "/"):
+		uri += urlEscape(d.Socket)
+	default:
+		uri += d.Socket
+	}
+	uri += "/" + d.Database + "?serverSelectionTimeoutMS=5000"
+
+	// Credentials in the URI would show on mongosh's command line to every
+	// local user, so the script logs in with them from its environment,
+	// against the URI's database like a URI login would.
+	var env []string
+	if d.User != "" {
+		authDB := d.Database
+		if authDB == "" {
+			authDB = "admin"
+		}
+		env = append(env, "VIGILIX_MONGO_USER="+d.User, "VIGILIX_MONGO_PASSWORD="+d.Password, "VIGILIX_MONGO_AUTHDB="+authDB)
+	}
+
+	const script = `const e = process.env;
+if (e.VIGILIX_MONGO_USER) db.getSiblingDB(e.VIGILIX_MONGO_AUTHDB).auth(e.VIGILIX_MONGO_USER, e.VIGILIX_MONGO_PASSWORD);
+const s = db.serverStatus();
+const slow = db.currentOp({active: true, secs_running: {$gte: 5}}).inprog.length;
+print([s.connections.current, s.connections.current + s.connections.available, slow, s.mem.resident, s.mem.virtual, s.opcounters.query, s.uptime].join("|"))`
+	out, err := client(ctx, env, "mongosh", "--quiet", uri, "--eval", script)
+	if err != nil {
+		return nil, err
+	}
+	f := strings.Split(strings.TrimSpace(out), "|")
+	if len(f) != 7 {
+		return nil, fmt.Errorf("unexpected mongosh output %q", strings.TrimSpace(out))
+	}
+	return []Stat{
+		{"Connections", f[0] + " of " + f[1]},
+		{"Operations running > 5s", f[2]},
+		{"Resident memory", f[3] + " MiB"},
+		{"Virtual memory", f[4] + " MiB"},
+		{"Queries", f[5] + " in " + uptime(f[6])},
+	}, nil
+}
+
+// uptime formats a number of seconds as a duration.
+func uptime(secs string) string {
+	d, err := time.ParseDuration(secs + "s")
+	if err != nil {
+		return secs + "s"
+	}
+	return d.Round(time.Minute).String()
+}
+
+// bytesOf formats a byte count given as text.
+func bytesOf(n string) string {
+	var v float64
+	if _, err := fmt.Sscan(n, &v); err != nil {
+		return n
+	}
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", v, units[i])
+}
+
+// urlEscape percent-encodes everything but unreserved characters, as
+// MongoDB URIs require for socket paths.
+func urlEscape(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package dbstats
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"vigilix/internal/config"
+)
+
+// redisStats speaks just enough RESP to AUTH and read INFO and SLOWLOG LEN.
+func redisStats(ctx context.Context, d config.Database) ([]Stat, error) {
+	network, addr := "tcp", "127.0.0.1:6379"
+	if d.Socket != "" {
+		addr = d.Socket
+		if strings.Contains(addr, "/") {
+			network = "unix"
+		}
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	r := bufio.NewReader(conn)
+
+	if d.Password != "" {
+		args := []string{"AUTH", d.Password}
+		if d.User != "" {
+			args = []string{"AUTH", d.User, d.Password}
+		}
+		if _, err := redisCall(conn, r, args...); err != nil {
+			return nil, err
+		}
+	}
+	info, err := redisCall(conn, r, "INFO")
+	if err != nil {
+		return nil, err
+	}
+	slow, err := redisCall(conn, r, "SLOWLOG", "LEN")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+			v[name] = value
+		}
+	}
+	memory := v["used_memory_human"]
+	if peak := v["used_memory_peak_human"]; peak != "" {
+		memory += " (peak " + peak + ")"
+	}
+	if limit := v["maxmemory_human"]; limit != "" && v["maxmemory"] != "0" {
+		memory += " of " + limit
+	}
+	return []Stat{
+		{"Connections", v["connected_clients"] + " (" + v["blocked_clients"] + " blocked, " + v["rejected_connections"] + " rejected)"},
+		{"Slow log entries", slow},
+		{"Memory", memory},
+		{"Operations/s", v["instantaneous_ops_per_sec"]},
+		{"Keys evicted", v["evicted_keys"]},
+		{"Uptime", uptime(v["uptime_in_seconds"])},
+	}, nil
+}
+
+// redisCall sends a command and returns its reply as text.
+func redisCall(w io.Writer, r *bufio.Reader, args ...string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return "", err
+	}
+
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", errors.New("empty reply from redis")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", errors.New("redis: " + line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return "", nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	}
+	return "", fmt.Errorf("unexpected reply from redis: %q", line)
+}
@@ -0,0 +1,134 @@
+// Package drain takes a worker-style unit out of service gracefully before
+// stopping it: it asks the unit to stop taking work, waits until it has
+// finished what it has, and only then stops it.
+package drain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/probe"
+	"vigilix/internal/systemd"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// pollInterval is how often the conditions are checked while waiting.
+const pollInterval = 2 * time.Second
+
+// Step is one thing done while draining, for the report.
+type Step struct {
+	At   time.Time
+	Text string
+}
+
+// Run drains unit as d describes and stops it. The steps taken are
+// returned along with the error that ended the drain, if any; when the
+// conditions are not met in time the unit is left running.
+func Run(ctx context.Context, unit string, d config.Drain) ([]Step, error) {
+	var steps []Step
+	note := func(format string, args ...any) {
+		steps = append(steps, Step{At: time.Now(), Text: fmt.Sprintf(format, args...)})
+	}
+
+	if d.Signal != "" {
+		if err := systemd.KillUnit(unit, d.Signal); err != nil {
+			return steps, fmt.Errorf("sending %s: %w", d.Signal, err)
+		}
+		note("Sent %s", d.Signal)
+	}
+	if len(d.Command) > 0 {
+		if err := runCommand(ctx, unit, d.Command); err != nil {
+			return steps, err
+		}
+		note("Ran %s", strings.Join(d.Command, " "))
+	}
+
+	if d.Port > 0 || d.Probe != "" {
+		start := time.Now()
+		wait, cancel := context.WithTimeout(ctx, d.Timeout())
+		defer cancel()
+		for {
+			pending, err := conditions(wait, d)
+			if err != nil {
+				return steps, err
+			}
+			if pending == "" {
+				note("Drained after %s", time.Since(start).Round(time.Second))
+				break
+			}
+			select {
+			case <-wait.Done():
+				if ctx.Err() != nil {
+					return steps, ctx.Err()
+				}
+				return steps, fmt.Errorf("still %s after %s; %s was left running", pending, d.Timeout(), unit)
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+
+	if err := systemd.StopUnit(unit); err != nil {
+		return steps, fmt.Errorf("stopping: %w", err)
+	}
+	note("Stopped %s", unit)
+	return steps, nil
+}
+
+// runCommand runs the pre-stop command with the unit's name in
+// VIGILIX_UNIT.
+func runCommand(ctx context.Context, unit string, command []string) error {
+	slog.Debug("exec", "drain", unit, "cmd", command)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), "VIGILIX_UNIT="+unit)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %w: %s", command[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", command[0], err)
+	}
+	return nil
+}
+
+// conditions describes what the drain is still waiting for, or returns ""
+// once every condition holds.
+func conditions(ctx context.Context, d config.Drain) (string, error) {
+	if d.Port > 0 {
+		n, err := openConnections(ctx, d.Port)
+		if err != nil {
+			return "", fmt.Errorf("counting connections: %w", err)
+		}
+		if n > 0 {
+			return fmt.Sprintf("%d connections open on port %d", n, d.Port), nil
+		}
+	}
+	if d.Probe != "" {
+		if r := probe.Run(ctx, d.Probe); !r.OK() {
+			return fmt.Sprintf("waiting for %s (%v)", d.Probe, r.Err), nil
+		}
+	}
+	return "", nil
+}
+
+// openConnections counts the established TCP connections to a local port.
+func openConnections(ctx context.Context, port int) (int, error) {
+	conns, err := net.ConnectionsWithContext(ctx, "tcp")
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, c := range conns {
+		if c.Laddr.Port == uint32(port) && c.Status == "ESTABLISHED" {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,142 @@
+package explain
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// execErrors maps the exit codes systemd itself uses when it fails to set up
+// a service process, with a hint at the usual cause. 1–7 are the LSB codes
+// that init scripts and many services follow.
+var execErrors = map[int]struct{ name, hint string }{
+	1:   {"FAILURE", "generic or unspecified error"},
+	2:   {"INVALIDARGUMENT", "invalid or excess arguments"},
+	3:   {"NOTIMPLEMENTED", "unimplemented feature"},
+	4:   {"NOPERMISSION", "the user has insufficient privileges"},
+	5:   {"NOTINSTALLED", "the program is not installed"},
+	6:   {"NOTCONFIGURED", "the program is not configured"},
+	7:   {"NOTRUNNING", "the program is not running"},
+	200: {"CHDIR", "WorkingDirectory does not exist or is not accessible"},
+	201: {"NICE", "the Nice= level could not be set"},
+	202: {"FDS", "file descriptors could not be closed or passed"},
+	203: {"EXEC", "ExecStart binary not found or not executable"},
+	204: {"MEMORY", "systemd ran out of memory setting up the process"},
+	205: {"LIMITS", "resource limits (Limit*=) could not be applied"},
+	206: {"OOM_ADJUST", "OOMScoreAdjust= could not be applied"},
+	207: {"SIGNAL_MASK", "the signal mask could not be set"},
+	208: {"STDIN", "standard input could not be set up"},
+	209: {"STDOUT", "standard output could not be set up"},
+	210: {"CHROOT", "RootDirectory could not be entered"},
+	211: {"IOPRIO", "IOSchedulingClass=/IOSchedulingPriority= could not be applied"},
+	212: {"TIMERSLACK", "TimerSlackNSec= could not be applied"},
+	213: {"SECUREBITS", "SecureBits= could not be applied"},
+	214: {"SETSCHEDULER", "CPUSchedulingPolicy= could not be applied"},
+	215: {"CPUAFFINITY", "CPUAffinity= could not be applied"},
+	216: {"GROUP", "Group= does not exist"},
+	217: {"USER", "User= does not exist"},
+	218: {"CAPABILITIES", "capabilities could not be applied"},
+	219: {"CGROUP", "the control group could not be set up"},
+	220: {"SETSID", "the process session could not be created"},
+	221: {"CONFIRM", "execution was cancelled at the confirmation prompt"},
+	222: {"STDERR", "standard error could not be set up"},
+	224: {"PAM", "the PAM session could not be set up"},
+	225: {"NETWORK", "PrivateNetwork= could not be set up"},
+	226: {"NAMESPACE", "namespace setup failed (check ReadWritePaths=, PrivateTmp= and friends)"},
+	227: {"NO_NEW_PRIVILEGES", "NoNewPrivileges could not be applied"},
+	228: {"SECCOMP", "the system call filter could not be installed"},
+	229: {"SELINUX_CONTEXT", "the SELinux context could not be applied"},
+	230: {"PERSONALITY", "Personality= could not be applied"},
+	231: {"APPARMOR_PROFILE", "the AppArmor profile could not be applied"},
+	232: {"ADDRESS_FAMILIES", "RestrictAddressFamilies= could not be applied"},
+	233: {"RUNTIME_DIRECTORY", "RuntimeDirectory could not be created"},
+	235: {"CHOWN", "socket ownership could not be changed"},
+	236: {"SMACK_PROCESS_LABEL", "the SMACK label could not be applied"},
+	237: {"KEYRING", "the kernel keyring could not be set up"},
+	238: {"STATE_DIRECTORY", "StateDirectory could not be created"},
+	239: {"CACHE_DIRECTORY", "CacheDirectory could not be created"},
+	240: {"LOGS_DIRECTORY", "LogsDirectory could not be created"},
+	241: {"CONFIGURATION_DIRECTORY", "ConfigurationDirectory could not be created"},
+	242: {"NUMA_POLICY", "NUMAPolicy= could not be applied"},
+	243: {"CREDENTIALS", "LoadCredential= or SetCredential= failed"},
+	245: {"BPF", "a BPF program could not be installed"},
+}
+
+var signalNames = map[int]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	4:  "SIGILL",
+	5:  "SIGTRAP",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	10: "SIGUSR1",
+	11: "SIGSEGV",
+	12: "SIGUSR2",
+	13: "SIGPIPE",
+	14: "SIGALRM",
+	15: "SIGTERM",
+	16: "SIGSTKFLT",
+	17: "SIGCHLD",
+	18: "SIGCONT",
+	19: "SIGSTOP",
+	20: "SIGTSTP",
+	21: "SIGTTIN",
+	22: "SIGTTOU",
+	23: "SIGURG",
+	24: "SIGXCPU",
+	25: "SIGXFSZ",
+	26: "SIGVTALRM",
+	27: "SIGPROF",
+	28: "SIGWINCH",
+	29: "SIGIO",
+	30: "SIGPWR",
+	31: "SIGSYS",
+}
+
+// describeExit turns an exit status into a sentence such as
+// "exited with code 203 (EXEC) — ExecStart binary not found or not executable".
+func describeExit(status int) string {
+	if e, ok := execErrors[status]; ok {
+		return fmt.Sprintf("exited with code %d (%s) — %s", status, e.name, e.hint)
+	}
+	// Shells report death by signal N as 128+N.
+	if sig, ok := signalNames[status-128]; ok && status > 128 {
+		return fmt.Sprintf("exited with code %d (128+%s)", status, sig)
+	}
+	return fmt.Sprintf("exited with code %d", status)
+}
+
+func signalName(sig int) string {
+	if name, ok := signalNames[sig]; ok {
+		return name
+	}
+	if sig >= 34 && sig <= 64 {
+		return fmt.Sprintf("SIGRTMIN+%d", sig-34)
+	}
+	return fmt.Sprintf("signal %d", sig)
+}
+
+// ExitStatus decodes the ExecMainCode and ExecMainStatus properties of a
+// unit's last main process: the code says how it ended (CLD_EXITED,
+// CLD_KILLED or CLD_DUMPED) and the status is its exit code or signal.
+// It returns "" when no process has ended yet.
+func ExitStatus(code, status string) string {
+	s, err := strconv.Atoi(status)
+	if err != nil {
+		return ""
+	}
+	switch code {
+	case "1":
+		if s == 0 {
+			return "exited successfully (code 0)"
+		}
+		return describeExit(s)
+	case "2":
+		return fmt.Sprintf("killed by %s (%d)", signalName(s), s)
+	case "3":
+		return fmt.Sprintf("dumped core after %s (%d)", signalName(s), s)
+	}
+	return ""
+}
@@ -0,0 +1,243 @@
+// Package explain turns a unit's runtime properties and recent journal into a
+// human-readable account of why it is in its current state.
+package explain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+)
+
+// How many journal lines are included in a report.
+const logLines = 20
+
+var properties = []string{
+	"LoadState",
+	"ActiveState",
+	"SubState",
+	"Result",
+	"ExecMainCode",
+	"ExecMainStatus",
+	"ConditionResult",
+	"ConditionTimestamp",
+	"AssertResult",
+	"NRestarts",
+	"ExecStart",
+	"ExecMainPID",
+	"ExecMainStartTimestamp",
+	"InactiveEnterTimestamp",
+}
+
+// Report is the explanation for a single unit.
+type Report struct {
+	Unit     string
+	State    string
+	Summary  string
+	Findings []string
+	Denials  []string
+	Logs     []string
+}
+
+// Unit gathers everything needed to explain the named unit's state.
+func Unit(name string) (*Report, error) {
+	props, err := systemd.ShowProperties(name, properties...)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := systemd.RecentLogs(name, logLines)
+	if err != nil {
+		// The journal is useful context but not essential to the report.
+		logs = ""
+	}
+
+	r := &Report{
+		Unit:  name,
+		State: fmt.Sprintf("%s (%s)", props["ActiveState"], props["SubState"]),
+	}
+	if logs != "" {
+		r.Logs = strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	}
+	r.Summary = summarize(props)
+	r.Findings = append(r.Findings, findings(props)...)
+	r.Findings = append(r.Findings, logHints(r.Logs)...)
+	if props["ActiveState"] == "failed" {
+		r.Denials = denials(props)
+		if len(r.Denials) > 0 {
+			r.Findings = append(r.Findings, "The security policy denied the service something around the time it failed; see Denials below.")
+		}
+	}
+	return r, nil
+}
+
+// Failure says in a few words why a unit that should be running is not,
+// with its last journal line, e.g. "exited with code 1, last log: bind:
+// address already in use". It is meant for notifications; Unit gives the
+// full account.
+func Failure(name string) string {
+	p, err := systemd.ShowProperties(name, properties...)
+	if err != nil {
+		return err.Error()
+	}
+	cause := fmt.Sprintf("%s (%s)", p["ActiveState"], p["SubState"])
+	status, _ := strconv.Atoi(p["ExecMainStatus"])
+	switch p["Result"] {
+	case "exit-code":
+		cause = describeExit(status)
+	case "signal", "core-dump":
+		cause = "killed by " + signalName(status)
+	case "timeout":
+		cause = "timed out"
+	case "oom-kill":
+		cause = "killed by the OOM killer"
+	case "start-limit-hit":
+		cause = "hit its start limit"
+	}
+	if logs, err := systemd.RecentLogs(name, 1); err == nil {
+		// Older journalctl prints a "-- Logs begin at" header first.
+		lines := strings.Split(strings.TrimSpace(logs), "\n")
+		line := lines[len(lines)-1]
+		if _, msg, ok := strings.Cut(line, "]: "); ok {
+			line = msg
+		}
+		if line != "" && !strings.HasPrefix(line, "--") {
+			cause += ", last log: " + line
+		}
+	}
+	return cause
+}
+
+// summarize produces the one-line headline of the report.
+func summarize(p map[string]string) string {
+	switch p["LoadState"] {
+	case "not-found":
+		return "The unit file does not exist."
+	case "masked":
+		return "The unit is masked and cannot be started."
+	case "error", "bad-setting":
+		return "The unit file could not be loaded; check it for syntax errors."
+	}
+
+	if p["ConditionResult"] == "no" {
+		return "Start was skipped because a Condition*= check was not met."
+	}
+	if p["AssertResult"] == "no" {
+		return "Start failed because an Assert*= check was not met."
+	}
+
+	switch p["ActiveState"] {
+	case "active":
+		return fmt.Sprintf("Running normally (%s).", p["SubState"])
+	case "activating":
+		return "The unit is still starting up."
+	case "deactivating":
+		return "The unit is shutting down."
+	case "reloading":
+		return "The unit is reloading its configuration."
+	}
+
+	status, _ := strconv.Atoi(p["ExecMainStatus"])
+	switch p["Result"] {
+	case "exit-code":
+		return "The main process " + describeExit(status) + "."
+	case "signal":
+		return fmt.Sprintf("The main process was killed by %s (%d).", signalName(status), status)
+	case "core-dump":
+		return fmt.Sprintf("The main process crashed with %s (%d) and dumped core.", signalName(status), status)
+	case "timeout":
+		return "The unit did not finish its start or stop within the configured timeout."
+	case "watchdog":
+		return "The watchdog timed out: the service stopped sending keep-alive pings."
+	case "start-limit-hit":
+		return "The unit restarted too often and hit its start limit; run reset-failed before starting again."
+	case "oom-kill":
+		return "The kernel OOM killer terminated a process of this unit."
+	case "resources":
+		return "systemd could not set up the unit's resources (files, directories, users or sockets)."
+	case "protocol":
+		return "The service violated the startup protocol (e.g. Type=forking without forking, missing PID file)."
+	case "exec-condition":
+		return "An ExecCondition= command told systemd to skip the start."
+	case "success":
+		if p["ActiveState"] == "inactive" {
+			return "The unit stopped cleanly or was never started."
+		}
+	}
+	return fmt.Sprintf("The unit is %s (result: %s).", p["ActiveState"], p["Result"])
+}
+
+// findings lists secondary observations that support the summary.
+func findings(p map[string]string) []string {
+	var out []string
+	if n, _ := strconv.Atoi(p["NRestarts"]); n > 0 {
+		out = append(out, fmt.Sprintf("Restarted %d time(s) by systemd since it was loaded.", n))
+	}
+	if p["ConditionResult"] == "no" && p["ConditionTimestamp"] != "" {
+		out = append(out, "Condition checked at "+p["ConditionTimestamp"]+".")
+	}
+	// The summary already names the exit code or signal of a failed run.
+	switch p["Result"] {
+	case "exit-code", "signal", "core-dump":
+	default:
+		if exit := ExitStatus(p["ExecMainCode"], p["ExecMainStatus"]); exit != "" && p["ExecMainStatus"] != "0" {
+			out = append(out, "Last main process "+exit+".")
+		}
+	}
+	return out
+}
+
+// Common log messages and what they usually mean.
+var logPatterns = []struct{ match, hint string }{
+	{"no such file or directory", "A file or directory the service needs is missing."},
+	{"permission denied", "The service lacks permission to a file, port or directory."},
+	{"address already in use", "Another process is already listening on the service's port."},
+	{"connection refused", "A dependency the service connects to is not accepting connections."},
+	{"out of memory", "The service ran out of memory."},
+	{"segmentation fault", "The service crashed with a segmentation fault."},
+}
+
+func logHints(lines []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		for _, p := range logPatterns {
+			if strings.Contains(lower, p.match) && !seen[p.hint] {
+				seen[p.hint] = true
+				out = append(out, p.hint+" (from journal)")
+			}
+		}
+	}
+	return out
+}
+
+// denials looks up SELinux/AppArmor denials for the unit's executable or main
+// PID between its last start and the moment it failed. These show up only in
+// the audit log, never in the unit's own journal.
+func denials(p map[string]string) []string {
+	until, ok := systemd.ParseTimestamp(p["InactiveEnterTimestamp"])
+	if !ok {
+		until = time.Now()
+	}
+	since, ok := systemd.ParseTimestamp(p["ExecMainStartTimestamp"])
+	if !ok || since.After(until) {
+		since = until.Add(-time.Hour)
+	}
+	pid, _ := strconv.Atoi(p["ExecMainPID"])
+
+	found, err := systemd.Denials(systemd.ExecPath(p["ExecStart"]), pid, since, until)
+	if err != nil {
+		return nil
+	}
+	out := make([]string, 0, len(found))
+	for _, d := range found {
+		line := d.Kind + ": " + d.Message
+		if !d.Time.IsZero() {
+			line = d.Time.Format("15:04:05") + " " + line
+		}
+		out = append(out, line)
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+// Package export writes tables of units in formats meant for other tools:
+// CSV for spreadsheets, JSON for scripts and Markdown for wikis.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format is an output format understood by Write.
+type Format string
+
+const (
+	CSV      Format = "csv"
+	JSON     Format = "json"
+	Markdown Format = "md"
+)
+
+// ParseFormat accepts a format name or a file extension such as ".csv".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(name, ".")) {
+	case "csv":
+		return CSV, nil
+	case "json":
+		return JSON, nil
+	case "md", "markdown":
+		return Markdown, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (want csv, json or md)", name)
+}
+
+// FormatForPath guesses the format from a file name's extension.
+func FormatForPath(path string) (Format, error) {
+	return ParseFormat(filepath.Ext(path))
+}
+
+// Table is a header row and the rows below it. Every row has one cell per
+// column.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Write renders t to w in format f.
+func Write(w io.Writer, f Format, t Table) error {
+	switch f {
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.Write(t.Columns)
+		cw.WriteAll(t.Rows)
+		return cw.Error()
+	case JSON:
+		// One object per row, keyed by column name.
+		objects := make([]map[string]string, len(t.Rows))
+		for i, row := range t.Rows {
+			obj := make(map[string]string, len(t.Columns))
+			for j, col := range t.Columns {
+				obj[col] = row[j]
+			}
+			objects[i] = obj
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(objects)
+	case Markdown:
+		return writeMarkdown(w, t)
+	}
+	return fmt.Errorf("unknown export format %q", f)
+}
+
+func writeMarkdown(w io.Writer, t Table) error {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(escapeCells(t.Columns), " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(t.Columns)) + "\n")
+	for _, row := range t.Rows {
+		b.WriteString("| " + strings.Join(escapeCells(row), " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeCells keeps cell contents from breaking the Markdown table.
+func escapeCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(c, "|", `\|`)
+		out[i] = strings.ReplaceAll(c, "\n", " ")
+	}
+	return out
+}
@@ -0,0 +1,263 @@
+// Package history keeps a local record of unit state changes, resource
+// samples and the actions taken from vigilix in an embedded bbolt database,
+// so they can be looked at later, even without a running UI.
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+	"vigilix/internal/config"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Event is a change of a unit's active state.
+type Event struct {
+	Time time.Time `json:"time"`
+	Unit string    `json:"unit"`
+	From string    `json:"from"`
+	To   string    `json:"to"`
+}
+
+// Sample is a unit's resource use at one point in time.
+type Sample struct {
+	Time    time.Time     `json:"time"`
+	Unit    string        `json:"unit"`
+	CPU     time.Duration `json:"cpu"` // total CPU time since the unit started
+	Memory  uint64        `json:"memory"`
+	IORead  uint64        `json:"io_read,omitempty"`
+	IOWrite uint64        `json:"io_write,omitempty"`
+	IPIn    uint64        `json:"ip_in,omitempty"`
+	IPOut   uint64        `json:"ip_out,omitempty"`
+}
+
+// Audit is an action taken on a unit from vigilix.
+type Audit struct {
+	Time   time.Time `json:"time"`
+	Unit   string    `json:"unit"`
+	Action string    `json:"action"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// Batch is a set of records written in one transaction.
+type Batch struct {
+	Events  []Event
+	Samples []Sample
+	Audits  []Audit
+}
+
+// Empty reports whether there is nothing to write.
+func (b Batch) Empty() bool {
+	return len(b.Events) == 0 && len(b.Samples) == 0 && len(b.Audits) == 0
+}
+
+var (
+	eventsBucket  = []byte("events")
+	samplesBucket = []byte("samples")
+	auditBucket   = []byte("audit")
+	uptimeBucket  = []byte("uptime")
+)
+
+// lockTimeout is how long Open waits for another vigilix holding the
+// database, e.g. the UI while it writes.
+const lockTimeout = 3 * time.Second
+
+// ErrLocked is returned when the database stays locked by another process.
+var ErrLocked = errors.New("the history database is in use by another vigilix")
+
+// Store is an open history database.
+type Store struct {
+	db *bolt.DB
+}
+
+// Path returns the location of the database.
+func Path() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.db"), nil
+}
+
+// Open opens the database, creating it unless readOnly. Only one process can
+// have it open for writing, so writers should close it again soon.
+func Open(readOnly bool) (*Store, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	if !readOnly {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: lockTimeout, ReadOnly: readOnly})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !readOnly {
+		err = db.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{eventsBucket, samplesBucket, auditBucket, uptimeBucket} {
+				if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Write adds a batch of records.
+func (s *Store) Write(b Batch) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, e := range b.Events {
+			if err := put(tx.Bucket(eventsBucket), e.Unit, e.Time, e); err != nil {
+				return err
+			}
+		}
+		for _, smp := range b.Samples {
+			if err := put(tx.Bucket(samplesBucket), smp.Unit, smp.Time, smp); err != nil {
+				return err
+			}
+		}
+		for _, a := range b.Audits {
+			if err := put(tx.Bucket(auditBucket), a.Unit, a.Time, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Events returns a unit's state changes since the given time, oldest first.
+func (s *Store) Events(unit string, since time.Time) ([]Event, error) {
+	return query[Event](s, eventsBucket, unit, since)
+}
+
+// Samples returns a unit's resource samples since the given time.
+func (s *Store) Samples(unit string, since time.Time) ([]Sample, error) {
+	return query[Sample](s, samplesBucket, unit, since)
+}
+
+// Audits returns the actions taken on a unit since the given time.
+func (s *Store) Audits(unit string, since time.Time) ([]Audit, error) {
+	return query[Audit](s, auditBucket, unit, since)
+}
+
+// SaveUptime replaces the stored availability history.
+func (s *Store) SaveUptime(u config.Uptime) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(uptimeBucket)
+		kept := u.Pruned(time.Now())
+		for unit, spans := range kept {
+			data, err := json.Marshal(spans)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(unit), data); err != nil {
+				return err
+			}
+		}
+		// Units whose spans have all expired would otherwise stay forever.
+		var gone [][]byte
+		err := b.ForEach(func(k, _ []byte) error {
+			if _, ok := kept[string(k)]; !ok {
+				gone = append(gone, bytes.Clone(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range gone {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LoadUptime returns the stored availability history.
+func (s *Store) LoadUptime() (config.Uptime, error) {
+	u := config.Uptime{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(uptimeBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var spans []config.Span
+			if err := json.Unmarshal(v, &spans); err != nil {
+				return err
+			}
+			u[string(k)] = spans
+			return nil
+		})
+	})
+	return u, err
+}
+
+// Records are keyed by unit, time and a sequence number, so a unit's
+// records are contiguous and in time order.
+func key(unit string, t time.Time, seq uint64) []byte {
+	k := make([]byte, 0, len(unit)+17)
+	k = append(k, unit...)
+	k = append(k, 0)
+	k = binary.BigEndian.AppendUint64(k, uint64(t.UnixNano()))
+	return binary.BigEndian.AppendUint64(k, seq)
+}
+
+func put(b *bolt.Bucket, unit string, t time.Time, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	return b.Put(key(unit, t, seq), data)
+}
+
+func query[T any](s *Store, bucket []byte, unit string, since time.Time) ([]T, error) {
+	var out []T
+	if epoch := time.Unix(0, 0); since.Before(epoch) {
+		since = epoch
+	}
+	prefix := append([]byte(unit), 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(key(unit, since, 0)); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var rec T
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			out = append(out, rec)
+		}
+		return nil
+	})
+	return out, err
+}
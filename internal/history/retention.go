@@ -0,0 +1,171 @@
+package history
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+	"vigilix/internal/config"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// PruneStats counts what Prune removed.
+type PruneStats struct {
+	Events, Audits int
+	Samples        int // expired entirely
+	Downsampled    int // dropped while thinning to one sample per hour
+}
+
+// errDryRun rolls back a pruning transaction after counting.
+var errDryRun = errors.New("dry run")
+
+// Prune applies the retention policy: state changes and actions expire
+// after the event retention; samples are kept at full resolution for the
+// sample retention, then thinned to the last one of every hour until the
+// hourly retention ends. With dryRun nothing is deleted, only counted.
+func (s *Store) Prune(r config.Retention, now time.Time, dryRun bool) (PruneStats, error) {
+	var st PruneStats
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		eventsCutoff := now.Add(-r.Events())
+		if st.Events, err = expire(tx.Bucket(eventsBucket), eventsCutoff); err != nil {
+			return err
+		}
+		if st.Audits, err = expire(tx.Bucket(auditBucket), eventsCutoff); err != nil {
+			return err
+		}
+		if st.Samples, st.Downsampled, err = thin(tx.Bucket(samplesBucket), now.Add(-r.Samples()), now.Add(-r.Hourly())); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	return st, err
+}
+
+// splitKey returns the unit and time encoded in a record key.
+func splitKey(k []byte) (unit string, t time.Time, ok bool) {
+	if len(k) < 17 {
+		return "", time.Time{}, false
+	}
+	unit = string(k[:len(k)-17])
+	nanos := binary.BigEndian.Uint64(k[len(k)-16 : len(k)-8])
+	return unit, time.Unix(0, int64(nanos)), true
+}
+
+// expire deletes the records older than cutoff.
+func expire(b *bolt.Bucket, cutoff time.Time) (int, error) {
+	if b == nil {
+		return 0, nil
+	}
+	var doomed [][]byte
+	b.ForEach(func(k, _ []byte) error {
+		if _, t, ok := splitKey(k); ok && t.Before(cutoff) {
+			doomed = append(doomed, k)
+		}
+		return nil
+	})
+	return len(doomed), deleteKeys(b, doomed)
+}
+
+// thin deletes samples older than expireBefore and, of those older than
+// fullAfter, all but the last one per unit and hour.
+func thin(b *bolt.Bucket, fullAfter, expireBefore time.Time) (expired, thinned int, err error) {
+	if b == nil {
+		return 0, 0, nil
+	}
+	var doomed [][]byte
+	var prevKey []byte
+	var prevUnit string
+	var prevHour time.Time
+	b.ForEach(func(k, _ []byte) error {
+		unit, t, ok := splitKey(k)
+		if !ok {
+			return nil
+		}
+		switch {
+		case t.Before(expireBefore):
+			doomed = append(doomed, k)
+			expired++
+		case t.Before(fullAfter):
+			hour := t.Truncate(time.Hour)
+			if prevKey != nil && unit == prevUnit && hour.Equal(prevHour) {
+				doomed = append(doomed, prevKey)
+				thinned++
+			}
+			prevKey, prevUnit, prevHour = k, unit, hour
+			return nil
+		}
+		prevKey = nil
+		return nil
+	})
+	return expired, thinned, deleteKeys(b, doomed)
+}
+
+func deleteKeys(b *bolt.Bucket, keys [][]byte) error {
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compact rewrites the database into a fresh file, returning the space
+// freed by pruning to the file system, and reports the sizes before and
+// after. bbolt never shrinks its file on its own.
+func Compact() (before, after int64, err error) {
+	path, err := Path()
+	if err != nil {
+		return 0, 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	before = info.Size()
+
+	src, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return 0, 0, ErrLocked
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer src.Close()
+
+	tmp := filepath.Join(filepath.Dir(path), ".history.db.compact")
+	os.Remove(tmp)
+	dst, err := bolt.Open(tmp, 0o600, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := bolt.Compact(dst, src, 1<<20); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+	// The lock belongs to the old file, not to the path: holding it keeps
+	// writers out only until the rename. One already waiting for it ends up
+	// with the replaced file once it is released, and what it writes there
+	// is lost, so compact while no other vigilix is running.
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+	if info, err := os.Stat(path); err == nil {
+		after = info.Size()
+	}
+	return before, after, nil
+}
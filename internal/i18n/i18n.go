@@ -0,0 +1,81 @@
+// Package i18n translates user-facing strings through a message catalog.
+//
+// The English text is the message key, gettext style, so untranslated
+// strings simply show in English. Catalogs are JSON objects mapping the
+// English text to its translation, read from
+// $XDG_CONFIG_HOME/vigilix/locales/<lang>.json, e.g. de_DE.json or de.json.
+// Format strings keep their verbs: "%d failed" → "%d fehlgeschlagen".
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"vigilix/internal/config"
+)
+
+var catalog map[string]string
+
+// Language returns the user's message language from the environment
+// (LC_ALL, LC_MESSAGES, LANG), without encoding or modifier, e.g. "de_DE".
+func Language() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			v, _, _ = strings.Cut(v, ".")
+			v, _, _ = strings.Cut(v, "@")
+			return v
+		}
+	}
+	return ""
+}
+
+// Load reads the catalog for lang, trying the full name before the bare
+// language ("de_DE", then "de"). English and missing catalogs are not
+// errors; the built-in strings are used.
+func Load(lang string) error {
+	catalog = nil
+	if lang == "" || lang == "C" || lang == "POSIX" || strings.HasPrefix(lang, "en") {
+		return nil
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+
+	candidates := []string{lang}
+	if base, _, ok := strings.Cut(lang, "_"); ok {
+		candidates = append(candidates, base)
+	}
+	for _, name := range candidates {
+		data, err := os.ReadFile(filepath.Join(dir, "locales", name+".json"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var c map[string]string
+		if err := json.Unmarshal(data, &c); err != nil {
+			return fmt.Errorf("locale %s: %w", name, err)
+		}
+		catalog = c
+		return nil
+	}
+	return nil
+}
+
+// T translates msg, returning it unchanged when there is no translation.
+func T(msg string) string {
+	if t, ok := catalog[msg]; ok && t != "" {
+		return t
+	}
+	return msg
+}
+
+// Tf translates a format string and formats it.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
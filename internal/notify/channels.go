@@ -0,0 +1,171 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+)
+
+// Channel kinds.
+const (
+	ChannelDesktop = "desktop" // notify-send
+	ChannelWebhook = "webhook" // POST the alert as JSON
+	ChannelCommand = "command" // run a program with the alert as JSON on stdin
+	ChannelEmail   = "email"   // send a mail over SMTP
+	ChannelNtfy    = "ntfy"    // publish to an ntfy topic
+	ChannelGotify  = "gotify"  // push a message to a Gotify server
+)
+
+// deliveryTimeout bounds how long one delivery may take.
+const deliveryTimeout = 15 * time.Second
+
+// ValidateChannel checks a channel for mistakes that would make it unusable.
+func ValidateChannel(c config.Channel) error {
+	switch {
+	case c.Name == "":
+		return errors.New("channel without a name")
+	case (c.Kind == ChannelWebhook || c.Kind == ChannelNtfy || c.Kind == ChannelGotify) && c.URL == "":
+		return fmt.Errorf("channel %q: no url", c.Name)
+	case c.Kind == ChannelGotify && c.Token == "":
+		return fmt.Errorf("channel %q: no token", c.Name)
+	case c.Kind == ChannelCommand && len(c.Command) == 0:
+		return fmt.Errorf("channel %q: no command", c.Name)
+	case c.Kind == ChannelEmail && (c.SMTP == "" || c.From == "" || len(c.To) == 0):
+		return fmt.Errorf("channel %q: smtp, from and to are required", c.Name)
+	case !knownKind(c.Kind):
+		return fmt.Errorf("channel %q: unknown kind %q", c.Name, c.Kind)
+	}
+	if c.Kind == ChannelEmail {
+		if _, _, err := mailTemplates(c); err != nil {
+			return fmt.Errorf("channel %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Deliver sends an alert to a channel.
+func Deliver(c config.Channel, a Alert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	var err error
+	switch c.Kind {
+	case ChannelDesktop:
+		err = exec.CommandContext(ctx, "notify-send", "--app-name=vigilix", "vigilix: "+a.Rule, a.Message).Run()
+	case ChannelWebhook:
+		err = postJSON(ctx, c.URL, a)
+	case ChannelCommand:
+		err = runCommand(ctx, c.Command, a)
+	case ChannelEmail:
+		err = sendMail(ctx, c, a)
+	case ChannelNtfy:
+		err = pushNtfy(ctx, c, a)
+	case ChannelGotify:
+		err = pushGotify(ctx, c, a)
+	default:
+		err = fmt.Errorf("unknown kind %q", c.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("channel %q: %w", c.Name, err)
+	}
+	return nil
+}
+
+func knownKind(kind string) bool {
+	switch kind {
+	case ChannelDesktop, ChannelWebhook, ChannelCommand, ChannelEmail, ChannelNtfy, ChannelGotify:
+		return true
+	}
+	return false
+}
+
+func postJSON(ctx context.Context, url string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return send(req)
+}
+
+// pushNtfy publishes the alert to an ntfy topic URL, e.g.
+// https://ntfy.sh/my-alerts.
+func pushNtfy(ctx context.Context, c config.Channel, a Alert) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, strings.NewReader(a.Message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", "vigilix: "+a.Rule)
+	req.Header.Set("Tags", "warning")
+	if c.Priority > 0 {
+		req.Header.Set("Priority", strconv.Itoa(c.Priority))
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return send(req)
+}
+
+// pushGotify sends the alert to a Gotify server with an application token.
+func pushGotify(ctx context.Context, c config.Channel, a Alert) error {
+	body, err := json.Marshal(map[string]any{
+		"title":    "vigilix: " + a.Rule,
+		"message":  a.Message,
+		"priority": c.Priority,
+	})
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(c.URL, "/") + "/message"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", c.Token)
+	return send(req)
+}
+
+// send performs an HTTP request and treats any non-2xx answer as an error.
+func send(req *http.Request) error {
+	url := req.URL.Redacted()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
+
+func runCommand(ctx context.Context, command []string, a Alert) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(append(data, '\n'))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+	"vigilix/internal/config"
+)
+
+const (
+	defaultSubject = "vigilix: {{.Rule}} on {{.Unit}}"
+	defaultBody    = "{{.Message}}\n\nRule: {{.Rule}}\nUnit: {{.Unit}}\nTime: {{.Time.Format \"2006-01-02 15:04:05 MST\"}}\n"
+)
+
+// mailTemplates parses the channel's subject and body templates, falling
+// back to the defaults.
+func mailTemplates(c config.Channel) (subject, body *template.Template, err error) {
+	subjectText, bodyText := c.Subject, c.Body
+	if subjectText == "" {
+		subjectText = defaultSubject
+	}
+	if bodyText == "" {
+		bodyText = defaultBody
+	}
+	if subject, err = template.New("subject").Parse(subjectText); err != nil {
+		return nil, nil, err
+	}
+	if body, err = template.New("body").Parse(bodyText); err != nil {
+		return nil, nil, err
+	}
+	return subject, body, nil
+}
+
+// sendMail mails an alert. The connection is upgraded with STARTTLS when
+// the server offers it; port 465 speaks TLS from the start.
+func sendMail(ctx context.Context, c config.Channel, a Alert) error {
+	msg, err := composeMail(c, a)
+	if err != nil {
+		return err
+	}
+	host, port, err := net.SplitHostPort(c.SMTP)
+	if err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", c.SMTP)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if port == "465" {
+		conn = tls.Client(conn, &tls.Config{ServerName: host})
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if c.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.Username, c.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(c.From); err != nil {
+		return err
+	}
+	for _, to := range c.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// composeMail renders the templates into a plain-text message with headers.
+func composeMail(c config.Channel, a Alert) ([]byte, error) {
+	subjectTmpl, bodyTmpl, err := mailTemplates(c)
+	if err != nil {
+		return nil, err
+	}
+	var subject, body bytes.Buffer
+	if err := subjectTmpl.Execute(&subject, a); err != nil {
+		return nil, err
+	}
+	if err := bodyTmpl.Execute(&body, a); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", c.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(c.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject.String())))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
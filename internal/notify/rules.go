@@ -0,0 +1,211 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+)
+
+// Alert is a rule firing for a unit.
+type Alert struct {
+	Rule    string    `json:"rule"`
+	Unit    string    `json:"unit"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// rule is a config.Rule with its log pattern compiled.
+type rule struct {
+	config.Rule
+	log *regexp.Regexp
+}
+
+// sample is what is known about a unit from states and metrics seen so far.
+type sample struct {
+	state    string
+	restarts int
+	memory   uint64
+}
+
+// Engine evaluates rules against unit states, metrics and journal lines.
+// Conditions on states and metrics are edge-triggered: a rule fires once
+// when they start to hold and again only after they stopped holding. Log
+// rules fire for every matching line.
+type Engine struct {
+	rules    []rule
+	channels map[string]config.Channel
+	units    map[string]sample
+	firing   map[string]bool // rule name + unit
+}
+
+// NewEngine checks the rules and the channels they refer to.
+func NewEngine(rules []config.Rule, channels []config.Channel) (*Engine, error) {
+	e := &Engine{
+		channels: make(map[string]config.Channel),
+		units:    make(map[string]sample),
+		firing:   make(map[string]bool),
+	}
+	for _, c := range channels {
+		if err := ValidateChannel(c); err != nil {
+			return nil, err
+		}
+		e.channels[c.Name] = c
+	}
+	for _, r := range rules {
+		switch {
+		case r.Name == "":
+			return nil, errors.New("rule without a name")
+		case r.State == "" && r.Restarts <= 0 && r.MemoryMB <= 0 && r.Log == "":
+			return nil, fmt.Errorf("rule %q: no condition", r.Name)
+		}
+		compiled := rule{Rule: r}
+		if r.Log != "" {
+			re, err := regexp.Compile(r.Log)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", r.Name, err)
+			}
+			compiled.log = re
+		}
+		for _, name := range r.Channels {
+			if _, ok := e.channels[name]; !ok {
+				return nil, fmt.Errorf("rule %q: unknown channel %q", r.Name, name)
+			}
+		}
+		e.rules = append(e.rules, compiled)
+	}
+	return e, nil
+}
+
+// NeedsLogs reports whether any rule matches journal lines.
+func (e *Engine) NeedsLogs() bool {
+	for _, r := range e.rules {
+		if r.log != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsMetrics reports whether any rule looks at restarts or memory.
+func (e *Engine) NeedsMetrics() bool {
+	for _, r := range e.rules {
+		if r.Restarts > 0 || r.MemoryMB > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Watches reports whether any rule applies to unit.
+func (e *Engine) Watches(unit string) bool {
+	for _, r := range e.rules {
+		if r.applies(unit) {
+			return true
+		}
+	}
+	return false
+}
+
+// Channels returns the channels an alert is routed to.
+func (e *Engine) Channels(a Alert) []config.Channel {
+	var out []config.Channel
+	for _, r := range e.rules {
+		if r.Name != a.Rule {
+			continue
+		}
+		for _, name := range r.Channels {
+			out = append(out, e.channels[name])
+		}
+	}
+	return out
+}
+
+// State records a unit's active state and returns the alerts it causes.
+func (e *Engine) State(unit, state string, now time.Time) []Alert {
+	s := e.units[unit]
+	s.state = state
+	e.units[unit] = s
+	return e.evaluate(unit, now)
+}
+
+// Metrics records a unit's restart count and memory use and returns the
+// alerts they cause.
+func (e *Engine) Metrics(unit string, restarts int, memory uint64, now time.Time) []Alert {
+	s := e.units[unit]
+	s.restarts, s.memory = restarts, memory
+	e.units[unit] = s
+	return e.evaluate(unit, now)
+}
+
+// Log returns the alerts a journal line of unit causes.
+func (e *Engine) Log(unit, message string, now time.Time) []Alert {
+	s := e.units[unit]
+	var alerts []Alert
+	for _, r := range e.rules {
+		if r.log == nil || !r.applies(unit) || !r.log.MatchString(message) {
+			continue
+		}
+		if reasons, ok := r.holds(s); ok {
+			reasons = append(reasons, "logged "+message)
+			alerts = append(alerts, Alert{Rule: r.Name, Unit: unit, Time: now, Message: unit + ": " + strings.Join(reasons, "; ")})
+		}
+	}
+	return alerts
+}
+
+func (e *Engine) evaluate(unit string, now time.Time) []Alert {
+	s := e.units[unit]
+	var alerts []Alert
+	for _, r := range e.rules {
+		if r.log != nil || !r.applies(unit) {
+			continue
+		}
+		key := r.Name + "\x00" + unit
+		reasons, ok := r.holds(s)
+		if ok && !e.firing[key] {
+			alerts = append(alerts, Alert{Rule: r.Name, Unit: unit, Time: now, Message: unit + ": " + strings.Join(reasons, "; ")})
+		}
+		e.firing[key] = ok
+	}
+	return alerts
+}
+
+func (r rule) applies(unit string) bool {
+	if len(r.Units) == 0 {
+		return true
+	}
+	for _, pattern := range r.Units {
+		if ok, _ := path.Match(pattern, unit); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// holds checks the state and metric conditions, describing each that holds.
+func (r rule) holds(s sample) ([]string, bool) {
+	var reasons []string
+	if r.State != "" {
+		if s.state != r.State {
+			return nil, false
+		}
+		reasons = append(reasons, "is "+s.state)
+	}
+	if r.Restarts > 0 {
+		if s.restarts < r.Restarts {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("restarted %d times", s.restarts))
+	}
+	if r.MemoryMB > 0 {
+		if s.memory < uint64(r.MemoryMB)<<20 {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("uses %d MB of memory (limit %d MB)", s.memory>>20, r.MemoryMB))
+	}
+	return reasons, true
+}
@@ -0,0 +1,120 @@
+// Package notify decides which alerts reach the user and delivers them.
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+	"vigilix/internal/config"
+)
+
+// Silence mutes alerts for one unit, or for every unit when Unit is empty,
+// until the given time.
+type Silence struct {
+	Unit   string    `json:"unit,omitempty"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Global reports whether the silence applies to all units.
+func (s Silence) Global() bool {
+	return s.Unit == ""
+}
+
+func silencesPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "silences.json"), nil
+}
+
+// LoadSilences returns the silences that have not expired yet.
+func LoadSilences() ([]Silence, error) {
+	path, err := silencesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Silence
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return Active(all, time.Now()), nil
+}
+
+// AddSilence stores a new silence, replacing any existing one for the same
+// unit, and returns the active set.
+func AddSilence(s Silence) ([]Silence, error) {
+	return updateSilences(func(current []Silence) []Silence {
+		return append(without(current, s.Unit), s)
+	})
+}
+
+// RemoveSilence lifts the silence for a unit ("" for the global one).
+func RemoveSilence(unit string) ([]Silence, error) {
+	return updateSilences(func(current []Silence) []Silence {
+		return without(current, unit)
+	})
+}
+
+func updateSilences(change func([]Silence) []Silence) ([]Silence, error) {
+	current, err := LoadSilences()
+	if err != nil {
+		return nil, err
+	}
+	updated := change(current)
+
+	path, err := silencesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := config.WriteFileAtomic(path, data); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func without(silences []Silence, unit string) []Silence {
+	var out []Silence
+	for _, s := range silences {
+		if s.Unit != unit {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Active filters out silences that have expired by now.
+func Active(silences []Silence, now time.Time) []Silence {
+	var out []Silence
+	for _, s := range silences {
+		if now.Before(s.Until) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Silenced reports whether alerts for unit are muted at now.
+func Silenced(silences []Silence, unit string, now time.Time) bool {
+	for _, s := range silences {
+		if (s.Global() || s.Unit == unit) && now.Before(s.Until) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Package plugin runs the external executables registered in the config.
+//
+// A plugin is started once per use. It receives a single Request as JSON on
+// stdin and must write a single Response as JSON to stdout before exiting.
+// Anything it writes to stderr is included in the error if it fails. Panel
+// plugins return a Title and Body to display; action plugins do something to
+// the unit and return a short Message to show as a notification.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+)
+
+// ProtocolVersion is sent with every request so plugins can reject versions
+// they do not understand.
+const ProtocolVersion = 1
+
+const (
+	KindPanel  = "panel"
+	KindAction = "action"
+)
+
+// How long a plugin may run before it is killed.
+const timeout = 15 * time.Second
+
+// Request is what vigilix writes to a plugin's stdin.
+type Request struct {
+	Version int    `json:"version"`
+	Kind    string `json:"kind"`
+	Unit    string `json:"unit"`
+	Scope   string `json:"scope"` // "system" or "user"
+}
+
+// Response is what a plugin writes to its stdout.
+type Response struct {
+	Title   string `json:"title,omitempty"`
+	Body    string `json:"body,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Applies reports whether the plugin is offered for the given unit.
+func Applies(p config.Plugin, unit string) bool {
+	if len(p.Units) == 0 {
+		return true
+	}
+	for _, pattern := range p.Units {
+		if ok, _ := path.Match(pattern, unit); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks a registration for mistakes that would make it unusable.
+func Validate(p config.Plugin) error {
+	switch {
+	case p.Name == "":
+		return errors.New("plugin without a name")
+	case len(p.Command) == 0:
+		return fmt.Errorf("plugin %q: no command", p.Name)
+	case p.Kind != KindPanel && p.Kind != KindAction:
+		return fmt.Errorf("plugin %q: kind must be %q or %q", p.Name, KindPanel, KindAction)
+	}
+	return nil
+}
+
+// Run invokes the plugin for unit and decodes its response. A response with
+// Error set is returned as an error.
+func Run(p config.Plugin, unit string) (*Response, error) {
+	if err := Validate(p); err != nil {
+		return nil, err
+	}
+	req, err := json.Marshal(Request{
+		Version: ProtocolVersion,
+		Kind:    p.Kind,
+		Unit:    unit,
+		Scope:   systemd.CurrentScope().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	slog.Debug("exec", "plugin", p.Name, "cmd", p.Command)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(append(req, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("plugin %q timed out after %s", p.Name, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %q: %w: %s", p.Name, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %q: %w", p.Name, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %q: invalid response: %w", p.Name, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %q: %s", p.Name, resp.Error)
+	}
+	return &resp, nil
+}
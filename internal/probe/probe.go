@@ -0,0 +1,75 @@
+// Package probe measures how long a unit's HTTP endpoint takes to answer.
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+	"vigilix/internal/supervise"
+)
+
+// Result is one probe of an endpoint.
+type Result struct {
+	At      time.Time
+	Latency time.Duration // until the response headers arrived
+	Status  int
+	Err     error
+}
+
+// OK reports whether the endpoint answered with a success or redirect.
+func (r Result) OK() bool {
+	return r.Err == nil && r.Status < 400
+}
+
+var client = &http.Client{
+	// Redirects are answers too; following them would time someone else.
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+// Run probes url once.
+func Run(ctx context.Context, url string) Result {
+	r := Result{At: time.Now()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	req.Header.Set("User-Agent", "vigilix-probe")
+	resp, err := client.Do(req)
+	r.Latency = time.Since(r.At)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	r.Status = resp.StatusCode
+	if !r.OK() {
+		r.Err = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return r
+}
+
+// RunAll probes every unit's URL concurrently, each within timeout.
+func RunAll(urls map[string]string, timeout time.Duration) map[string]Result {
+	out := make(map[string]Result, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for unit, url := range urls {
+		wg.Add(1)
+		supervise.Go("http probe", func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			r := Run(ctx, url)
+			mu.Lock()
+			out[unit] = r
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,128 @@
+// Package recording writes and reads vigilix session recordings (.vgx): a
+// gzip-compressed stream of JSON lines holding every key pressed, every
+// background event the UI received and every screen it drew, with the time
+// since the start. They are for post-incident review and for reporting UI
+// bugs with exactly what was on screen.
+package recording
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Kinds of entries.
+const (
+	Key   = "key"   // a key press, as tea.KeyMsg.String reports it
+	Size  = "size"  // the terminal was resized
+	Event = "event" // a message from a background command
+	Frame = "frame" // the rendered screen changed
+)
+
+// Header is the first line of a recording.
+type Header struct {
+	Version string    `json:"vigilix"`
+	Host    string    `json:"host"`
+	Started time.Time `json:"started"`
+}
+
+// Entry is one recorded key, event or frame.
+type Entry struct {
+	At     time.Duration `json:"t"`
+	Kind   string        `json:"k"`
+	Data   string        `json:"d,omitempty"`
+	Width  int           `json:"w,omitempty"`
+	Height int           `json:"h,omitempty"`
+}
+
+// Writer appends entries to a recording. It is safe for concurrent use.
+type Writer struct {
+	mu    sync.Mutex
+	f     *os.File
+	gz    *gzip.Writer
+	enc   *json.Encoder
+	start time.Time
+	flush time.Time // last flush of the compressed stream
+	err   error
+}
+
+// Create starts a new recording at path, replacing any file there. The
+// recording holds whole screens and logs, so only the user may read it.
+func Create(path, version string) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	// A replaced file keeps its mode otherwise.
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return nil, err
+	}
+	gz := gzip.NewWriter(f)
+	w := &Writer{f: f, gz: gz, enc: json.NewEncoder(gz), start: time.Now()}
+	host, _ := os.Hostname()
+	if err := w.enc.Encode(Header{Version: version, Host: host, Started: w.start}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Add records an entry now. Errors are kept for Close. The stream is
+// flushed every second, so a recording survives the process being killed.
+func (w *Writer) Add(e Entry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	e.At = time.Since(w.start)
+	w.err = w.enc.Encode(e)
+	if w.err == nil && time.Since(w.flush) >= time.Second {
+		w.flush = time.Now()
+		w.err = w.gz.Flush()
+	}
+}
+
+// Close flushes the recording and reports the first write error.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return errors.Join(w.err, w.gz.Close(), w.f.Close())
+}
+
+// Read loads a whole recording. A recording cut short, e.g. by a crash,
+// is read up to its last complete entry.
+func Read(path string) (Header, []Entry, error) {
+	var h Header
+	f, err := os.Open(path)
+	if err != nil {
+		return h, nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return h, nil, fmt.Errorf("%s is not a vigilix recording: %w", path, err)
+	}
+	dec := json.NewDecoder(gz)
+	if err := dec.Decode(&h); err != nil || h.Started.IsZero() {
+		return h, nil, fmt.Errorf("%s is not a vigilix recording", path)
+	}
+	var entries []Entry
+	for {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return h, entries, nil
+			}
+			return h, entries, err
+		}
+		entries = append(entries, e)
+	}
+}
@@ -0,0 +1,116 @@
+// Package rollout restarts a group of units in dependency order, checking
+// that each one is healthy before moving on to the next.
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/probe"
+	"vigilix/internal/systemd"
+)
+
+// pollInterval is how often a restarted unit's health is checked.
+const pollInterval = time.Second
+
+// Step is one thing done during a rollout, for the report.
+type Step struct {
+	At   time.Time
+	Unit string
+	Text string
+	Err  error
+}
+
+// Run restarts the group's units in order. probes holds the health check
+// URLs by unit name, as configured for the probes panel. A unit that does
+// not become healthy ends the rollout with an error, after the rollback.
+func Run(ctx context.Context, g config.Group, probes map[string]string) ([]Step, error) {
+	var steps []Step
+	note := func(unit string, err error, format string, args ...any) {
+		steps = append(steps, Step{At: time.Now(), Unit: unit, Text: fmt.Sprintf(format, args...), Err: err})
+	}
+
+	for i, unit := range g.Units {
+		start := time.Now()
+		err := systemd.RestartUnit(unit)
+		if err == nil {
+			err = WaitHealthy(ctx, unit, probes[unit], g.HealthTimeout())
+		}
+		if err == nil {
+			note(unit, nil, "Restarted %s, healthy after %s", unit, time.Since(start).Round(100*time.Millisecond))
+			continue
+		}
+		note(unit, err, "Restarting %s", unit)
+		if len(g.Rollback) > 0 {
+			rollback(ctx, g, probes, g.Units[:i+1], unit, note)
+		}
+		return steps, fmt.Errorf("%s did not come back: %w", unit, err)
+	}
+	return steps, nil
+}
+
+// WaitHealthy waits until the unit is active and its probe, if any,
+// answers.
+func WaitHealthy(ctx context.Context, unit, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	why := "not active"
+	for {
+		props, err := systemd.ShowProperties(unit, "ActiveState", "Result")
+		if err != nil {
+			return err
+		}
+		switch props["ActiveState"] {
+		case "failed":
+			return fmt.Errorf("failed (%s)", props["Result"])
+		case "active":
+			if url == "" {
+				return nil
+			}
+			r := probe.Run(ctx, url)
+			if r.OK() {
+				return nil
+			}
+			why = fmt.Sprintf("%s: %v", url, r.Err)
+		default:
+			why = props["ActiveState"]
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("still %s after %s", why, timeout)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// rollback runs the group's rollback command for the failed unit, then
+// restarts the units restarted so far again, last first.
+func rollback(ctx context.Context, g config.Group, probes map[string]string, restarted []string, failed string, note func(string, error, string, ...any)) {
+	slog.Debug("exec", "rollback", g.Name, "cmd", g.Rollback)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, g.Rollback[0], g.Rollback[1:]...)
+	cmd.Env = append(os.Environ(), "VIGILIX_UNIT="+failed, "VIGILIX_GROUP="+g.Name)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		note(failed, err, "Rolling back with %s", strings.Join(g.Rollback, " "))
+		return
+	}
+	note(failed, nil, "Rolled back with %s", strings.Join(g.Rollback, " "))
+	for i := len(restarted) - 1; i >= 0; i-- {
+		unit := restarted[i]
+		err := systemd.RestartUnit(unit)
+		if err == nil {
+			err = WaitHealthy(ctx, unit, probes[unit], g.HealthTimeout())
+		}
+		note(unit, err, "Restarted %s after the rollback", unit)
+	}
+}
@@ -0,0 +1,349 @@
+// Package screenshot saves a rendered frame of the interface for sharing,
+// e.g. in a ticket: as plain text, as text with its ANSI colors kept, or as
+// an SVG image that any browser shows.
+package screenshot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Format is an output format understood by Write.
+type Format string
+
+const (
+	Text Format = "txt"
+	ANSI Format = "ans"
+	SVG  Format = "svg"
+)
+
+// FormatForPath picks the format from a file name's extension.
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")) {
+	case "txt":
+		return Text, nil
+	case "ans", "ansi":
+		return ANSI, nil
+	case "svg":
+		return SVG, nil
+	}
+	return "", fmt.Errorf("unknown screenshot format %q (want .svg, .ans or .txt)", filepath.Ext(path))
+}
+
+// Colors are the terminal's default colors, used where the frame sets
+// none. Each is "#rrggbb" or an ANSI color number, as in a theme.
+type Colors struct {
+	Foreground, Background string
+}
+
+// Write renders frame, a string as printed to the terminal, to w.
+func Write(w io.Writer, f Format, frame string, c Colors) error {
+	var err error
+	switch f {
+	case Text:
+		_, err = io.WriteString(w, strings.TrimRight(ansi.Strip(frame), "\n")+"\n")
+	case ANSI:
+		_, err = io.WriteString(w, strings.TrimRight(frame, "\n")+"\x1b[0m\n")
+	case SVG:
+		err = writeSVG(w, frame, c)
+	default:
+		err = fmt.Errorf("unknown screenshot format %q", f)
+	}
+	return err
+}
+
+// style is the SGR state a run of text is drawn with. Colors are "#rrggbb",
+// or empty for the default.
+type style struct {
+	fg, bg                     string
+	bold, faint, italic        bool
+	underline, reverse, strike bool
+}
+
+// run is text drawn in one style starting at column col.
+type run struct {
+	col  int
+	text string
+	style
+}
+
+// parse splits the frame into lines of styled runs. Escape sequences other
+// than SGR (cursor movement, titles, hyperlinks) are dropped.
+func parse(frame string) [][]run {
+	var lines [][]run
+	var line []run
+	var cur style
+	var text strings.Builder
+	col, start := 0, 0
+	flush := func() {
+		if text.Len() > 0 {
+			line = append(line, run{col: start, text: text.String(), style: cur})
+			text.Reset()
+		}
+		start = col
+	}
+
+	for i := 0; i < len(frame); {
+		switch c := frame[i]; {
+		case c == '\n':
+			flush()
+			lines = append(lines, line)
+			line, col, start = nil, 0, 0
+			i++
+		case c == '\x1b' && i+1 < len(frame) && frame[i+1] == '[':
+			j := i + 2
+			for j < len(frame) && (frame[j] < 0x40 || frame[j] > 0x7e) {
+				j++
+			}
+			if j < len(frame) && frame[j] == 'm' {
+				flush()
+				cur = applySGR(cur, frame[i+2:j])
+			}
+			i = j + 1
+		case c == '\x1b' && i+1 < len(frame) && frame[i+1] == ']':
+			// OSC, ended by BEL or ST.
+			j := i + 2
+			for j < len(frame) && frame[j] != '\a' && !(frame[j] == '\x1b' && j+1 < len(frame) && frame[j+1] == '\\') {
+				j++
+			}
+			if j < len(frame) && frame[j] == '\x1b' {
+				j++
+			}
+			i = j + 1
+		case c == '\x1b':
+			i += 2
+		case c == '\r':
+			i++
+		default:
+			end := i + 1
+			for end < len(frame) && frame[end] != '\x1b' && frame[end] != '\n' && frame[end] != '\r' {
+				end++
+			}
+			s := frame[i:end]
+			text.WriteString(s)
+			col += ansi.StringWidth(s)
+			i = end
+		}
+	}
+	flush()
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// applySGR updates s with the parameters of one SGR sequence.
+func applySGR(s style, params string) style {
+	if params == "" {
+		return style{}
+	}
+	p := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	n := func(i int) int {
+		if i >= len(p) {
+			return 0
+		}
+		v, _ := strconv.Atoi(p[i])
+		return v
+	}
+	// extended reads a 38/48 color starting at p[i] and returns it with the
+	// number of parameters used.
+	extended := func(i int) (string, int) {
+		switch n(i + 1) {
+		case 5:
+			return indexed(n(i + 2)), 3
+		case 2:
+			return fmt.Sprintf("#%02x%02x%02x", n(i+2), n(i+3), n(i+4)), 5
+		}
+		return "", 2
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch v := n(i); {
+		case v == 0:
+			s = style{}
+		case v == 1:
+			s.bold = true
+		case v == 2:
+			s.faint = true
+		case v == 3:
+			s.italic = true
+		case v == 4:
+			s.underline = true
+		case v == 7:
+			s.reverse = true
+		case v == 9:
+			s.strike = true
+		case v == 22:
+			s.bold, s.faint = false, false
+		case v == 23:
+			s.italic = false
+		case v == 24:
+			s.underline = false
+		case v == 27:
+			s.reverse = false
+		case v == 29:
+			s.strike = false
+		case v >= 30 && v <= 37:
+			s.fg = indexed(v - 30)
+		case v >= 90 && v <= 97:
+			s.fg = indexed(v - 90 + 8)
+		case v == 39:
+			s.fg = ""
+		case v >= 40 && v <= 47:
+			s.bg = indexed(v - 40)
+		case v >= 100 && v <= 107:
+			s.bg = indexed(v - 100 + 8)
+		case v == 49:
+			s.bg = ""
+		case v == 38, v == 48:
+			color, used := extended(i)
+			if v == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+			i += used - 1
+		}
+	}
+	return s
+}
+
+// ansi16 is the xterm default palette for the 16 basic colors.
+var ansi16 = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// indexed converts a 256-color palette number to "#rrggbb".
+func indexed(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	}
+	g := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", g, g, g)
+}
+
+// resolve turns a theme color into "#rrggbb".
+func resolve(color, fallback string) string {
+	if strings.HasPrefix(color, "#") {
+		return color
+	}
+	if n, err := strconv.Atoi(color); err == nil {
+		if hex := indexed(n); hex != "" {
+			return hex
+		}
+	}
+	return fallback
+}
+
+// Cell metrics of the SVG in pixels, for a 14px monospace font.
+const (
+	cellWidth  = 8.4
+	lineHeight = 17.0
+	fontSize   = 14
+	padding    = 12.0
+)
+
+// writeSVG draws every run as a <tspan> stretched to its exact cell width,
+// so box drawing and columns line up whatever monospace font the viewer
+// substitutes.
+func writeSVG(w io.Writer, frame string, c Colors) error {
+	fg := resolve(c.Foreground, "#e5e5e5")
+	bg := resolve(c.Background, "#000000")
+	lines := parse(frame)
+	cols := 0
+	for _, line := range lines {
+		if len(line) > 0 {
+			last := line[len(line)-1]
+			cols = max(cols, last.col+ansi.StringWidth(last.text))
+		}
+	}
+	width := float64(cols)*cellWidth + 2*padding
+	height := float64(len(lines))*lineHeight + 2*padding
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" rx="6" fill="%s"/>`+"\n", bg)
+	fmt.Fprintf(&b, `<g font-family="'DejaVu Sans Mono', Menlo, Consolas, monospace" font-size="%d" fill="%s" xml:space="preserve">`+"\n", fontSize, fg)
+
+	for y, line := range lines {
+		top := padding + float64(y)*lineHeight
+		for _, r := range line {
+			runFg, runBg := r.fg, r.bg
+			if r.reverse {
+				runFg, runBg = runBg, runFg
+				if runFg == "" {
+					runFg = bg
+				}
+				if runBg == "" {
+					runBg = fg
+				}
+			}
+			if runBg != "" {
+				fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n",
+					padding+float64(r.col)*cellWidth, top, float64(ansi.StringWidth(r.text))*cellWidth, lineHeight, runBg)
+			}
+		}
+		if len(line) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, `<text y="%.1f">`, top+lineHeight*0.78)
+		for _, r := range line {
+			if strings.TrimSpace(r.text) == "" {
+				continue
+			}
+			runFg := r.fg
+			if r.reverse {
+				runFg = r.bg
+				if runFg == "" {
+					runFg = bg
+				}
+			}
+			attrs := fmt.Sprintf(` x="%.1f" textLength="%.1f" lengthAdjust="spacingAndGlyphs"`,
+				padding+float64(r.col)*cellWidth, float64(ansi.StringWidth(r.text))*cellWidth)
+			if runFg != "" {
+				attrs += ` fill="` + runFg + `"`
+			}
+			if r.bold {
+				attrs += ` font-weight="bold"`
+			}
+			if r.faint {
+				attrs += ` opacity="0.6"`
+			}
+			if r.italic {
+				attrs += ` font-style="italic"`
+			}
+			switch {
+			case r.underline && r.strike:
+				attrs += ` text-decoration="underline line-through"`
+			case r.underline:
+				attrs += ` text-decoration="underline"`
+			case r.strike:
+				attrs += ` text-decoration="line-through"`
+			}
+			fmt.Fprintf(&b, `<tspan%s>%s</tspan>`, attrs, html.EscapeString(r.text))
+		}
+		b.WriteString("</text>\n")
+	}
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
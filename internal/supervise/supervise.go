@@ -0,0 +1,143 @@
+// Package supervise keeps track of the goroutines and subprocesses vigilix
+// runs in the background, so they can be listed when something hangs and
+// none of them outlive the program.
+//
+// Goroutines are counted when started through Go. Subprocesses need no
+// registration: they are found as children of this process in /proc, which
+// also catches the ones started by libraries.
+package supervise
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	mu      sync.Mutex
+	running = make(map[string]int)
+	wg      sync.WaitGroup
+
+	root, cancelRoot = context.WithCancel(context.Background())
+)
+
+// Context is cancelled by Shutdown. Background work that runs until
+// stopped derives its context from it.
+func Context() context.Context {
+	return root
+}
+
+// Go runs fn in a goroutine counted under name until it returns.
+func Go(name string, fn func()) {
+	mu.Lock()
+	running[name]++
+	mu.Unlock()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			mu.Lock()
+			if running[name]--; running[name] == 0 {
+				delete(running, name)
+			}
+			mu.Unlock()
+			wg.Done()
+		}()
+		fn()
+	}()
+}
+
+// Task is a kind of tracked goroutine and how many are running.
+type Task struct {
+	Name  string
+	Count int
+}
+
+// Goroutines returns the running tracked goroutines by name.
+func Goroutines() []Task {
+	mu.Lock()
+	defer mu.Unlock()
+	tasks := make([]Task, 0, len(running))
+	for name, n := range running {
+		tasks = append(tasks, Task{Name: name, Count: n})
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return tasks
+}
+
+// Process is a running child process.
+type Process struct {
+	PID     int
+	Command string
+}
+
+// Processes lists the live child processes of vigilix.
+func Processes() []Process {
+	stats, _ := filepath.Glob("/proc/[0-9]*/stat")
+	self := os.Getpid()
+	var out []Process
+	for _, path := range stats {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// pid (comm) state ppid …; comm may contain spaces and parentheses.
+		s := string(data)
+		end := strings.LastIndexByte(s, ')')
+		if end < 0 {
+			continue
+		}
+		f := strings.Fields(s[end+1:])
+		if len(f) < 2 || f[0] == "Z" {
+			continue
+		}
+		if ppid, _ := strconv.Atoi(f[1]); ppid != self {
+			continue
+		}
+		pid, _ := strconv.Atoi(filepath.Base(filepath.Dir(path)))
+		command := s[strings.IndexByte(s, '(')+1 : end]
+		if cmdline, err := os.ReadFile(filepath.Join(filepath.Dir(path), "cmdline")); err == nil && len(cmdline) > 0 {
+			command = strings.TrimSpace(strings.ReplaceAll(string(cmdline), "\x00", " "))
+		}
+		out = append(out, Process{PID: pid, Command: command})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PID < out[j].PID })
+	return out
+}
+
+// Shutdown cancels Context and gives the tracked goroutines up to timeout
+// to return. Child processes still running after that are sent SIGTERM,
+// and SIGKILL if they ignore it. Goroutines stuck in a system call (e.g.
+// statfs on a dead NFS mount) are left behind; the program is exiting.
+func Shutdown(timeout time.Duration) {
+	cancelRoot()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+
+	children := Processes()
+	if len(children) == 0 {
+		return
+	}
+	for _, p := range children {
+		syscall.Kill(p.PID, syscall.SIGTERM)
+	}
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) && len(Processes()) > 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+	for _, p := range Processes() {
+		syscall.Kill(p.PID, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,83 @@
+package systemd
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	// Units per `systemctl show` call. One call per unit costs a process
+	// and a D-Bus round trip each; one call for thousands of units hits
+	// argument limits and serializes everything behind the slowest unit.
+	showBatchSize = 64
+	// Concurrent `systemctl show` calls.
+	showWorkers = 4
+)
+
+// ShowUnitsProperties fetches the same properties for many units, keyed by
+// unit name. Units are fetched in batches of showBatchSize per `systemctl
+// show` call, with up to showWorkers calls in flight.
+func ShowUnitsProperties(names []string, props ...string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string, len(names))
+	if len(names) == 0 {
+		return result, nil
+	}
+
+	var batches [][]string
+	for start := 0; start < len(names); start += showBatchSize {
+		batches = append(batches, names[start:min(start+showBatchSize, len(names))])
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	work := make(chan []string)
+	for range min(showWorkers, len(batches)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range work {
+				part, err := showBatch(batch, props)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				for id, p := range part {
+					result[id] = p
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, b := range batches {
+		work <- b
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// showBatch runs a single `systemctl show` for a batch of units.
+func showBatch(names, props []string) (map[string]map[string]string, error) {
+	args := []string{"show", "--no-pager", "-p", strings.Join(append([]string{"Id"}, props...), ",")}
+	args = append(append(args, "--"), names...)
+	output, err := systemctl(args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]string, len(names))
+	for _, block := range strings.Split(string(output), "\n\n") {
+		p := parseProperties(block)
+		if id := p["Id"]; id != "" {
+			result[id] = p
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BootTimes splits the time the current boot took the way systemd-analyze
+// time does. Phases that did not happen, e.g. the firmware in a container
+// or the initrd on many servers, are zero.
+type BootTimes struct {
+	Started   time.Time // when the kernel started
+	Firmware  time.Duration
+	Loader    time.Duration
+	Kernel    time.Duration
+	InitRD    time.Duration
+	Userspace time.Duration
+	Finished  bool // false while units are still starting
+}
+
+// Total is the time from power-on until the last boot-up job finished.
+func (b BootTimes) Total() time.Duration {
+	return b.Firmware + b.Loader + b.Kernel + b.InitRD + b.Userspace
+}
+
+var bootProps = []string{
+	"KernelTimestamp",
+	"FirmwareTimestampMonotonic",
+	"LoaderTimestampMonotonic",
+	"InitRDTimestampMonotonic",
+	"UserspaceTimestampMonotonic",
+	"FinishTimestampMonotonic",
+}
+
+// GetBootTimes reads the boot timestamps of the system manager, whatever
+// the scope: the user manager's would be labelled as boot phases.
+func GetBootTimes() (BootTimes, error) {
+	if Offline() {
+		return BootTimes{}, ErrOffline
+	}
+	output, err := command(context.Background(), "systemctl", "show", "--no-pager", "-p", strings.Join(bootProps, ",")).Output()
+	if err != nil {
+		return BootTimes{}, err
+	}
+	props := parseProperties(string(output))
+	usec := func(key string) time.Duration {
+		v, _ := strconv.ParseInt(props[key], 10, 64)
+		return time.Duration(v) * time.Microsecond
+	}
+
+	var b BootTimes
+	b.Started, _ = ParseTimestamp(props["KernelTimestamp"])
+	firmware, loader := usec("FirmwareTimestampMonotonic"), usec("LoaderTimestampMonotonic")
+	initrd, userspace, finish := usec("InitRDTimestampMonotonic"), usec("UserspaceTimestampMonotonic"), usec("FinishTimestampMonotonic")
+	// Firmware and loader are counted back from the kernel's start.
+	if firmware > loader {
+		b.Firmware = firmware - loader
+	}
+	b.Loader = loader
+	b.Kernel = userspace
+	if initrd > 0 {
+		b.Kernel, b.InitRD = initrd, userspace-initrd
+	}
+	if finish > 0 {
+		b.Userspace, b.Finished = finish-userspace, true
+	}
+	return b, nil
+}
+
+// UnitTime is how long a unit took to start during boot.
+type UnitTime struct {
+	Unit string
+	Time time.Duration
+}
+
+// Blame returns how long each unit took to start during the current boot,
+// slowest first, from systemd-analyze blame.
+func Blame() ([]UnitTime, error) {
+	output, err := command(context.Background(), "systemd-analyze", "blame", "--no-pager").Output()
+	if err != nil {
+		return nil, err
+	}
+	var times []UnitTime
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		d, err := parseSpan(fields[:len(fields)-1])
+		if err != nil {
+			continue
+		}
+		times = append(times, UnitTime{Unit: fields[len(fields)-1], Time: d})
+	}
+	return times, scanner.Err()
+}
+
+// parseSpan reads a time span as systemd prints it, e.g. "1min 2.345s" or
+// "712ms".
+func parseSpan(parts []string) (time.Duration, error) {
+	s := strings.Join(parts, "")
+	s = strings.ReplaceAll(s, "min", "m")
+	return time.ParseDuration(s)
+}
+
+// KernelWarnings returns the kernel's messages of the current boot at
+// warning priority or worse, at most limit of them.
+func KernelWarnings(limit int) ([]LogLine, error) {
+	args := []string{"-k", "-b", "-p", "warning", "-n", strconv.Itoa(limit), "-o", "json", logFields, "--no-pager", "-q"}
+	// The kernel logs to the system journal; --user would find nothing.
+	if Offline() {
+		return scanLogLines(journalctl(context.Background(), args...))
+	}
+	return scanLogLines(command(context.Background(), "journalctl", args...))
+}
@@ -0,0 +1,70 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"time"
+)
+
+// ClockStatus is the system clock's time zone and synchronization as
+// reported by timedatectl.
+type ClockStatus struct {
+	Timezone     string
+	NTP          bool // a time synchronization service is enabled
+	Synchronized bool // the kernel considers the clock synchronized
+	// Offset is the clock's distance from the time server at the last
+	// poll. Only systemd-timesyncd reports it; HasOffset is false under
+	// chrony or ntpd.
+	Offset    time.Duration
+	HasOffset bool
+	Server    string
+}
+
+// GetClockStatus reads the clock's state from timedatectl. timedatectl
+// always talks to the system, whatever the scope.
+func GetClockStatus() (ClockStatus, error) {
+	if Offline() {
+		return ClockStatus{}, ErrOffline
+	}
+	output, err := command(context.Background(), "timedatectl", "show").Output()
+	if err != nil {
+		return ClockStatus{}, err
+	}
+	props := parseProperties(string(output))
+	c := ClockStatus{
+		Timezone:     props["Timezone"],
+		NTP:          props["NTP"] == "yes",
+		Synchronized: props["NTPSynchronized"] == "yes",
+	}
+
+	// timesync-status fails unless systemd-timesyncd is the service in use.
+	if output, err := command(context.Background(), "timedatectl", "timesync-status").Output(); err == nil {
+		c.Offset, c.HasOffset, c.Server = parseTimesync(output)
+	}
+	return c, nil
+}
+
+// parseTimesync reads the offset and server from timedatectl
+// timesync-status, whose lines look like "       Offset: -1.042ms" and
+// "       Server: 192.0.2.1 (ntp.example.com)".
+func parseTimesync(output []byte) (offset time.Duration, ok bool, server string) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Offset":
+			if d, err := time.ParseDuration(value); err == nil {
+				offset, ok = d, true
+			}
+		case "Server":
+			server = value
+		}
+	}
+	return offset, ok, server
+}
@@ -0,0 +1,89 @@
+package systemd
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// Condition is a Condition*= or Assert*= directive from a unit file.
+type Condition struct {
+	Directive string // e.g. ConditionPathExists
+	Value     string
+	Assert    bool
+	Failed    bool // not met on the last start attempt
+}
+
+// Conditions lists the unit's conditions and assertions, marking the ones
+// systemd reported as not met on the last start.
+func Conditions(name string) ([]Condition, error) {
+	content, err := GetUnitFileContent(name)
+	if err != nil {
+		return nil, err
+	}
+	conds := parseConditions(content)
+	if len(conds) == 0 {
+		return nil, nil
+	}
+
+	status, err := Status(name)
+	if err != nil {
+		return conds, err
+	}
+	failed := failedConditions(status)
+	for i := range conds {
+		if failed[conds[i].Directive+"="+conds[i].Value] {
+			conds[i].Failed = true
+		}
+	}
+	return conds, nil
+}
+
+func parseConditions(content string) []Condition {
+	var conds []Condition
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "Condition") && !strings.HasPrefix(line, "Assert") {
+			continue
+		}
+		directive, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		conds = append(conds, Condition{
+			Directive: strings.TrimSpace(directive),
+			Value:     strings.TrimSpace(value),
+			Assert:    strings.HasPrefix(line, "Assert"),
+		})
+	}
+	return conds
+}
+
+// failedConditions extracts "ConditionX=value was not met" lines from
+// `systemctl status` output.
+func failedConditions(status string) map[string]bool {
+	failed := make(map[string]bool)
+	for _, line := range strings.Split(status, "\n") {
+		idx := strings.Index(line, " was not met")
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(line[:idx])
+		if len(fields) == 0 {
+			continue
+		}
+		failed[fields[len(fields)-1]] = true
+	}
+	return failed
+}
+
+// Status returns the `systemctl status` text of a unit. systemctl exits
+// non-zero for inactive units, so that case is not treated as an error.
+func Status(name string) (string, error) {
+	output, err := systemctl("status", "--no-pager", "-n", "0", "--", name).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(output) > 0 {
+		return string(output), nil
+	}
+	return string(output), err
+}
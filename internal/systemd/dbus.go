@@ -0,0 +1,147 @@
+package systemd
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BusService is a D-Bus name that the bus daemon starts on first use.
+type BusService struct {
+	Name    string
+	Running bool
+	PID     int
+	// Unit is the systemd unit started for the name, resolved from aliases
+	// like dbus-org.freedesktop.hostname1.service. Empty when the bus
+	// daemon starts the program itself.
+	Unit string
+}
+
+// Directories holding D-Bus service activation files, per scope.
+var busServiceDirs = map[Scope][]string{
+	ScopeSystem: {"/usr/share/dbus-1/system-services", "/usr/local/share/dbus-1/system-services", "/lib/dbus-1/system-services"},
+	ScopeUser:   {"/usr/share/dbus-1/services", "/usr/local/share/dbus-1/services"},
+}
+
+// ActivatableServices lists bus-activatable names and the systemd units
+// behind them. Names come from the activation files on disk, which are
+// readable even when the bus is not; busctl adds whether each one is running.
+func ActivatableServices() ([]BusService, error) {
+	if Offline() {
+		return nil, ErrOffline
+	}
+
+	dirs := busServiceDirs[scope]
+	if scope == ScopeUser {
+		if home, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(home, ".local/share/dbus-1/services"))
+		}
+	}
+	services := make(map[string]*BusService)
+	for _, dir := range dirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.service"))
+		for _, f := range files {
+			name, unit := readBusServiceFile(f)
+			if name != "" && services[name] == nil {
+				services[name] = &BusService{Name: name, Unit: unit}
+			}
+		}
+	}
+
+	busErr := addBusState(services)
+	if busErr != nil && len(services) == 0 {
+		return nil, busErr
+	}
+
+	resolveUnitAliases(services)
+
+	out := make([]BusService, 0, len(services))
+	for _, s := range services {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// readBusServiceFile extracts Name= and SystemdService= from a D-Bus
+// activation file.
+func readBusServiceFile(path string) (name, unit string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Name":
+			name = value
+		case "SystemdService":
+			unit = value
+		}
+	}
+	return name, unit
+}
+
+// addBusState merges `busctl list --activatable` into services: running
+// names get their PID and unit, and names only the bus knows are added.
+func addBusState(services map[string]*BusService) error {
+	output, err := scopedCommand(context.Background(), "busctl", "list", "--activatable", "--no-legend", "--no-pager").Output()
+	if err != nil {
+		return err
+	}
+	// NAME PID PROCESS USER CONNECTION UNIT SESSION DESCRIPTION
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], ":") {
+			continue
+		}
+		s := services[fields[0]]
+		if s == nil {
+			s = &BusService{Name: fields[0]}
+			services[fields[0]] = s
+		}
+		if pid, err := strconv.Atoi(fields[1]); err == nil {
+			s.Running, s.PID = true, pid
+			if len(fields) > 5 && fields[5] != "-" {
+				s.Unit = fields[5]
+			}
+		}
+	}
+	return nil
+}
+
+// resolveUnitAliases replaces alias unit names (dbus-org.….service) with the
+// real unit's name, using one systemctl call for all of them.
+func resolveUnitAliases(services map[string]*BusService) {
+	var aliases []string
+	for _, s := range services {
+		if s.Unit != "" {
+			aliases = append(aliases, s.Unit)
+		}
+	}
+	props, err := ShowUnitsProperties(aliases, "Names")
+	if err != nil {
+		return
+	}
+	real := make(map[string]string)
+	for id, p := range props {
+		for _, n := range strings.Fields(p["Names"]) {
+			real[n] = id
+		}
+	}
+	for _, s := range services {
+		if id, ok := real[s.Unit]; ok {
+			s.Unit = id
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package systemd
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Denial is a SELinux AVC or AppArmor denial logged by the kernel.
+type Denial struct {
+	Time    time.Time
+	Kind    string // "SELinux" or "AppArmor"
+	Message string
+}
+
+// Slack added around the failure window, since audit records and the unit's
+// state change are not logged at exactly the same instant.
+const denialSlack = 5 * time.Second
+
+var (
+	auditPID  = regexp.MustCompile(`\bpid=(\d+)`)
+	auditComm = regexp.MustCompile(`\bcomm="?([^"\s]+)`)
+	auditExe  = regexp.MustCompile(`\bexe="?([^"\s]+)`)
+)
+
+// Denials looks for SELinux and AppArmor denials that involve the unit's
+// executable or main PID between since and until.
+func Denials(exe string, pid int, since, until time.Time) ([]Denial, error) {
+	args := []string{
+		"--since", "@" + strconv.FormatInt(since.Add(-denialSlack).Unix(), 10),
+		"--until", "@" + strconv.FormatInt(until.Add(denialSlack).Unix(), 10),
+		"-o", "short-unix", "--no-pager", "-q",
+		"_TRANSPORT=audit", "_TRANSPORT=kernel",
+	}
+	// Audit records only reach the system journal, even in user scope.
+	var cmd *exec.Cmd
+	if Offline() {
+		cmd = journalctl(context.Background(), args...)
+	} else {
+		cmd = command(context.Background(), "journalctl", args...)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseDenials(string(output), exe, pid), nil
+}
+
+func parseDenials(output, exe string, pid int) []Denial {
+	var out []Denial
+	for _, line := range strings.Split(output, "\n") {
+		kind := denialKind(line)
+		if kind == "" || !involves(line, exe, pid) {
+			continue
+		}
+		d := Denial{Kind: kind, Message: line}
+		// short-unix lines start with "<seconds>.<micros> ".
+		if stamp, rest, ok := strings.Cut(line, " "); ok {
+			if secs, err := strconv.ParseFloat(stamp, 64); err == nil {
+				d.Time = time.Unix(int64(secs), 0)
+				d.Message = rest
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func denialKind(line string) string {
+	switch {
+	case strings.Contains(line, "avc:") && strings.Contains(line, "denied"):
+		return "SELinux"
+	case strings.Contains(line, `apparmor="DENIED"`):
+		return "AppArmor"
+	}
+	return ""
+}
+
+// involves reports whether an audit line names the executable or PID.
+func involves(line, exe string, pid int) bool {
+	if pid > 0 {
+		if m := auditPID.FindStringSubmatch(line); m != nil && m[1] == strconv.Itoa(pid) {
+			return true
+		}
+	}
+	if exe == "" {
+		return false
+	}
+	if m := auditExe.FindStringSubmatch(line); m != nil && m[1] == exe {
+		return true
+	}
+	// comm is truncated by the kernel to 15 bytes.
+	base := filepath.Base(exe)
+	if len(base) > 15 {
+		base = base[:15]
+	}
+	m := auditComm.FindStringSubmatch(line)
+	return m != nil && m[1] == base
+}
+
+// ExecPath extracts the binary from an ExecStart= property value such as
+// "{ path=/usr/bin/foo ; argv[]=/usr/bin/foo -x ; ... }".
+func ExecPath(value string) string {
+	_, rest, ok := strings.Cut(value, "path=")
+	if !ok {
+		return ""
+	}
+	path, _, _ := strings.Cut(rest, " ")
+	return strings.TrimSpace(path)
+}
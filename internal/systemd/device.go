@@ -0,0 +1,59 @@
+package systemd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DeviceInfo describes the kernel device behind a .device unit.
+type DeviceInfo struct {
+	SysfsPath  string
+	Driver     string
+	Properties map[string]string // udev properties, e.g. ID_MODEL
+}
+
+// Device looks up a device unit's sysfs path, driver and udev properties.
+// Without udevadm the kernel's own uevent variables are used instead.
+func Device(name string) (*DeviceInfo, error) {
+	props, err := ShowProperties(name, "SysFSPath")
+	if err != nil {
+		return nil, err
+	}
+	d := &DeviceInfo{SysfsPath: props["SysFSPath"]}
+	if d.SysfsPath == "" {
+		return d, nil
+	}
+	d.Driver = driver(d.SysfsPath)
+
+	out, err := command(context.Background(), "udevadm", "info", "--query=property", "--path="+d.SysfsPath).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		out, err = os.ReadFile(filepath.Join(d.SysfsPath, "uevent"))
+	}
+	if err != nil {
+		return d, err
+	}
+	d.Properties = make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			d.Properties[key] = value
+		}
+	}
+	return d, nil
+}
+
+// driver is the kernel driver bound to the device or, for block devices
+// and other class devices, to its parent.
+func driver(sysfs string) string {
+	for _, link := range []string{"driver", "device/driver"} {
+		if target, err := os.Readlink(filepath.Join(sysfs, link)); err == nil {
+			return filepath.Base(target)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package systemd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DropInDir is where administrator drop-ins for a unit live: under
+// /etc/systemd/system for the system manager and ~/.config/systemd/user for
+// the user manager.
+func DropInDir(name string) (string, error) {
+	base := "/etc/systemd/system"
+	if scope == ScopeUser {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(dir, "systemd", "user")
+	}
+	return filepath.Join(base, name+".d"), nil
+}
+
+// WriteDropIn writes a drop-in file for a unit and reloads the manager so it
+// takes effect on the next (re)start. It returns the file's path.
+func WriteDropIn(name, file, content string) (string, error) {
+	if Offline() {
+		return "", ErrOffline
+	}
+	dir, err := DropInDir(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, file)
+	if err := WriteUnitFile(path, []byte(content)); err != nil {
+		return "", err
+	}
+	return path, DaemonReload()
+}
+
+// WriteUnitFile replaces a unit file or drop-in through a temporary file
+// renamed into place, so the manager never loads a half-written one.
+func WriteUnitFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// IsAdminPath reports whether a unit file or drop-in belongs to the
+// administrator: under /etc/systemd, or ~/.config/systemd for the user
+// manager. Files under /usr/lib and /run belong to packages or are
+// generated at runtime, and vigilix leaves them alone.
+func IsAdminPath(path string) bool {
+	path = filepath.Clean(path)
+	if strings.HasPrefix(path, "/etc/systemd/") {
+		return true
+	}
+	if scope != ScopeUser {
+		return false
+	}
+	dir, err := os.UserConfigDir()
+	return err == nil && strings.HasPrefix(path, filepath.Join(dir, "systemd")+"/")
+}
+
+// RemoveDropIn deletes a drop-in written by WriteDropIn and reloads.
+func RemoveDropIn(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return DaemonReload()
+}
+
+// DaemonReload makes the manager re-read unit files and drop-ins.
+func DaemonReload() error {
+	return systemctl("daemon-reload").Run()
+}
+
+// UnitFiles returns the unit file and drop-ins a unit is loaded from, in
+// the order they apply. Masked units point at /dev/null, which is left out.
+func UnitFiles(name string) ([]string, error) {
+	props, err := ShowProperties(name, "FragmentPath", "DropInPaths")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if f := props["FragmentPath"]; f != "" && f != "/dev/null" {
+		files = append(files, f)
+	}
+	return append(files, strings.Fields(props["DropInPaths"])...), nil
+}
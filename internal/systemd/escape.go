@@ -0,0 +1,80 @@
+package systemd
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Unit types whose names encode a file system path, e.g. the mount unit for
+// /mnt/my disk is "mnt-my\x20disk.mount".
+var pathUnitTypes = []string{".mount", ".automount", ".device", ".swap"}
+
+// Unescape reverses systemd's \xNN escaping in a unit name fragment, like
+// `systemd-escape --unescape`. Invalid escapes are left as they are.
+func Unescape(s string) string {
+	if !strings.Contains(s, `\x`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && s[i+1] == 'x' {
+			if c, err := strconv.ParseUint(s[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// EscapePath turns a file system path into the name fragment systemd uses
+// for it, like `systemd-escape --path`: slashes become dashes and anything
+// outside [A-Za-z0-9:_.] is written as \xNN.
+func EscapePath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "-"
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '/':
+			b.WriteByte('-')
+		case c == '.' && i == 0, !isPlainNameByte(c):
+			b.WriteString(`\x` + strconv.FormatUint(uint64(c)|0x100, 16)[1:])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func isPlainNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == ':' || c == '_' || c == '.'
+}
+
+// UnitPath returns the file system path a mount, automount, device or swap
+// unit stands for, e.g. "/dev/disk/by-uuid/1234" for
+// "dev-disk-by\x2duuid-1234.device".
+func UnitPath(name string) (string, bool) {
+	for _, t := range pathUnitTypes {
+		stem, ok := strings.CutSuffix(name, t)
+		if !ok {
+			continue
+		}
+		if stem == "-" {
+			return "/", true
+		}
+		// Dashes separate path components; literal dashes are \x2d.
+		parts := strings.Split(stem, "-")
+		for i, p := range parts {
+			parts[i] = Unescape(p)
+		}
+		return "/" + strings.Join(parts, "/"), true
+	}
+	return "", false
+}
@@ -0,0 +1,201 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Firewall is one firewall front end and what its ruleset amounts to.
+type Firewall struct {
+	Name        string // firewalld, ufw, nftables or iptables
+	Unit        string
+	ActiveState string
+	Enabled     string // UnitFileState
+	// Summary is a few lines describing the active ruleset; RulesErr says
+	// why it could not be read, usually missing privileges.
+	Summary  []string
+	RulesErr error
+}
+
+// firewalls are the front ends looked for, with the command describing
+// their active ruleset.
+var firewalls = []struct {
+	name, unit string
+	rules      func() ([]string, error)
+}{
+	{"firewalld", "firewalld.service", firewalldRules},
+	{"ufw", "ufw.service", ufwRules},
+	{"nftables", "nftables.service", nftRules},
+	{"iptables", "iptables.service", iptablesRules},
+}
+
+// Firewalls reports the firewall units installed on the system. The
+// ruleset of nftables is described even without a unit, since firewalls
+// loaded by other means still drop traffic.
+func Firewalls() ([]Firewall, error) {
+	if Offline() {
+		return nil, ErrOffline
+	}
+	if scope == ScopeUser {
+		return nil, errors.New("the firewall belongs to the system manager; run without --user")
+	}
+
+	var out []Firewall
+	for _, f := range firewalls {
+		props, err := ShowProperties(f.unit, "LoadState", "ActiveState", "UnitFileState")
+		if err != nil || props["LoadState"] != "loaded" {
+			continue
+		}
+		fw := Firewall{Name: f.name, Unit: f.unit, ActiveState: props["ActiveState"], Enabled: props["UnitFileState"]}
+		if fw.ActiveState == "active" {
+			fw.Summary, fw.RulesErr = f.rules()
+		}
+		out = append(out, fw)
+	}
+	if len(out) == 0 {
+		if summary, err := nftRules(); err == nil && len(summary) > 0 {
+			out = append(out, Firewall{Name: "nftables", ActiveState: "no unit", Summary: summary})
+		}
+	}
+	return out, nil
+}
+
+// firewallCommand runs a firewall tool in the fixed locale and returns its
+// output, or what it printed as the error.
+func firewallCommand(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s is not installed", name)
+	}
+	out, err := command(context.Background(), name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// firewalldRules lists the active zones with their services and ports.
+func firewalldRules() ([]string, error) {
+	out, err := firewallCommand("firewall-cmd", "--list-all-zones")
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	active := false
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case line == "":
+			active = false
+		case !strings.HasPrefix(line, " "):
+			active = strings.Contains(line, "(active)")
+			if active {
+				lines = append(lines, "zone "+line)
+			}
+		case active:
+			k, v, _ := strings.Cut(strings.TrimSpace(line), ":")
+			if v = strings.TrimSpace(v); v != "" && v != "no" {
+				lines = append(lines, "  "+k+": "+v)
+			}
+		}
+	}
+	return lines, nil
+}
+
+func ufwRules() ([]string, error) {
+	out, err := firewallCommand("ufw", "status", "verbose")
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimRight(line, " "); line != "" && !strings.HasPrefix(line, "--") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+var (
+	nftTable  = regexp.MustCompile(`^table (\S+) (\S+) \{`)
+	nftChain  = regexp.MustCompile(`^\s+chain (\S+) \{`)
+	nftPolicy = regexp.MustCompile(`hook (\S+) .*policy (\S+);`)
+)
+
+// nftRules counts the rules per chain and shows the base chains' hooks and
+// default policies, which decide what happens to unmatched traffic.
+func nftRules() ([]string, error) {
+	out, err := firewallCommand("nft", "list", "ruleset")
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	table, chain, hook := "", "", ""
+	rules := 0
+	flush := func() {
+		if chain != "" {
+			lines = append(lines, fmt.Sprintf("  %s %s: %d rules%s", table, chain, rules, hook))
+		}
+		chain, hook, rules = "", "", 0
+	}
+	for _, line := range strings.Split(out, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case nftTable.MatchString(line):
+			flush()
+			m := nftTable.FindStringSubmatch(line)
+			table = m[1] + " " + m[2]
+		case nftChain.MatchString(line):
+			flush()
+			chain = nftChain.FindStringSubmatch(line)[1]
+		case chain != "" && strings.HasPrefix(trimmed, "type "):
+			if m := nftPolicy.FindStringSubmatch(trimmed); m != nil {
+				hook = " · hook " + m[1] + ", policy " + m[2]
+			}
+		case chain != "" && trimmed == "}":
+			flush()
+		case chain != "" && trimmed != "":
+			rules++
+		}
+	}
+	flush()
+	return lines, nil
+}
+
+// iptablesRules shows the default policies and rule counts per chain of
+// the filter table.
+func iptablesRules() ([]string, error) {
+	out, err := firewallCommand("iptables", "-S")
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]string)
+	counts := make(map[string]int)
+	var chains []string
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Fields(line)
+		switch {
+		case len(f) >= 3 && f[0] == "-P":
+			policies[f[1]] = f[2]
+			chains = append(chains, f[1])
+		case len(f) >= 2 && f[0] == "-N":
+			chains = append(chains, f[1])
+		case len(f) >= 2 && f[0] == "-A":
+			counts[f[1]]++
+		}
+	}
+	var lines []string
+	for _, c := range chains {
+		line := fmt.Sprintf("  filter %s: %d rules", c, counts[c])
+		if p := policies[c]; p != "" {
+			line += ", policy " + p
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -0,0 +1,20 @@
+package systemd
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// SystemState returns the manager's overall state as reported by
+// `systemctl is-system-running`: "running", "degraded" (some unit failed),
+// "maintenance", "starting", "stopping", "initializing" or "offline".
+func SystemState() (string, error) {
+	out, err := systemctl("is-system-running").Output()
+	// Any state but "running" exits non-zero; the state is still printed.
+	var exitErr *exec.ExitError
+	if state := strings.TrimSpace(string(out)); state != "" && (err == nil || errors.As(err, &exitErr)) {
+		return state, nil
+	}
+	return "", err
+}
@@ -0,0 +1,65 @@
+package systemd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Job is a queued or running state change in the manager's job queue.
+type Job struct {
+	ID    int
+	Unit  string
+	Type  string // start, stop, restart, verify-active, …
+	State string // waiting or running
+	// Blocking lists the units whose jobs are ordered after this one and
+	// therefore wait for it to finish.
+	Blocking []string
+}
+
+// blockingRe matches the lines `list-jobs --before` prints under a job:
+// "	blocking job 73 (systemd-fsck@dev-sdb.service/start)".
+var blockingRe = regexp.MustCompile(`blocking job \d+ \(([^/]+)/`)
+
+// Jobs returns the job queue. Blocking is only filled in on systemd 233 and
+// newer.
+func Jobs() ([]Job, error) {
+	args := []string{"list-jobs", "--no-legend", "--no-pager", "--full"}
+	if Supports(FeatureJobOrdering) {
+		args = append(args, "--before")
+	}
+	out, err := systemctl(args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseJobs(string(out)), nil
+}
+
+func parseJobs(out string) []Job {
+	var jobs []Job
+	for _, line := range strings.Split(out, "\n") {
+		if m := blockingRe.FindStringSubmatch(line); m != nil {
+			if len(jobs) > 0 {
+				last := &jobs[len(jobs)-1]
+				last.Blocking = append(last.Blocking, m[1])
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, Job{ID: id, Unit: fields[1], Type: fields[2], State: fields[3]})
+	}
+	return jobs
+}
+
+// CancelJob removes a job from the queue. A running start job is aborted
+// and its unit left in whatever state it reached.
+func CancelJob(id int) error {
+	return systemctl("cancel", "--", strconv.Itoa(id)).Run()
+}
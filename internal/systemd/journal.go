@@ -0,0 +1,359 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsWindow is the time span covered by PriorityCounts.
+type StatsWindow int
+
+const (
+	WindowHour StatsWindow = iota
+	WindowDay
+	WindowBoot
+)
+
+func (w StatsWindow) String() string {
+	switch w {
+	case WindowDay:
+		return "24h"
+	case WindowBoot:
+		return "this boot"
+	}
+	return "1h"
+}
+
+func (w StatsWindow) args() []string {
+	switch w {
+	case WindowDay:
+		return []string{"--since", "-24h"}
+	case WindowBoot:
+		return []string{"-b"}
+	}
+	return []string{"--since", "-1h"}
+}
+
+// PriorityCounts counts a unit's journal entries per syslog priority
+// (0 emerg … 6 info) within the window. Debug messages are left out.
+func PriorityCounts(name string, window StatsWindow) ([7]int, error) {
+	var counts [7]int
+
+	args := append([]string{"--unit=" + name, "-p", "info", "-o", "json", "--output-fields=PRIORITY", "--no-pager", "-q"}, window.args()...)
+	output, err := journalctl(context.Background(), args...).Output()
+	if err != nil {
+		return counts, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry struct {
+			Priority string `json:"PRIORITY"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		p, err := strconv.Atoi(entry.Priority)
+		if err != nil || p < 0 || p >= len(counts) {
+			continue
+		}
+		counts[p]++
+	}
+	return counts, scanner.Err()
+}
+
+// JournalEntry is one journal record, as delivered by FollowJournal.
+type JournalEntry struct {
+	Time     time.Time
+	Unit     string
+	Priority int
+	Message  string
+}
+
+// FollowJournal streams new journal entries of the given units (every unit
+// when empty) with priority maxPriority or more severe, until ctx is
+// cancelled. Offline journals never grow, so they cannot be followed.
+func FollowJournal(ctx context.Context, units []string, maxPriority int, out chan<- JournalEntry) error {
+	if Offline() {
+		return ErrOffline
+	}
+	args := []string{"-f", "-n", "0", "-o", "json", "-p", strconv.Itoa(maxPriority), "--no-pager", "-q",
+		"--output-fields=MESSAGE,PRIORITY,_SYSTEMD_UNIT,_SYSTEMD_USER_UNIT"}
+	for _, u := range units {
+		args = append(args, "--unit="+u)
+	}
+	cmd := journalctl(ctx, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry, ok := parseJournalEntry(scanner.Bytes())
+		if !ok {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case out <- entry:
+		}
+	}
+	if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func parseJournalEntry(line []byte) (JournalEntry, bool) {
+	var raw struct {
+		Realtime string          `json:"__REALTIME_TIMESTAMP"`
+		Priority string          `json:"PRIORITY"`
+		Unit     string          `json:"_SYSTEMD_UNIT"`
+		UserUnit string          `json:"_SYSTEMD_USER_UNIT"`
+		Message  json.RawMessage `json:"MESSAGE"`
+	}
+	if json.Unmarshal(line, &raw) != nil {
+		return JournalEntry{}, false
+	}
+
+	var e JournalEntry
+	// Binary messages are encoded as byte arrays; only text is of interest.
+	if json.Unmarshal(raw.Message, &e.Message) != nil {
+		return JournalEntry{}, false
+	}
+	if usec, err := strconv.ParseInt(raw.Realtime, 10, 64); err == nil {
+		e.Time = time.UnixMicro(usec)
+	}
+	e.Priority, _ = strconv.Atoi(raw.Priority)
+	e.Unit = raw.Unit
+	if raw.UserUnit != "" {
+		e.Unit = raw.UserUnit
+	}
+	return e, true
+}
+
+// priorityNames are the syslog priority names journalctl -p accepts, indexed
+// by level.
+var priorityNames = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// ParsePriority accepts a syslog priority name ("err", "warning", …) or its
+// number (0–7).
+func ParsePriority(s string) (int, error) {
+	for i, name := range priorityNames {
+		if s == name {
+			return i, nil
+		}
+	}
+	if p, err := strconv.Atoi(s); err == nil && p >= 0 && p < len(priorityNames) {
+		return p, nil
+	}
+	return 0, fmt.Errorf("unknown priority %q", s)
+}
+
+// PriorityName returns the syslog name of a priority level.
+func PriorityName(p int) string {
+	if p < 0 || p >= len(priorityNames) {
+		return strconv.Itoa(p)
+	}
+	return priorityNames[p]
+}
+
+// LogLine is one journal line of a unit, formatted like journalctl's short
+// output. Cursor locates its entry, so a stream can resume after it and
+// older lines can be fetched before it; it is empty for lines that did not
+// come from the journal.
+type LogLine struct {
+	Text   string
+	Cursor string
+	Time   time.Time // when the entry was logged; zero outside the journal
+	Unit   string    // the unit that logged it, if any
+	// Priority is the syslog level of the entry, "0" (emerg) to "7"
+	// (debug), or empty for lines that did not come from the journal.
+	Priority string
+}
+
+// logFields are the fields needed to format an entry like -o short, plus
+// its priority and unit.
+const logFields = "--output-fields=MESSAGE,PRIORITY,SYSLOG_IDENTIFIER,SYSLOG_PID,_COMM,_PID,_HOSTNAME,_SYSTEMD_UNIT,_SYSTEMD_USER_UNIT"
+
+// parseLogLine decodes a line of journalctl -o json output.
+func parseLogLine(line []byte) (LogLine, bool) {
+	var raw struct {
+		Cursor     string          `json:"__CURSOR"`
+		Realtime   string          `json:"__REALTIME_TIMESTAMP"`
+		Hostname   string          `json:"_HOSTNAME"`
+		Identifier string          `json:"SYSLOG_IDENTIFIER"`
+		Comm       string          `json:"_COMM"`
+		SyslogPID  string          `json:"SYSLOG_PID"`
+		PID        string          `json:"_PID"`
+		Priority   string          `json:"PRIORITY"`
+		Unit       string          `json:"_SYSTEMD_UNIT"`
+		UserUnit   string          `json:"_SYSTEMD_USER_UNIT"`
+		Message    json.RawMessage `json:"MESSAGE"`
+	}
+	if json.Unmarshal(line, &raw) != nil {
+		return LogLine{}, false
+	}
+
+	var b strings.Builder
+	var at time.Time
+	if usec, err := strconv.ParseInt(raw.Realtime, 10, 64); err == nil {
+		at = time.UnixMicro(usec)
+		b.WriteString(at.Format(time.Stamp) + " ")
+	}
+	if raw.Hostname != "" {
+		b.WriteString(raw.Hostname + " ")
+	}
+	ident, pid := cmp.Or(raw.Identifier, raw.Comm), cmp.Or(raw.SyslogPID, raw.PID)
+	if ident != "" {
+		b.WriteString(ident)
+		if pid != "" {
+			b.WriteString("[" + pid + "]")
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(journalMessage(raw.Message))
+	return LogLine{Text: b.String(), Cursor: raw.Cursor, Time: at, Unit: cmp.Or(raw.UserUnit, raw.Unit), Priority: raw.Priority}, true
+}
+
+// journalMessage decodes a MESSAGE field, which journalctl prints as a
+// string, as an array of bytes when it is not valid text, or as an array of
+// either when the entry repeats the field.
+func journalMessage(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var data []byte
+	var codes []int
+	if json.Unmarshal(raw, &codes) == nil {
+		for _, c := range codes {
+			data = append(data, byte(c))
+		}
+		return strings.ToValidUTF8(string(data), "�")
+	}
+	var parts []json.RawMessage
+	if json.Unmarshal(raw, &parts) == nil && len(parts) > 0 {
+		return journalMessage(parts[0])
+	}
+	return ""
+}
+
+// OlderLogs returns up to n journal lines of a unit from before the entry at
+// cursor, oldest first. It returns none at the start of the journal.
+func OlderLogs(name, cursor string, n int) ([]LogLine, error) {
+	lines, err := readLogLines("--unit="+name, "-r", "-n", strconv.Itoa(n), "--after-cursor="+cursor)
+	slices.Reverse(lines)
+	return lines, err
+}
+
+// LogsAfter returns the last limit lines a unit logged after the journal
+// cursor, oldest first, and how many lines before those were left out.
+// Only the kept lines are decoded.
+func LogsAfter(name, cursor string, limit int) ([]LogLine, int, error) {
+	cmd := journalctl(context.Background(), "--unit="+name, "--after-cursor="+cursor, "-o", "json", logFields, "--no-pager", "-q")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, 0, err
+	}
+
+	// A ring of the last limit raw entries.
+	ring := make([][]byte, 0, limit)
+	total := 0
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := bytes.Clone(scanner.Bytes())
+		if len(ring) < limit {
+			ring = append(ring, entry)
+		} else {
+			ring[total%limit] = entry
+		}
+		total++
+	}
+	scanErr := scanner.Err()
+	if err := cmd.Wait(); err != nil {
+		return nil, 0, err
+	}
+	if scanErr != nil {
+		return nil, 0, scanErr
+	}
+
+	lines := make([]LogLine, 0, len(ring))
+	for i := range ring {
+		// Once full, the oldest entry is the one written next.
+		if line, ok := parseLogLine(ring[(total+i)%len(ring)]); ok {
+			lines = append(lines, line)
+		}
+	}
+	return lines, total - len(ring), nil
+}
+
+// JournalBetween returns the journal lines of every unit, and of the
+// kernel, logged from since to until, oldest first. Only the last limit
+// lines are returned when there are more.
+func JournalBetween(since, until time.Time, limit int) ([]LogLine, error) {
+	return readLogLines(
+		"--since=@"+strconv.FormatInt(since.Unix(), 10),
+		"--until=@"+strconv.FormatInt(until.Add(time.Second-1).Unix(), 10),
+		"-n", strconv.Itoa(limit))
+}
+
+// SearchJournal returns the last limit journal lines of every unit that
+// contain text, oldest first.
+func SearchJournal(text string, limit int) ([]LogLine, error) {
+	lines, err := readLogLines("--grep="+regexp.QuoteMeta(text), "--case-sensitive=true", "-n", strconv.Itoa(limit))
+	// Like grep, journalctl exits with 1 and says nothing when there is
+	// no match.
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && exit.ExitCode() == 1 && len(bytes.TrimSpace(exit.Stderr)) == 0 {
+		return nil, nil
+	}
+	return lines, err
+}
+
+// readLogLines runs journalctl with JSON output and decodes its lines.
+func readLogLines(args ...string) ([]LogLine, error) {
+	args = append(args, "-o", "json", logFields, "--no-pager", "-q")
+	return scanLogLines(journalctl(context.Background(), args...))
+}
+
+// scanLogLines runs a journalctl command printing JSON and parses its
+// entries.
+func scanLogLines(cmd *exec.Cmd) ([]LogLine, error) {
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []LogLine
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line, ok := parseLogLine(scanner.Bytes()); ok {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
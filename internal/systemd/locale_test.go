@@ -0,0 +1,108 @@
+package systemd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The fixtures in testdata/locales were captured with LC_ALL set to the
+// locale in their name.
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "locales", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseUnitsLocales(t *testing.T) {
+	want := []Unit{
+		{Name: "cron.service", LoadState: "loaded", ActiveState: "active", SubState: "running", Description: "Regular background program processing daemon"},
+		{Name: "nginx.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed", Description: "A high performance web server and a reverse proxy server"},
+		{Name: "systemd-journald.service", LoadState: "loaded", ActiveState: "active", SubState: "running", Description: "Journal Service"},
+		{Name: `dev-disk-by\x2dlabel-data.device`, LoadState: "loaded", ActiveState: "active", SubState: "plugged", Description: "/dev/disk/by-label/data"},
+		{Name: "tmp.mount", LoadState: "not-found", ActiveState: "inactive", SubState: "dead", Description: "tmp.mount"},
+	}
+	// C.UTF-8 keeps the descriptions as the unit files spell them.
+	utf8 := slices.Clone(want)
+	utf8[1].Description = "Hochleistungs-Webserver für Größe"
+	utf8[2].Description = "ジャーナルサービス"
+
+	tests := []struct {
+		fixture string
+		want    []Unit
+	}{
+		{"list-units.C.txt", want},
+		{"list-units.C.UTF-8.txt", utf8},
+		// Older systemctl marks failed units with a bullet even with
+		// --plain, "*" where the charset has no "●".
+		{"list-units.de_DE.UTF-8.txt", want},
+		{"list-units.POSIX.txt", want},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got := parseUnits(readFixture(t, tt.fixture))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseUnits:\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePropertiesLocales(t *testing.T) {
+	stamp := time.Date(2026, 10, 12, 9, 30, 1, 0, time.UTC)
+	tests := []struct {
+		fixture     string
+		description string
+		// Weekday names follow LC_TIME, so timestamps only parse in
+		// the C locale vigilix runs systemctl in.
+		parses bool
+	}{
+		{"show.C.UTF-8.txt", "Hochleistungs-Webserver für Größe", true},
+		{"show.de_DE.UTF-8.txt", "Hochleistungs-Webserver für Größe", false},
+		{"show.fr_FR.UTF-8.txt", "Serveur web", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			props := parseProperties(readFixture(t, tt.fixture))
+			if props["Description"] != tt.description {
+				t.Errorf("Description = %q, want %q", props["Description"], tt.description)
+			}
+			if props["Environment"] != "A=1 B=2" {
+				t.Errorf("Environment = %q, want %q", props["Environment"], "A=1 B=2")
+			}
+			got, ok := ParseTimestamp(props["ExecMainStartTimestamp"])
+			if ok != tt.parses {
+				t.Fatalf("ParseTimestamp(%q) ok = %v, want %v", props["ExecMainStartTimestamp"], ok, tt.parses)
+			}
+			if ok && !got.Equal(stamp) {
+				t.Errorf("ParseTimestamp = %v, want %v", got, stamp)
+			}
+		})
+	}
+}
+
+func TestCommandForcesLocale(t *testing.T) {
+	for _, locale := range []string{"de_DE.UTF-8", "fr_FR.UTF-8", "ja_JP.UTF-8"} {
+		t.Run(locale, func(t *testing.T) {
+			t.Setenv("LC_ALL", locale)
+			t.Setenv("LANG", locale)
+			// exec keeps the last of duplicate variables.
+			var last string
+			for _, v := range command(context.Background(), "systemctl").Env {
+				if value, ok := strings.CutPrefix(v, "LC_ALL="); ok {
+					last = value
+				}
+			}
+			if last != "C.UTF-8" {
+				t.Errorf("LC_ALL = %q, want C.UTF-8", last)
+			}
+		})
+	}
+}
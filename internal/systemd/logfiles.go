@@ -0,0 +1,164 @@
+package systemd
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// tailBacklog is how many existing lines TailFile shows before following.
+const tailBacklog = 200
+
+// tailPoll is how often TailFile checks a file for new lines.
+const tailPoll = 500 * time.Millisecond
+
+// OutputFiles returns the files a unit's stdout and stderr are connected to
+// with StandardOutput=file:/append:/truncate:, which bypass the journal.
+func OutputFiles(name string) ([]string, error) {
+	props, err := ShowProperties(name, "StandardOutput", "StandardError")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, key := range []string{"StandardOutput", "StandardError"} {
+		v := props[key]
+		for _, prefix := range []string{"file:", "append:", "truncate:"} {
+			if path, ok := strings.CutPrefix(v, prefix); ok && !slices.Contains(files, path) {
+				files = append(files, path)
+			}
+		}
+	}
+	return files, nil
+}
+
+// TailFile sends the last lines of a file to out and then follows it like
+// `tail -F`: it reopens the file when it is rotated or truncated, and waits
+// for it to appear when it does not exist yet. It returns when ctx is done.
+func TailFile(ctx context.Context, path string, out chan<- string) error {
+	send := func(line string) bool {
+		select {
+		case out <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var (
+		f      *os.File
+		reader *bufio.Reader
+		info   os.FileInfo
+		first  = true
+	)
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+	ticker := time.NewTicker(tailPoll)
+	defer ticker.Stop()
+
+	for {
+		if f == nil {
+			opened, err := os.Open(path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if opened != nil {
+				f, info = opened, nil
+				if fi, err := f.Stat(); err == nil {
+					info = fi
+				}
+				if first {
+					for _, line := range lastLines(f, tailBacklog) {
+						if !send(line) {
+							return nil
+						}
+					}
+				}
+				reader = bufio.NewReader(f)
+			}
+			first = false
+		}
+
+		if reader != nil {
+			for {
+				line, err := reader.ReadString('\n')
+				if err == io.EOF {
+					// Keep a partial line for the next round.
+					if line != "" {
+						f.Seek(-int64(len(line)), io.SeekCurrent)
+						reader.Reset(f)
+					}
+					break
+				}
+				if err != nil {
+					return err
+				}
+				if !send(strings.TrimRight(line, "\r\n")) {
+					return nil
+				}
+			}
+			if rotated(path, f, info) {
+				f.Close()
+				f, reader = nil, nil
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rotated reports whether path now names a different file than f, or f was
+// truncated below the current read position.
+func rotated(path string, f *os.File, info os.FileInfo) bool {
+	current, err := os.Stat(path)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	if info != nil && !os.SameFile(info, current) {
+		return true
+	}
+	pos, err := f.Seek(0, io.SeekCurrent)
+	return err == nil && current.Size() < pos
+}
+
+// lastLines returns up to n complete lines from the end of f and leaves the
+// offset at the end of the last of them.
+func lastLines(f *os.File, n int) []string {
+	const chunk = 64 << 10
+	end, err := f.Seek(0, io.SeekEnd)
+	if err != nil || end == 0 {
+		return nil
+	}
+	start := max(end-chunk, 0)
+	buf := make([]byte, end-start)
+	if _, err := f.ReadAt(buf, start); err != nil && err != io.EOF {
+		return nil
+	}
+	// Stop at the last newline so a line being written is read in full
+	// later, and drop the first line when it was cut by the chunk.
+	last := strings.LastIndexByte(string(buf), '\n')
+	if last < 0 {
+		f.Seek(start, io.SeekStart)
+		return nil
+	}
+	f.Seek(start+int64(last)+1, io.SeekStart)
+	text := string(buf[:last])
+	if start > 0 {
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[i+1:]
+		}
+	}
+	lines := strings.Split(text, "\n")
+	return lines[max(len(lines)-n, 0):]
+}
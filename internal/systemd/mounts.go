@@ -0,0 +1,68 @@
+package systemd
+
+import (
+	"sort"
+	"strings"
+)
+
+// Mount is a mount or automount unit with what it mounts where.
+type Mount struct {
+	Unit        string
+	Where       string // mount point
+	What        string // device, e.g. /dev/sda1 or server:/export
+	Type        string // file system
+	Options     string
+	ActiveState string
+	SubState    string
+	Result      string // why the last attempt failed, e.g. "exit-code"
+	Automount   bool
+}
+
+// Failed reports whether the unit is in the failed state.
+func (m Mount) Failed() bool {
+	return m.ActiveState == "failed"
+}
+
+// Mounts lists all loaded mount and automount units, sorted by mount point.
+func Mounts() ([]Mount, error) {
+	units, err := ListUnitsFiltered(Filter{Types: []string{"mount", "automount"}})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	states := make(map[string]Unit)
+	for _, u := range units {
+		if strings.HasSuffix(u.Name, ".mount") || strings.HasSuffix(u.Name, ".automount") {
+			names = append(names, u.Name)
+			states[u.Name] = u
+		}
+	}
+	props, err := ShowUnitsProperties(names, "Where", "What", "Type", "Options", "Result")
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make([]Mount, 0, len(names))
+	for _, name := range names {
+		p := props[name]
+		mounts = append(mounts, Mount{
+			Unit:        name,
+			Where:       p["Where"],
+			What:        p["What"],
+			Type:        p["Type"],
+			Options:     p["Options"],
+			ActiveState: states[name].ActiveState,
+			SubState:    states[name].SubState,
+			Result:      p["Result"],
+			Automount:   strings.HasSuffix(name, ".automount"),
+		})
+	}
+	sort.Slice(mounts, func(i, j int) bool { return mounts[i].Where < mounts[j].Where })
+	return mounts, nil
+}
+
+// Remount re-applies a mount unit's options (`mount -o remount`) through
+// systemd, which implements reload for mount units that way.
+func Remount(unit string) error {
+	return systemctl("reload", "--", unit).Run()
+}
@@ -0,0 +1,56 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrOffline is returned by operations that need a running systemd while
+// vigilix is browsing an exported journal directory.
+var ErrOffline = errors.New("not available when browsing an offline journal")
+
+// journalDir, when set, points journalctl at exported journal files
+// (journalctl -D) and disables everything that needs systemctl.
+var journalDir string
+
+// SetJournalDir switches vigilix to read-only browsing of the journal files
+// in dir, e.g. copied off a machine that no longer boots.
+func SetJournalDir(dir string) {
+	journalDir = dir
+}
+
+// Offline reports whether vigilix is browsing an offline journal.
+func Offline() bool {
+	return journalDir != ""
+}
+
+// JournalDir returns the directory set with SetJournalDir.
+func JournalDir() string {
+	return journalDir
+}
+
+// listJournalUnits derives the unit list from the units that logged to the
+// offline journal, since there is no manager to ask.
+func listJournalUnits() ([]Unit, error) {
+	output, err := journalctl(context.Background(), "-F", "_SYSTEMD_UNIT").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var units []Unit
+	for _, name := range strings.Split(string(output), "\n") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		units = append(units, Unit{
+			Name:        name,
+			LoadState:   "journal",
+			ActiveState: "unknown",
+			SubState:    "unknown",
+			Description: "from offline journal",
+		})
+	}
+	return units, nil
+}
@@ -0,0 +1,35 @@
+package systemd
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// quadletExts are the source file types Podman's Quadlet generator turns
+// into services.
+var quadletExts = map[string]bool{
+	".container": true,
+	".pod":       true,
+	".volume":    true,
+	".network":   true,
+	".kube":      true,
+	".image":     true,
+	".build":     true,
+}
+
+// QuadletSource returns the Quadlet file a unit was generated from, which
+// systemd records as the unit's SourcePath.
+func QuadletSource(name string) (string, bool) {
+	props, err := ShowProperties(name, "SourcePath")
+	if err != nil {
+		return "", false
+	}
+	path := props["SourcePath"]
+	return path, path != "" && quadletExts[filepath.Ext(path)]
+}
+
+// ReadQuadlet reads a Quadlet source file.
+func ReadQuadlet(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
@@ -0,0 +1,112 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Transient timers created by vigilix share this prefix so they can be
+// listed and cancelled later.
+const schedulePrefix = "vigilix-sched-"
+
+var unsafeNameChars = regexp.MustCompile(`[^a-zA-Z0-9:_.\-]`)
+
+// ScheduledAction is a pending one-off action backed by a transient timer.
+type ScheduledAction struct {
+	Timer       string
+	Description string
+	Next        time.Time
+}
+
+// ScheduleAction arranges for `systemctl <action> <unit>` to run once at the
+// given time using a transient timer (systemd-run). when is either a
+// relative delay such as "+30m", a clock time such as "02:00" (the next
+// occurrence is used) or any OnCalendar= expression.
+func ScheduleAction(action, unit, when string) (string, error) {
+	if Offline() {
+		return "", ErrOffline
+	}
+
+	name := fmt.Sprintf("%s%s-%s-%d", schedulePrefix, action, unsafeNameChars.ReplaceAllString(unit, "_"), time.Now().Unix())
+	args := []string{
+		"--unit=" + name,
+		"--description=vigilix: " + action + " " + unit,
+		"--timer-property=RemainAfterElapsed=no",
+	}
+
+	trigger, err := timerTrigger(when, time.Now())
+	if err != nil {
+		return "", err
+	}
+	args = append(args, trigger)
+
+	systemctlArgs := []string{"systemctl", action, "--", unit}
+	if scope == ScopeUser {
+		args = append([]string{"--user"}, args...)
+		systemctlArgs = []string{"systemctl", "--user", action, "--", unit}
+	}
+	args = append(args, systemctlArgs...)
+
+	if output, err := command(context.Background(), "systemd-run", args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("systemd-run: %s", strings.TrimSpace(string(output)))
+	}
+	return name + ".timer", nil
+}
+
+// timerTrigger converts a user-supplied time into a systemd-run option.
+func timerTrigger(when string, now time.Time) (string, error) {
+	when = strings.TrimSpace(when)
+	if when == "" {
+		return "", fmt.Errorf("no time given")
+	}
+
+	if d, err := time.ParseDuration(strings.TrimPrefix(when, "+")); err == nil {
+		return fmt.Sprintf("--on-active=%ds", int(d.Seconds())), nil
+	}
+
+	// A bare clock time would repeat daily as OnCalendar=, so pin it to
+	// its next occurrence to keep the action one-off.
+	if t, err := time.ParseInLocation("15:04", when, time.Local); err == nil {
+		next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return "--on-calendar=" + next.Format("2006-01-02 15:04:05"), nil
+	}
+
+	return "--on-calendar=" + when, nil
+}
+
+// ListScheduled returns the pending actions created by ScheduleAction.
+func ListScheduled() ([]ScheduledAction, error) {
+	output, err := systemctl("list-units", "--all", "--no-legend", "--no-pager", "--type=timer", schedulePrefix+"*").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var timers []string
+	for _, u := range parseUnits(string(output)) {
+		timers = append(timers, u.Name)
+	}
+	props, err := ShowUnitsProperties(timers, "Description", "NextElapseUSecRealtime")
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []ScheduledAction
+	for _, t := range timers {
+		p := props[t]
+		next, _ := ParseTimestamp(p["NextElapseUSecRealtime"])
+		actions = append(actions, ScheduledAction{Timer: t, Description: p["Description"], Next: next})
+	}
+	return actions, nil
+}
+
+// CancelScheduled stops a pending action's timer and its service.
+func CancelScheduled(timer string) error {
+	service := strings.TrimSuffix(timer, ".timer") + ".service"
+	return systemctl("stop", timer, service).Run()
+}
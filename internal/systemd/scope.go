@@ -0,0 +1,73 @@
+package systemd
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+)
+
+// Scope selects which systemd instance vigilix talks to.
+type Scope int
+
+const (
+	ScopeSystem Scope = iota
+	ScopeUser
+)
+
+func (s Scope) String() string {
+	if s == ScopeUser {
+		return "user"
+	}
+	return "system"
+}
+
+var scope = ScopeSystem
+
+// SetScope switches all subsequent calls between the system and user manager.
+func SetScope(s Scope) {
+	scope = s
+}
+
+// CurrentScope returns the scope set with SetScope.
+func CurrentScope() Scope {
+	return scope
+}
+
+// systemctl builds a systemctl command for the current scope. In offline
+// mode the command fails with ErrOffline as soon as it is run.
+func systemctl(args ...string) *exec.Cmd {
+	cmd := scopedCommand(context.Background(), "systemctl", args...)
+	if Offline() {
+		cmd.Err = ErrOffline
+	}
+	return cmd
+}
+
+// journalctl builds a journalctl command for the current scope, reading the
+// offline journal directory instead when one is set.
+func journalctl(ctx context.Context, args ...string) *exec.Cmd {
+	if Offline() {
+		return command(ctx, "journalctl", append([]string{"-D", journalDir}, args...)...)
+	}
+	return scopedCommand(ctx, "journalctl", args...)
+}
+
+func scopedCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	if scope == ScopeUser {
+		args = append([]string{"--user"}, args...)
+	}
+	return command(ctx, name, args...)
+}
+
+// command runs a systemd tool in a fixed locale. Column headers, state
+// words and timestamps ("Mon 2026-10-12 …") are translated under other
+// locales, which would break parsing. C.UTF-8 keeps non-ASCII unit
+// descriptions and log messages intact; where it is not installed, the C
+// library falls back to plain C, which parses the same.
+func command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	slog.Debug("exec", "cmd", name, "args", args)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C.UTF-8")
+	return cmd
+}
@@ -0,0 +1,149 @@
+package systemd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Swap is a swap device or file, with its unit when systemd manages it.
+type Swap struct {
+	Unit        string // empty for swap enabled outside systemd
+	What        string
+	Type        string // "partition", "file"; zram devices are partitions
+	Size, Used  uint64 // bytes; zero while inactive
+	Priority    int
+	ActiveState string
+	Zram        *Zram
+}
+
+// Zram holds the compression statistics of a zram swap device.
+type Zram struct {
+	Algorithm  string
+	Original   uint64 // bytes stored
+	Compressed uint64 // bytes after compression
+	MemoryUsed uint64 // bytes of RAM used, including overhead
+	DiskSize   uint64 // configured device size
+}
+
+// Swaps lists swap units together with the kernel's view from /proc/swaps.
+func Swaps() ([]Swap, error) {
+	if Offline() {
+		return nil, ErrOffline
+	}
+	active, err := readProcSwaps()
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := ListUnitsFiltered(Filter{Types: []string{"swap"}})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	states := make(map[string]string)
+	for _, u := range units {
+		if strings.HasSuffix(u.Name, ".swap") {
+			names = append(names, u.Name)
+			states[u.Name] = u.ActiveState
+		}
+	}
+	props, err := ShowUnitsProperties(names, "What")
+	if err != nil {
+		return nil, err
+	}
+	// Several .swap units alias one device (by-uuid, by-path, …); the
+	// kernel lists it once under its resolved name.
+	var swaps []Swap
+	seen := make(map[string]bool)
+	for _, name := range names {
+		what := props[name]["What"]
+		dev := resolvePath(what)
+		if seen[dev] {
+			continue
+		}
+		seen[dev] = true
+		s := active[dev]
+		delete(active, dev)
+		s.Unit, s.What, s.ActiveState = name, what, states[name]
+		swaps = append(swaps, s)
+	}
+	for _, s := range active {
+		s.ActiveState = "active"
+		swaps = append(swaps, s)
+	}
+
+	for i := range swaps {
+		if base := filepath.Base(resolvePath(swaps[i].What)); strings.HasPrefix(base, "zram") {
+			swaps[i].Zram = readZram(base)
+		}
+	}
+	sort.Slice(swaps, func(i, j int) bool { return swaps[i].What < swaps[j].What })
+	return swaps, nil
+}
+
+// readProcSwaps parses /proc/swaps, keyed by file name.
+func readProcSwaps() (map[string]Swap, error) {
+	f, err := os.Open("/proc/swaps")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	swaps := make(map[string]Swap)
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		size, _ := strconv.ParseUint(fields[2], 10, 64)
+		used, _ := strconv.ParseUint(fields[3], 10, 64)
+		prio, _ := strconv.Atoi(fields[4])
+		// Spaces in file names are escaped as \040.
+		name := strings.ReplaceAll(fields[0], `\040`, " ")
+		swaps[name] = Swap{What: name, Type: fields[1], Size: size * 1024, Used: used * 1024, Priority: prio}
+	}
+	return swaps, scanner.Err()
+}
+
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// readZram reads a zram device's statistics from sysfs, or nil.
+func readZram(dev string) *Zram {
+	dir := filepath.Join("/sys/block", dev)
+	data, err := os.ReadFile(filepath.Join(dir, "mm_stat"))
+	if err != nil {
+		return nil
+	}
+	// orig_data_size compr_data_size mem_used_total mem_limit ...
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil
+	}
+	z := &Zram{}
+	z.Original, _ = strconv.ParseUint(fields[0], 10, 64)
+	z.Compressed, _ = strconv.ParseUint(fields[1], 10, 64)
+	z.MemoryUsed, _ = strconv.ParseUint(fields[2], 10, 64)
+	if size, err := os.ReadFile(filepath.Join(dir, "disksize")); err == nil {
+		z.DiskSize, _ = strconv.ParseUint(strings.TrimSpace(string(size)), 10, 64)
+	}
+	// The active algorithm is the bracketed one: "lzo [zstd] lz4".
+	if algos, err := os.ReadFile(filepath.Join(dir, "comp_algorithm")); err == nil {
+		for _, a := range strings.Fields(string(algos)) {
+			if strings.HasPrefix(a, "[") {
+				z.Algorithm = strings.Trim(a, "[]")
+			}
+		}
+	}
+	return z
+}
@@ -3,8 +3,12 @@ package systemd
 import (
 	"bufio"
 	"context"
-	"os/exec"
+	"encoding/json"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Unit struct {
@@ -15,10 +19,96 @@ type Unit struct {
 	Description string
 }
 
-// ListUnits returns a list of all systemd units.
-func ListUnits() ([]Unit, error) {
+// unitTypes are the suffixes systemd recognises in unit names.
+var unitTypes = []string{
+	".service", ".socket", ".target", ".timer", ".mount", ".automount",
+	".swap", ".path", ".slice", ".scope", ".device",
+}
+
+// UnitName completes a bare name like "nginx" to "nginx.service", and a path
+// like "/mnt/data" to its mount (or, under /dev, device) unit, the way
+// systemctl does. Names that already carry a unit type are returned as is.
+func UnitName(name string) string {
+	if strings.HasPrefix(name, "/") {
+		if strings.HasPrefix(name, "/dev/") {
+			return EscapePath(name) + ".device"
+		}
+		return EscapePath(name) + ".mount"
+	}
+	for _, t := range unitTypes {
+		if strings.HasSuffix(name, t) {
+			return name
+		}
+	}
+	return name + ".service"
+}
+
+// ListUnits returns a list of all systemd units, or of those matching any
+// of the glob patterns when some are given.
+func ListUnits(patterns ...string) ([]Unit, error) {
+	return ListUnitsFiltered(Filter{Patterns: patterns})
+}
+
+// Filter narrows a unit listing down in systemctl itself, so that large
+// systems do not send and parse thousands of units only to drop most of
+// them. Empty fields match every unit.
+type Filter struct {
+	States   []string // load, active or sub states, e.g. "failed", "running"
+	Types    []string // unit types, e.g. "service", "mount"
+	Patterns []string // glob patterns for the unit name
+}
+
+func (f Filter) args() []string {
+	var args []string
+	if len(f.States) > 0 {
+		args = append(args, "--state="+strings.Join(f.States, ","))
+	}
+	if len(f.Types) > 0 {
+		args = append(args, "--type="+strings.Join(f.Types, ","))
+	}
+	return append(append(args, "--"), f.Patterns...)
+}
+
+// Matches applies the filter to a unit that is already listed.
+func (f Filter) Matches(u Unit) bool {
+	if len(f.States) > 0 && !slices.Contains(f.States, u.LoadState) &&
+		!slices.Contains(f.States, u.ActiveState) && !slices.Contains(f.States, u.SubState) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, strings.TrimPrefix(path.Ext(u.Name), ".")) {
+		return false
+	}
+	return len(f.Patterns) == 0 || MatchesAny(f.Patterns, u.Name)
+}
+
+// ListUnitsFiltered returns the units that match the filter.
+func ListUnitsFiltered(f Filter) ([]Unit, error) {
+	if Offline() {
+		// The journal has no notion of unit state; filter what it has.
+		units, err := listJournalUnits()
+		if err != nil {
+			return nil, err
+		}
+		var matching []Unit
+		for _, u := range units {
+			if f.Matches(u) {
+				matching = append(matching, u)
+			}
+		}
+		return matching, nil
+	}
+	if Supports(FeatureJSONOutput) {
+		args := append([]string{"list-units", "--all", "--no-pager", "--output=json"}, f.args()...)
+		output, err := systemctl(args...).Output()
+		if err != nil {
+			return nil, err
+		}
+		return parseUnitsJSON(output)
+	}
+
 	// We use --no-legend and --no-pager for easier parsing
-	cmd := exec.Command("systemctl", "list-units", "--all", "--no-legend", "--no-pager")
+	args := append([]string{"list-units", "--all", "--no-legend", "--no-pager", "--plain"}, f.args()...)
+	cmd := systemctl(args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -27,17 +117,80 @@ func ListUnits() ([]Unit, error) {
 	return parseUnits(string(output)), nil
 }
 
+// MatchesAny reports whether the unit name matches any of the glob
+// patterns, e.g. "nginx*" or "*.timer".
+func MatchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// UnitFileStates returns the enablement state of every installed unit file
+// ("enabled", "disabled", "static", "masked", ...), by unit name.
+func UnitFileStates() (map[string]string, error) {
+	if Offline() {
+		return nil, ErrOffline
+	}
+	output, err := systemctl("list-unit-files", "--no-legend", "--no-pager").Output()
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		// UNIT FILE STATE [PRESET]
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		states[fields[0]] = fields[1]
+	}
+	return states, nil
+}
+
+func parseUnitsJSON(output []byte) ([]Unit, error) {
+	var raw []struct {
+		Unit        string `json:"unit"`
+		Load        string `json:"load"`
+		Active      string `json:"active"`
+		Sub         string `json:"sub"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, err
+	}
+
+	units := make([]Unit, len(raw))
+	for i, r := range raw {
+		units[i] = Unit{
+			Name:        r.Unit,
+			LoadState:   r.Load,
+			ActiveState: r.Active,
+			SubState:    r.Sub,
+			Description: r.Description,
+		}
+	}
+	return units, nil
+}
+
 func parseUnits(output string) []Unit {
 	var units []Unit
 	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		fields := strings.Fields(line)
-		if len(fields) < 5 {
+		// Older systemctl versions mark failed units with a bullet even
+		// with --plain.
+		if len(fields) > 0 && (fields[0] == "●" || fields[0] == "*") {
+			fields = fields[1:]
+		}
+		if len(fields) < 4 {
 			continue
 		}
-		// systemctl output format varies, but usually:
-		// UNIT LOAD ACTIVE SUB DESCRIPTION
-		// We'll take a simplified approach for now
+		// UNIT LOAD ACTIVE SUB DESCRIPTION. Unit names never contain
+		// whitespace (systemd escapes it as \x20), so only the
+		// description may span several fields.
 		unit := Unit{
 			Name:        fields[0],
 			LoadState:   fields[1],
@@ -51,28 +204,48 @@ func parseUnits(output string) []Unit {
 }
 
 func StartUnit(name string) error {
-	return exec.Command("systemctl", "start", name).Run()
+	return systemctl("start", "--", name).Run()
 }
 
 func StopUnit(name string) error {
-	return exec.Command("systemctl", "stop", name).Run()
+	return systemctl("stop", "--", name).Run()
 }
 
 func RestartUnit(name string) error {
-	return exec.Command("systemctl", "restart", name).Run()
+	return systemctl("restart", "--", name).Run()
+}
+
+func ReloadUnit(name string) error {
+	return systemctl("reload", "--", name).Run()
+}
+
+// ResetFailed clears a unit's failed state and its restart counter.
+func ResetFailed(name string) error {
+	return systemctl("reset-failed", "--", name).Run()
+}
+
+// GlobAction runs a start, stop, restart or reload on every loaded unit
+// matching the glob pattern, expanded by systemctl.
+func GlobAction(verb, pattern string) error {
+	return systemctl(verb, "--", pattern).Run()
 }
 
 func EnableUnit(name string) error {
-	return exec.Command("systemctl", "enable", name).Run()
+	return systemctl("enable", "--", name).Run()
 }
 
 func DisableUnit(name string) error {
-	return exec.Command("systemctl", "disable", name).Run()
+	return systemctl("disable", "--", name).Run()
 }
 
-func GetLogs(name string) (string, error) {
-	// journalctl -u name -n 100 --no-pager
-	cmd := exec.Command("journalctl", "-u", name, "-n", "100", "--no-pager")
+// KillUnit sends a signal (e.g. "SIGTERM") to all processes of a unit.
+func KillUnit(name, signal string) error {
+	return systemctl("kill", "--signal="+signal, "--", name).Run()
+}
+
+// RecentLogs returns the last n journal lines of a unit.
+func RecentLogs(name string, n int) (string, error) {
+	cmd := journalctl(context.Background(), "--unit="+name, "-n", strconv.Itoa(n), "--no-pager")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -80,8 +253,21 @@ func GetLogs(name string) (string, error) {
 	return string(output), nil
 }
 
-func StreamLogs(ctx context.Context, name string, out chan<- string) error {
-	cmd := exec.CommandContext(ctx, "journalctl", "-f", "-u", name, "--no-pager")
+// StreamLogs follows the journal of a unit, starting with its last 100
+// lines or, when after is a cursor, with the entries following it, so a
+// reconnected stream neither repeats nor misses lines.
+func StreamLogs(ctx context.Context, name, after string, out chan<- LogLine) error {
+	args := []string{"-f", "--unit=" + name, "-o", "json", logFields, "--no-pager", "-q"}
+	if Offline() {
+		// Nothing new will arrive, so load a larger backlog instead of following.
+		args = []string{"--unit=" + name, "-n", "1000", "-o", "json", logFields, "--no-pager", "-q"}
+	}
+	if after != "" {
+		args = append(args, "--no-tail", "--after-cursor="+after)
+	} else if !Offline() {
+		args = append(args, "-n", "100")
+	}
+	cmd := journalctl(ctx, args...)
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -91,22 +277,75 @@ func StreamLogs(ctx context.Context, name string, out chan<- string) error {
 	}
 
 	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
 	for scanner.Scan() {
-		text := scanner.Text()
+		line, ok := parseLogLine(scanner.Bytes())
+		if !ok {
+			continue
+		}
 		select {
 		case <-ctx.Done():
-			return nil
-		case out <- text:
+		case out <- line:
+			continue
 		}
+		break
+	}
+	// Always reap the process; cancelling ctx kills it.
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
 	}
-	return cmd.Wait()
+	return err
 }
 
 func GetUnitFileContent(name string) (string, error) {
-	cmd := exec.Command("systemctl", "cat", name, "--no-pager")
+	cmd := systemctl("cat", "--no-pager", "--", name)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
 	}
 	return string(output), nil
 }
+
+// ShowProperties returns the requested properties of a unit as reported by
+// `systemctl show`.
+func ShowProperties(name string, props ...string) (map[string]string, error) {
+	args := []string{"show", "--no-pager"}
+	if len(props) > 0 {
+		args = append(args, "-p", strings.Join(props, ","))
+	}
+	args = append(args, "--", name)
+	output, err := systemctl(args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseProperties(string(output)), nil
+}
+
+func parseProperties(output string) map[string]string {
+	props := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		props[key] = value
+	}
+	return props
+}
+
+// systemd prints timestamps like "Mon 2026-10-12 09:13:01 UTC".
+const timestampLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// ParseTimestamp parses a timestamp property value. It reports false for
+// empty values, which systemd uses for events that never happened.
+func ParseTimestamp(value string) (time.Time, bool) {
+	if value == "" || value == "n/a" {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(timestampLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
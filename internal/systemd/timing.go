@@ -0,0 +1,97 @@
+package systemd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transition measures how long an action took to bring a unit to its new
+// state. Phases that did not happen (e.g. deactivating on start) are zero.
+type Transition struct {
+	Action       string
+	Started      time.Time
+	Total        time.Duration // from issuing the action until systemctl returned
+	Deactivating time.Duration
+	Activating   time.Duration
+}
+
+// Queued is the part of Total spent outside the unit's own transition:
+// waiting in the job queue behind other jobs and dependencies.
+func (t Transition) Queued() time.Duration {
+	if q := t.Total - t.Deactivating - t.Activating; q > 0 {
+		return q
+	}
+	return 0
+}
+
+// Monotonic timestamps bracketing the activating and deactivating phases.
+var transitionProps = []string{
+	"InactiveExitTimestampMonotonic",
+	"ActiveEnterTimestampMonotonic",
+	"ActiveExitTimestampMonotonic",
+	"InactiveEnterTimestampMonotonic",
+}
+
+// TimedAction runs a start, stop or restart and measures its phases from the
+// unit's own state-change timestamps. Only timestamps that moved during the
+// action count, so a failed start does not report a stale phase.
+func TimedAction(action, name string) (Transition, error) {
+	t := Transition{Action: action, Started: time.Now()}
+	before, err := ShowProperties(name, transitionProps...)
+	if err != nil {
+		return t, err
+	}
+
+	if err := systemctl(action, "--", name).Run(); err != nil {
+		return t, err
+	}
+	t.Total = time.Since(t.Started)
+
+	after, err := ShowProperties(name, transitionProps...)
+	if err != nil {
+		return t, err
+	}
+	t.Activating = phase(before, after, "InactiveExitTimestampMonotonic", "ActiveEnterTimestampMonotonic")
+	t.Deactivating = phase(before, after, "ActiveExitTimestampMonotonic", "InactiveEnterTimestampMonotonic")
+	return t, nil
+}
+
+// phase is the time between two monotonic timestamps, provided both were
+// updated by the action being measured.
+func phase(before, after map[string]string, from, to string) time.Duration {
+	if before[from] == after[from] || before[to] == after[to] {
+		return 0
+	}
+	start, err1 := strconv.ParseInt(after[from], 10, 64)
+	end, err2 := strconv.ParseInt(after[to], 10, 64)
+	if err1 != nil || err2 != nil || end < start {
+		return 0
+	}
+	return time.Duration(end-start) * time.Microsecond
+}
+
+// timespanUnits are the suffixes systemd uses when printing time spans.
+var timespanUnits = map[string]time.Duration{
+	"us": time.Microsecond, "ms": time.Millisecond, "s": time.Second,
+	"min": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour,
+}
+
+// ParseTimespan reads a time span as `systemctl show` prints it, e.g.
+// "1min 30s" or "500ms". It reports false for "infinity" and empty values.
+func ParseTimespan(value string) (time.Duration, bool) {
+	var total time.Duration
+	for _, part := range strings.Fields(value) {
+		i := strings.IndexFunc(part, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(part[:i], 64)
+		unit, ok := timespanUnits[part[i:]]
+		if err != nil || !ok {
+			return 0, false
+		}
+		total += time.Duration(n * float64(unit))
+	}
+	return total, total > 0
+}
@@ -0,0 +1,104 @@
+package systemd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SocketStatus describes a .socket unit and the service it activates.
+type SocketStatus struct {
+	Listen      []string // e.g. "[::]:22 (Stream)"
+	Listening   bool
+	Accepted    int // connections accepted since the socket started
+	Connections int // connections currently open
+	Refused     int
+	Triggers    []string // the backing service(s)
+}
+
+// Socket reports the listening and activation state of a socket unit.
+func Socket(name string) (*SocketStatus, error) {
+	output, err := systemctl("show", "--no-pager",
+		"-p", "Listen,SubState,NAccepted,NConnections,NRefused,Triggers", "--", name).Output()
+	if err != nil {
+		return nil, err
+	}
+	props := parseProperties(string(output))
+
+	s := &SocketStatus{
+		Listen:    multiValue(string(output), "Listen"),
+		Listening: props["SubState"] == "listening" || props["SubState"] == "running",
+		Triggers:  strings.Fields(props["Triggers"]),
+	}
+	s.Accepted, _ = strconv.Atoi(props["NAccepted"])
+	s.Connections, _ = strconv.Atoi(props["NConnections"])
+	s.Refused, _ = strconv.Atoi(props["NRefused"])
+	return s, nil
+}
+
+// Triggers lists the units the named trigger unit (socket, path or timer)
+// activates.
+func Triggers(name string) ([]string, error) {
+	props, err := ShowProperties(name, "Triggers")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(props["Triggers"]), nil
+}
+
+// IsTrigger reports whether a unit's type activates other units on demand.
+func IsTrigger(name string) bool {
+	return strings.HasSuffix(name, ".socket") || strings.HasSuffix(name, ".path") || strings.HasSuffix(name, ".timer")
+}
+
+// multiValue collects a property systemctl show prints once per value, such
+// as Listen=, which parseProperties would collapse to the last one.
+func multiValue(output, key string) []string {
+	var values []string
+	for _, line := range strings.Split(output, "\n") {
+		if v, ok := strings.CutPrefix(line, key+"="); ok && v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// WatchedPath is one PathExists=, PathChanged=, … directive of a path unit.
+type WatchedPath struct {
+	Condition string // e.g. "PathModified"
+	Path      string
+	Exists    bool
+}
+
+// PathStatus describes a .path unit and the unit it activates.
+type PathStatus struct {
+	Paths    []WatchedPath
+	Waiting  bool // watching for the condition
+	Triggers []string
+}
+
+// PathUnit reports what a path unit watches and whether those paths exist
+// right now.
+func PathUnit(name string) (*PathStatus, error) {
+	output, err := systemctl("show", "--no-pager", "-p", "Paths,SubState,Triggers", "--", name).Output()
+	if err != nil {
+		return nil, err
+	}
+	props := parseProperties(string(output))
+
+	p := &PathStatus{
+		Waiting:  props["SubState"] == "waiting",
+		Triggers: strings.Fields(props["Triggers"]),
+	}
+	// Each value looks like "PathExists (/run/foo.flag)".
+	for _, v := range multiValue(string(output), "Paths") {
+		cond, path, ok := strings.Cut(v, " (")
+		if !ok || !strings.HasSuffix(path, ")") {
+			continue
+		}
+		path = strings.TrimSuffix(path, ")")
+		_, statErr := os.Stat(path)
+		p.Paths = append(p.Paths, WatchedPath{Condition: cond, Path: path, Exists: statErr == nil})
+	}
+	return p, nil
+}
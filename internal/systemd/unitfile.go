@@ -0,0 +1,49 @@
+package systemd
+
+import "strings"
+
+// PathRef is a filesystem path referenced by a unit file directive.
+type PathRef struct {
+	Directive string
+	Path      string
+}
+
+// Directives whose value starts with (or is) a path.
+var pathDirectives = map[string]bool{
+	"ExecStart":        true,
+	"ExecStartPre":     true,
+	"ExecStartPost":    true,
+	"ExecReload":       true,
+	"ExecStop":         true,
+	"ExecStopPost":     true,
+	"ExecCondition":    true,
+	"EnvironmentFile":  true,
+	"WorkingDirectory": true,
+}
+
+// ReferencedPaths extracts absolute paths from Exec*=, EnvironmentFile= and
+// WorkingDirectory= lines of unit file content, in order of appearance and
+// without duplicates.
+func ReferencedPaths(content string) []PathRef {
+	var refs []PathRef
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || !pathDirectives[strings.TrimSpace(key)] {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		// Exec prefixes (-, @, :, +, !) and the "ignore missing" dash of
+		// EnvironmentFile=/WorkingDirectory= are not part of the path.
+		path := strings.TrimLeft(fields[0], "-@:+!")
+		if !strings.HasPrefix(path, "/") || seen[path] {
+			continue
+		}
+		seen[path] = true
+		refs = append(refs, PathRef{Directive: strings.TrimSpace(key), Path: path})
+	}
+	return refs
+}
@@ -0,0 +1,123 @@
+package systemd
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Usage is a unit's resource consumption from cgroup accounting. Each Has
+// field is false when that kind of accounting is off for the unit.
+type Usage struct {
+	Unit            string
+	CPU             time.Duration // total CPU time since the unit started
+	Memory          uint64        // current memory use in bytes
+	IORead, IOWrite uint64        // block I/O bytes since the unit started
+	IPIn, IPOut     uint64        // network bytes received and sent
+	Restarts        int           // automatic restarts of a service
+	HasCPU, HasMem  bool
+	HasIO, HasIP    bool
+}
+
+// usageProps are the accounting counters UnitUsage reads.
+var usageProps = []string{
+	"CPUUsageNSec", "MemoryCurrent", "IOReadBytes", "IOWriteBytes", "IPIngressBytes", "IPEgressBytes", "NRestarts",
+}
+
+// cgroupSuffixes are the unit types that own a cgroup and so are accounted.
+var cgroupSuffixes = []string{".service", ".scope", ".slice", ".socket", ".mount", ".swap"}
+
+// UnitUsage reports the accounted resources of every active unit that owns
+// a cgroup. Units without any accounting data are left out.
+func UnitUsage() ([]Usage, error) {
+	units, err := ListUnitsFiltered(Filter{States: []string{"active", "reloading"}})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, u := range units {
+		if (u.ActiveState == "active" || u.ActiveState == "reloading") && OwnsCgroup(u.Name) {
+			names = append(names, u.Name)
+		}
+	}
+	usage, err := UnitsUsage(names)
+	if err != nil {
+		return nil, err
+	}
+	var accounted []Usage
+	for _, u := range usage {
+		if u.HasCPU || u.HasMem || u.HasIO || u.HasIP {
+			accounted = append(accounted, u)
+		}
+	}
+	return accounted, nil
+}
+
+// UnitsUsage reports the accounted resources of the named units, in order.
+func UnitsUsage(names []string) ([]Usage, error) {
+	props, err := ShowUnitsProperties(names, usageProps...)
+	if err != nil {
+		return nil, err
+	}
+	usage := make([]Usage, len(names))
+	for i, name := range names {
+		usage[i] = parseUsage(name, props[name])
+	}
+	return usage, nil
+}
+
+// UnitUsageOf reports the accounted resources of one unit.
+func UnitUsageOf(name string) (Usage, error) {
+	props, err := ShowProperties(name, usageProps...)
+	if err != nil {
+		return Usage{Unit: name}, err
+	}
+	return parseUsage(name, props), nil
+}
+
+// OwnsCgroup reports whether a unit has a cgroup of its own and so can be
+// accounted.
+func OwnsCgroup(name string) bool {
+	for _, suffix := range cgroupSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnableAccounting switches on I/O and IP accounting for a unit at runtime
+// and in its persistent configuration, via systemctl set-property.
+func EnableAccounting(name string) error {
+	return systemctl("set-property", "--", name, "IOAccounting=yes", "IPAccounting=yes").Run()
+}
+
+func parseUsage(name string, p map[string]string) Usage {
+	u := Usage{Unit: name}
+	var cpu uint64
+	cpu, u.HasCPU = ParseCounter(p["CPUUsageNSec"])
+	u.CPU = time.Duration(cpu)
+	u.Memory, u.HasMem = ParseCounter(p["MemoryCurrent"])
+	var read, write bool
+	u.IORead, read = ParseCounter(p["IOReadBytes"])
+	u.IOWrite, write = ParseCounter(p["IOWriteBytes"])
+	u.HasIO = read || write
+	var in, out bool
+	u.IPIn, in = ParseCounter(p["IPIngressBytes"])
+	u.IPOut, out = ParseCounter(p["IPEgressBytes"])
+	u.HasIP = in || out
+	u.Restarts, _ = strconv.Atoi(p["NRestarts"])
+	return u
+}
+
+// ParseCounter parses an accounting counter such as MemoryCurrent.
+// systemctl shows "[not set]" or the maximum uint64 when the counter is
+// unavailable.
+func ParseCounter(value string) (uint64, bool) {
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || n == math.MaxUint64 {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,76 @@
+package systemd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Feature is a systemd capability that only exists from some version on.
+type Feature int
+
+const (
+	// FeatureJSONOutput is `systemctl list-* --output=json`.
+	FeatureJSONOutput Feature = iota
+	// FeatureSecurityAnalysis is `systemd-analyze security`.
+	FeatureSecurityAnalysis
+	// FeatureMemoryMax is the cgroup v2 MemoryMax= property for set-property.
+	FeatureMemoryMax
+	// FeatureIOAccounting is the IOAccounting= property for set-property.
+	FeatureIOAccounting
+	// FeatureIPAccounting is the IPAccounting= property for set-property.
+	FeatureIPAccounting
+	// FeatureJobOrdering is `systemctl list-jobs --before/--after`.
+	FeatureJobOrdering
+)
+
+// Minimum systemd version providing each feature.
+var featureVersions = map[Feature]int{
+	FeatureJSONOutput:       246,
+	FeatureSecurityAnalysis: 240,
+	FeatureMemoryMax:        231,
+	FeatureIOAccounting:     230,
+	FeatureIPAccounting:     235,
+	FeatureJobOrdering:      233,
+}
+
+// version is the detected systemd version, 0 when unknown.
+var version int
+
+// DetectVersion queries `systemctl --version` and remembers the result for
+// Supports. Until it succeeds, every gated feature is treated as missing so
+// vigilix falls back to invocations that work on old releases (CentOS 7
+// ships systemd 219).
+func DetectVersion() (int, error) {
+	output, err := systemctl("--version").Output()
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseVersion(string(output))
+	if err != nil {
+		return 0, err
+	}
+	version = v
+	return v, nil
+}
+
+// Version returns the version found by DetectVersion, or 0.
+func Version() int {
+	return version
+}
+
+// Supports reports whether the detected systemd provides f.
+func Supports(f Feature) bool {
+	return version >= featureVersions[f] && version > 0
+}
+
+// parseVersion reads the first line of `systemctl --version`, e.g.
+// "systemd 252 (252.39-1~deb12u1)".
+func parseVersion(output string) (int, error) {
+	line, _, _ := strings.Cut(output, "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "systemd" {
+		return 0, fmt.Errorf("unexpected systemctl --version output: %q", line)
+	}
+	return strconv.Atoi(fields[1])
+}
@@ -0,0 +1,112 @@
+// Package textdiff computes line-based differences between two texts.
+package textdiff
+
+import "strings"
+
+// Op is the kind of a diff line.
+type Op int
+
+const (
+	Equal  Op = iota
+	Delete    // only in the old text
+	Insert    // only in the new text
+)
+
+// Line is one line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines diffs a and b line by line using the longest common subsequence.
+// Unit files are short, so the quadratic table is not a concern.
+func Lines(a, b string) []Line {
+	x := splitLines(a)
+	y := splitLines(b)
+
+	// lcs[i][j] is the LCS length of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []Line
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			out = append(out, Line{Equal, x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Line{Delete, x[i]})
+			i++
+		default:
+			out = append(out, Line{Insert, y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		out = append(out, Line{Delete, x[i]})
+	}
+	for ; j < len(y); j++ {
+		out = append(out, Line{Insert, y[j]})
+	}
+	return out
+}
+
+// Row is a side-by-side pair. A nil side means the line has no counterpart.
+type Row struct {
+	Left, Right *string
+}
+
+// SideBySide pairs up a diff for two-column display: runs of deletions and
+// insertions are matched line for line as changes.
+func SideBySide(lines []Line) []Row {
+	var rows []Row
+	for k := 0; k < len(lines); {
+		if lines[k].Op == Equal {
+			text := lines[k].Text
+			rows = append(rows, Row{Left: &text, Right: &text})
+			k++
+			continue
+		}
+
+		var dels, ins []string
+		for ; k < len(lines) && lines[k].Op != Equal; k++ {
+			if lines[k].Op == Delete {
+				dels = append(dels, lines[k].Text)
+			} else {
+				ins = append(ins, lines[k].Text)
+			}
+		}
+		for n := 0; n < len(dels) || n < len(ins); n++ {
+			var row Row
+			if n < len(dels) {
+				row.Left = &dels[n]
+			}
+			if n < len(ins) {
+				row.Right = &ins[n]
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// accessLine matches a request in common or combined log format anywhere in
+// a log line, so journal and file prefixes are skipped. A trailing number is
+// taken as the request time: seconds with a fraction as nginx logs
+// $request_time, whole microseconds as Apache logs %D.
+var accessLine = regexp.MustCompile(`\S+ \S+ \S+ \[([^\]]+)\] "(\S+) (\S+)[^"]*" (\d{3}) (\d+|-)(?: "[^"]*" "[^"]*")?(?: (?:rt=)?(\d+(?:\.\d+)?))?\s*$`)
+
+// webServerFragments are the unit name fragments that get the access log
+// view, the same ones the list draws web server icons for.
+var webServerFragments = []string{"nginx", "apache", "httpd"}
+
+// accessEntry is one parsed request.
+type accessEntry struct {
+	time    string
+	method  string
+	path    string
+	status  int
+	bytes   string
+	latency time.Duration // negative when not logged
+}
+
+func isWebServer(unit string) bool {
+	unit = strings.ToLower(unit)
+	for _, f := range webServerFragments {
+		if strings.Contains(unit, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseAccess(line string) (accessEntry, bool) {
+	sm := accessLine.FindStringSubmatch(line)
+	if sm == nil {
+		return accessEntry{}, false
+	}
+	e := accessEntry{time: sm[1], method: sm[2], path: sm[3], bytes: sm[5], latency: -1}
+	if t, err := time.Parse("02/Jan/2006:15:04:05 -0700", sm[1]); err == nil {
+		e.time = t.Format("15:04:05")
+	}
+	e.status, _ = strconv.Atoi(sm[4])
+	if sm[6] != "" {
+		if strings.Contains(sm[6], ".") {
+			secs, _ := strconv.ParseFloat(sm[6], 64)
+			e.latency = time.Duration(secs * float64(time.Second))
+		} else {
+			us, _ := strconv.ParseInt(sm[6], 10, 64)
+			e.latency = time.Duration(us) * time.Microsecond
+		}
+	}
+	return e, true
+}
+
+// statusColor colors a status code by its class.
+func statusColor(status int) lipgloss.Color {
+	switch status / 100 {
+	case 2:
+		return green
+	case 3:
+		return cyan
+	case 4:
+		return orange
+	case 5:
+		return red
+	}
+	return comment
+}
+
+// renderAccessLog shows the requests among the log lines as columns,
+// limited to one status class (2-5) unless class is 0.
+func renderAccessLog(lines []string, class, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var entries []accessEntry
+	other := 0
+	for _, line := range lines {
+		e, ok := parseAccess(line)
+		switch {
+		case !ok:
+			other++
+		case class == 0 || e.status/100 == class:
+			entries = append(entries, e)
+		}
+	}
+
+	var b strings.Builder
+	title := "Access log"
+	if class != 0 {
+		title += fmt.Sprintf(" · %dxx only", class)
+	}
+	b.WriteString(heading.Render(title) + "\n")
+	b.WriteString(dim.Render(fmt.Sprintf("%d requests shown, %d other lines hidden. 2-5: one status class · 0: all · a: raw log", len(entries), other)) + "\n\n")
+	if len(entries) == 0 {
+		b.WriteString(dim.Render("No requests in common or combined log format yet.") + "\n")
+		return b.String()
+	}
+
+	pathWidth := max(width-8-2-3-2-7-2-8-2-9-2, 10)
+	fmt.Fprintln(&b, dim.Render(fmt.Sprintf("%-8s  %3s  %-7s  %-*s  %8s  %9s", "TIME", "ST", "METHOD", pathWidth, "PATH", "BYTES", "LATENCY")))
+	for _, e := range entries {
+		status := lipgloss.NewStyle().Foreground(statusColor(e.status)).Render(strconv.Itoa(e.status))
+		path := fit(e.path, pathWidth)
+		latency := "–"
+		if e.latency >= 0 {
+			latency = e.latency.Round(time.Millisecond).String()
+			if e.latency < time.Millisecond {
+				latency = e.latency.Round(time.Microsecond).String()
+			}
+		}
+		fmt.Fprintf(&b, "%-8s  %s  %-7s  %s  %8s  %9s\n", e.time, status, e.method, path, e.bytes, latency)
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+	"time"
+	"vigilix/internal/i18n"
+	"vigilix/internal/systemd"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// actionBarHeight is the line kept above the status bar for the actions
+// that apply to the selected unit.
+const actionBarHeight = 1
+
+// unitActions are the keys that make sense for the unit in its current
+// state, most useful first. Drainable units have a drain configured.
+func unitActions(i item, drainable bool) []key.Binding {
+	var actions []key.Binding
+	switch i.unit.ActiveState {
+	case "failed":
+		actions = append(actions, keys.Restart, keys.ResetFailed, keys.Enter)
+	case "active", "reloading":
+		actions = append(actions, keys.Stop, keys.Restart, keys.Reload)
+		if drainable {
+			actions = append(actions, keys.Drain)
+		}
+	case "activating", "deactivating":
+		actions = append(actions, keys.Stalled, keys.Stop)
+	default:
+		actions = append(actions, keys.Start)
+	}
+	switch i.times.enabled {
+	case "disabled":
+		enable := keys.Enable
+		enable.SetHelp(enable.Help().Key, "enable")
+		actions = append(actions, enable)
+	case "enabled":
+		disable := keys.Enable
+		disable.SetHelp(disable.Help().Key, "disable")
+		actions = append(actions, disable)
+	}
+	if systemd.IsTrigger(i.unit.Name) {
+		actions = append(actions, keys.Trigger)
+	}
+	if i.unit.ActiveState != "failed" {
+		actions = append(actions, keys.Enter)
+	}
+	return actions
+}
+
+// actionBarView shows the actions for the selected unit on one line, so
+// they can be found without opening the help. A stop or disable that can
+// still be undone is offered first.
+func (m model) actionBarView() string {
+	i, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return ""
+	}
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var parts []string
+	if systemd.Offline() {
+		parts = append(parts, dim.Render(i18n.T(offlineActions)))
+	} else {
+		_, drainable := m.cfg.Drains[i.unit.Name]
+		for _, b := range translated(unitActions(i, drainable)) {
+			desc := b.Help().Desc
+			if b.Help().Key == keys.Enter.Help().Key {
+				desc = i18n.T("logs")
+			}
+			parts = append(parts, keyStyle.Render(b.Help().Key)+" "+dim.Render(desc))
+		}
+	}
+	bar := " " + stateMark(i.unit.ActiveState) + " " + i.unit.Name + "  " + strings.Join(parts, dim.Render(" · "))
+	if e, ok := m.undo.latest(time.Now()); ok {
+		bar = lipgloss.NewStyle().Bold(true).Foreground(yellow).Render(" ↶ "+e.prompt(time.Now())) + dim.Render("  │") + bar
+	}
+	return truncate(bar, m.width)
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stateMark returns a shape (or, in ASCII mode, a letter) that tells the
+// active states apart without relying on color.
+func stateMark(state string) string {
+	for _, s := range stateMarks {
+		if s.state == state {
+			if glyphs.ascii {
+				return s.letter
+			}
+			return s.shape
+		}
+	}
+	if glyphs.ascii {
+		return "?"
+	}
+	return "◌"
+}
+
+var stateMarks = []struct{ state, shape, letter string }{
+	{"active", "●", "A"},
+	{"failed", "✖", "F"},
+	{"inactive", "○", "I"},
+	{"activating", "◐", "S"},
+	{"deactivating", "◑", "D"},
+	{"reloading", "↻", "R"},
+	{"maintenance", "◆", "M"},
+}
+
+// stateBadge renders a unit's active state as a colored badge that also
+// carries its mark, so it reads the same in any palette.
+func stateBadge(state string) string {
+	bg, fg := comment, foreground
+	switch state {
+	case "active":
+		bg, fg = green, lipgloss.Color("#000000")
+	case "failed":
+		bg = red
+	case "inactive":
+		bg = lipgloss.Color("#44475a") // Dark gray
+	}
+	return lipgloss.NewStyle().
+		Background(bg).
+		Foreground(fg).
+		Padding(0, 1).
+		Bold(true).
+		Render(stateMark(state) + " " + strings.ToUpper(state))
+}
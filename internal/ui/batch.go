@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Long previews are cut off so the dialog still fits on screen.
+const batchPreviewLimit = 15
+
+type batchResult struct {
+	unit string
+	err  error
+}
+
+type batchDoneMsg struct {
+	action  string
+	results []batchResult
+}
+
+// runBatch applies fn to every unit in turn and reports each outcome.
+func runBatch(action string, units []string, fn func(string) error) tea.Cmd {
+	return func() tea.Msg {
+		results := make([]batchResult, len(units))
+		for i, u := range units {
+			results[i] = batchResult{unit: u, err: fn(u)}
+		}
+		return batchDoneMsg{action: action, results: results}
+	}
+}
+
+func (m model) failedUnits() []string {
+	var names []string
+	for _, u := range m.allUnits {
+		if u.ActiveState == "failed" {
+			names = append(names, u.Name)
+		}
+	}
+	return names
+}
+
+// restartFailedDialog previews the failed units and restarts them on confirm.
+func (m model) restartFailedDialog() *dialog {
+	failed := m.failedUnits()
+	if len(failed) == 0 {
+		return &dialog{title: "Restart failed units", lines: []string{"No units are in the failed state."}}
+	}
+
+	lines := []string{fmt.Sprintf("The following %d unit(s) will be restarted:", len(failed)), ""}
+	lines = append(lines, previewLines(failed)...)
+	return &dialog{
+		title:   "Restart failed units",
+		lines:   lines,
+		confirm: runBatch("Restart", failed, systemd.RestartUnit),
+	}
+}
+
+func previewLines(units []string) []string {
+	var lines []string
+	for i, u := range units {
+		if i == batchPreviewLimit {
+			lines = append(lines, fmt.Sprintf("  … and %d more", len(units)-batchPreviewLimit))
+			break
+		}
+		lines = append(lines, "  • "+u)
+	}
+	return lines
+}
+
+// batchReportDialog lists the per-unit outcome of a batch action.
+func batchReportDialog(msg batchDoneMsg) *dialog {
+	ok := lipgloss.NewStyle().Foreground(green).Render("✓")
+	fail := lipgloss.NewStyle().Foreground(red).Render("✗")
+
+	failures := 0
+	var lines []string
+	for _, r := range msg.results {
+		if r.err != nil {
+			failures++
+			lines = append(lines, fmt.Sprintf("%s %s: %v", fail, r.unit, r.err))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s %s", ok, r.unit))
+		}
+	}
+	title := fmt.Sprintf("%s: %d succeeded, %d failed", msg.action, len(msg.results)-failures, failures)
+	return &dialog{title: title, lines: lines}
+}
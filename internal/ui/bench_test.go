@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// benchSizes are the list lengths the rendering benchmarks run with.
+var benchSizes = []int{1000, 10000}
+
+// benchStates is the mix of unit states the synthetic lists cycle through.
+var benchStates = [][2]string{
+	{"active", "running"}, {"active", "running"}, {"active", "exited"},
+	{"inactive", "dead"}, {"failed", "failed"}, {"activating", "start"},
+}
+
+// benchModel is the UI at width x height, in the list view, showing n
+// synthetic units. The saved session is ignored.
+func benchModel(n, width, height int) model {
+	m := NewModel(config.Default())
+	m.restore = nil
+	m.devMode = false
+	m.viewMode = ModeList
+	m.busy = 0
+	units := make([]systemd.Unit, n)
+	for i := range units {
+		state := benchStates[i%len(benchStates)]
+		units[i] = systemd.Unit{
+			Name:        fmt.Sprintf("bench-%05d.service", i),
+			LoadState:   "loaded",
+			ActiveState: state[0],
+			SubState:    state[1],
+			Description: fmt.Sprintf("Synthetic service number %d for rendering benchmarks", i),
+		}
+	}
+	m.allUnits = units
+	m.updateListItems()
+	next, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return next.(model)
+}
+
+// overBudget fails a benchmark whose time per operation exceeds budget, so
+// layout regressions show up in CI.
+func overBudget(b *testing.B, budget time.Duration) {
+	if perOp := b.Elapsed() / time.Duration(b.N); perOp > budget {
+		b.Errorf("%s per operation, over the budget of %s", perOp, budget)
+	}
+}
+
+// BenchmarkDelegate renders single list rows. Every visible row is
+// rendered on each frame, so a row gets a 25th of the frame budget.
+func BenchmarkDelegate(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			m := benchModel(n, 160, 50)
+			items := m.list.Items()
+			d := itemDelegate{columns: defaultColumns}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				d.Render(io.Discard, m.list, i%len(items), items[i%len(items)])
+			}
+			overBudget(b, frameBudget/25)
+		})
+	}
+}
+
+// BenchmarkView renders whole frames of the list view on a 160x50
+// terminal.
+func BenchmarkView(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			m := benchModel(n, 160, 50)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = m.View()
+			}
+			overBudget(b, frameBudget)
+		})
+	}
+}
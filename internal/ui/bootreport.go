@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"vigilix/internal/bootreport"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openBootReportMsg asks for the report of the current boot.
+type openBootReportMsg struct{}
+
+type bootReportMsg struct {
+	report bootreport.Report
+}
+
+type bootReportSavedMsg struct {
+	path string
+	err  error
+}
+
+func fetchBootReport() tea.Msg {
+	return bootReportMsg{report: bootreport.Generate()}
+}
+
+// renderBootReport shows the boot report: boot time, failed units, the
+// slowest units and the kernel's warnings.
+func renderBootReport(r bootreport.Report, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	failed := lipgloss.NewStyle().Foreground(red)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Boot report") + "  " + dim.Render(r.Host) + "\n")
+	if !r.BootedAt.IsZero() {
+		b.WriteString(dim.Render("Booted "+r.BootedAt.Format("2006-01-02 15:04:05")) + "\n")
+	}
+	if len(r.Phases) > 0 {
+		b.WriteString(truncate(r.Summary(), width) + "\n")
+	}
+
+	b.WriteString("\n" + heading.Render(fmt.Sprintf("Failed units (%d)", len(r.Failed))) + "\n")
+	for _, f := range r.Failed {
+		b.WriteString(truncate(failed.Render("✗ "+f.Unit)+" "+f.Cause, width) + "\n")
+	}
+	if len(r.Failed) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(green).Render("✓ none") + "\n")
+	}
+
+	b.WriteString("\n" + heading.Render("Slowest units") + "\n")
+	for _, s := range r.Slowest {
+		b.WriteString(fmt.Sprintf("%10s  %s\n", bootreport.Seconds(s.Seconds), truncate(s.Unit, width-12)))
+	}
+
+	b.WriteString("\n" + heading.Render(fmt.Sprintf("Kernel warnings (%d)", len(r.KernelWarnings))) + "\n")
+	for _, msg := range r.KernelWarnings {
+		style := lipgloss.NewStyle().Foreground(orange)
+		if msg.Priority < "4" {
+			style = failed
+		}
+		b.WriteString(dim.Render(msg.Time.Format("15:04:05")) + " " + style.Render(truncate(msg.Text, width-9)) + "\n")
+	}
+	if len(r.KernelWarnings) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(green).Render("✓ none") + "\n")
+	}
+
+	if len(r.Errors) > 0 {
+		b.WriteString("\n" + heading.Render("Not available") + "\n")
+		for _, e := range r.Errors {
+			b.WriteString(dim.Render(truncate(e, width)) + "\n")
+		}
+	}
+	b.WriteString("\n" + dim.Render("e: save as Markdown or JSON"))
+	return b.String()
+}
+
+// bootReportPrompt asks where to save the report; the format follows the
+// file extension.
+func bootReportPrompt(r bootreport.Report) *prompt {
+	return newPrompt(
+		"Save boot report",
+		"<file.md|.txt|.json>",
+		"boot-report-"+r.Generated.Format("2006-01-02")+".md",
+		func(value string) tea.Cmd {
+			return func() tea.Msg { return saveBootReport(r, value) }
+		},
+	)
+}
+
+func saveBootReport(r bootreport.Report, path string) tea.Msg {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return bootReportSavedMsg{err: fmt.Errorf("expected a file name")}
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return bootReportSavedMsg{err: err}
+		}
+		path = filepath.Join(home, rest)
+	}
+	data := []byte(r.Markdown())
+	if filepath.Ext(path) == ".json" {
+		var err error
+		if data, err = json.MarshalIndent(r, "", "  "); err != nil {
+			return bootReportSavedMsg{err: err}
+		}
+	}
+	return bootReportSavedMsg{path: path, err: os.WriteFile(path, data, 0o644)}
+}
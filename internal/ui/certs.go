@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/certs"
+	"vigilix/internal/notify"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Certificates change rarely; checking hourly is plenty.
+const certInterval = time.Hour
+
+type certsMsg map[string][]certs.Cert
+
+type certTickMsg struct{}
+
+// fetchCerts checks the configured certificates, keyed by full unit name.
+func fetchCerts(sources map[string][]string) tea.Cmd {
+	byUnit := make(map[string][]string, len(sources))
+	for name, list := range sources {
+		unit := systemd.UnitName(name)
+		byUnit[unit] = append(byUnit[unit], list...)
+	}
+	return func() tea.Msg {
+		return certsMsg(certs.CheckAll(byUnit))
+	}
+}
+
+func certTick() tea.Cmd {
+	return tea.Tick(certInterval, func(time.Time) tea.Msg { return certTickMsg{} })
+}
+
+// expiring returns the certificate of a unit that expires first, if it does
+// within the warning window.
+func (m model) expiring(unit string, now time.Time) (certs.Cert, bool) {
+	var soonest certs.Cert
+	found := false
+	for _, c := range m.certs[unit] {
+		if c.Err != nil || c.Left(now) > m.cfg.CertWarning() {
+			continue
+		}
+		if !found || c.NotAfter.Before(soonest.NotAfter) {
+			soonest, found = c, true
+		}
+	}
+	return soonest, found
+}
+
+// alertCerts notifies once per certificate when it enters the warning
+// window or cannot be read.
+func (m *model) alertCerts() {
+	now := time.Now()
+	seen := make(map[string]bool)
+	for unit, list := range m.certs {
+		for _, c := range list {
+			expiring := c.Err == nil && c.Left(now) <= m.cfg.CertWarning()
+			if c.Err == nil && !expiring {
+				continue
+			}
+			key := unit + "\x00" + c.Source
+			seen[key] = true
+			if m.certAlerts[key] || !m.cfg.Notifications || notify.Silenced(m.silences, unit, now) {
+				continue
+			}
+			if c.Err != nil {
+				m.toasts.push(toastError, fmt.Sprintf("%s: cannot check the certificate of %s: %v", unit, c.Source, c.Err))
+			} else {
+				m.toasts.push(toastError, fmt.Sprintf("%s: the certificate of %s %s", unit, c.Source, expiryText(c, now)))
+			}
+		}
+	}
+	if len(seen) > 0 {
+		m.refreshMessages()
+	}
+	m.certAlerts = seen
+}
+
+// expiryText says when a certificate expires or expired.
+func expiryText(c certs.Cert, now time.Time) string {
+	left := c.Left(now)
+	if left <= 0 {
+		return "expired " + humanDuration(-left) + " ago"
+	}
+	return "expires in " + humanDuration(left)
+}
+
+// certBadge marks a unit whose certificate expires soon, red once expired.
+func certBadge(c certs.Cert, now time.Time) string {
+	left := c.Left(now)
+	if left <= 0 {
+		return lipgloss.NewStyle().Foreground(red).Bold(true).Render("⚠ cert expired")
+	}
+	return lipgloss.NewStyle().Foreground(orange).Bold(true).Render("⚠ cert " + humanDuration(left))
+}
+
+// renderCerts lists a unit's certificates with their expiry.
+func renderCerts(list []certs.Cert, warning time.Duration, label, value lipgloss.Style) string {
+	now := time.Now()
+	var b strings.Builder
+	for _, c := range list {
+		var text string
+		switch left := c.Left(now); {
+		case c.Err != nil:
+			text = lipgloss.NewStyle().Foreground(red).Render(c.Err.Error())
+		case left <= 0:
+			text = lipgloss.NewStyle().Foreground(red).Render(c.Subject + " · " + expiryText(c, now))
+		case left <= warning:
+			text = lipgloss.NewStyle().Foreground(orange).Render(c.Subject + " · " + expiryText(c, now))
+		default:
+			text = c.Subject + " · " + expiryText(c, now)
+		}
+		if c.Err == nil {
+			text += lipgloss.NewStyle().Foreground(comment).Render(" (" + c.NotAfter.Local().Format("2006-01-02") + ")")
+		}
+		kind := "Endpoint"
+		if certs.IsFile(c.Source) {
+			kind = "File"
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render(kind), " ", value.Render(c.Source+" · "+text)) + "\n")
+	}
+	return b.String()
+}
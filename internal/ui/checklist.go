@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checklist is a modal list of options that can be toggled. Enter submits
+// the checked ones, esc cancels. Like prompt submissions, the submitted
+// command counts as background work.
+type checklist struct {
+	title  string
+	items  []checkItem
+	cursor int
+	submit func(values []string) tea.Cmd
+}
+
+type checkItem struct {
+	label   string // shown
+	value   string // submitted
+	note    string // dimmed, after the label
+	checked bool
+}
+
+// handleKey reports the command to run (if any) and whether the checklist
+// should close.
+func (c *checklist) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "up", "k":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "j":
+		if c.cursor < len(c.items)-1 {
+			c.cursor++
+		}
+	case " ", "space", "x":
+		c.items[c.cursor].checked = !c.items[c.cursor].checked
+	case "enter":
+		var values []string
+		for _, it := range c.items {
+			if it.checked {
+				values = append(values, it.value)
+			}
+		}
+		if len(values) == 0 {
+			return nil, true
+		}
+		return c.submit(values), true
+	case "esc", "q":
+		return nil, true
+	}
+	return nil, false
+}
+
+func (c *checklist) View(width int) string {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(cyan).Render(c.title), ""}
+	for i, it := range c.items {
+		box := "[ ]"
+		if it.checked {
+			box = lipgloss.NewStyle().Foreground(green).Render("[x]")
+		}
+		line := box + " " + it.label
+		if it.note != "" {
+			line += "  " + dim.Render(it.note)
+		}
+		if i == c.cursor {
+			line = lipgloss.NewStyle().Bold(true).Render("▸ ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", dim.Render("↑/↓: move · space: toggle · enter: apply · esc: cancel"))
+
+	return lipgloss.NewStyle().
+		Border(panelBorder).
+		BorderForeground(purple).
+		Background(background).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
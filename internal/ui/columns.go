@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listColumns are the columns the unit list can show. "state" is the badge
+// on the right of the first line; the others make up the second line.
+var listColumns = []string{"state", "sub", "enabled", "memory", "uptime", "description"}
+
+var defaultColumns = []config.Column{{Name: "state"}, {Name: "uptime"}, {Name: "description"}}
+
+// columnWidths line up the short columns when the config gives no width.
+var columnWidths = map[string]int{"sub": 9, "enabled": 8, "memory": 7}
+
+// validColumns returns the configured columns, or the default ones when
+// none are set, and an error naming the first unknown column.
+func validColumns(cols []config.Column) ([]config.Column, error) {
+	if len(cols) == 0 {
+		return defaultColumns, nil
+	}
+	var valid []config.Column
+	var err error
+	for _, c := range cols {
+		if !slices.Contains(listColumns, c.Name) {
+			if err == nil {
+				err = fmt.Errorf("unknown list column %q; use one of %v", c.Name, listColumns)
+			}
+			continue
+		}
+		valid = append(valid, c)
+	}
+	return valid, err
+}
+
+func hasColumn(cols []config.Column, name string) bool {
+	return slices.ContainsFunc(cols, func(c config.Column) bool { return c.Name == name })
+}
+
+// column is the value of a second-line column for the item.
+func (i item) column(name string, now time.Time) string {
+	switch name {
+	case "sub":
+		return i.unit.SubState
+	case "enabled":
+		return i.times.enabled
+	case "memory":
+		if i.times.hasMemory {
+			return humanBytes(i.times.memory)
+		}
+	case "uptime":
+		return i.relativeState(now)
+	case "description":
+		return i.description()
+	}
+	return ""
+}
+
+// columnLine joins the item's second-line columns. Columns with a width are
+// padded or cut to it and followed by a space; the others are separated by
+// " · ", and left out when empty.
+func (i item) columnLine(cols []config.Column, now time.Time) string {
+	var b strings.Builder
+	sep := ""
+	for _, c := range cols {
+		if c.Name == "state" {
+			continue
+		}
+		value := i.column(c.Name, now)
+		width := c.Width
+		if width == 0 {
+			width = columnWidths[c.Name]
+		}
+		switch {
+		case width > 0:
+			b.WriteString(sep + fit(value, width))
+			sep = " "
+		case value != "":
+			b.WriteString(sep + value)
+			sep = " · "
+		}
+	}
+	return b.String()
+}
+
+// columnsChecklist chooses the list columns. Their order and widths come
+// from the config; newly checked columns are added at the end.
+func columnsChecklist(cols []config.Column) *checklist {
+	var items []checkItem
+	for _, name := range listColumns {
+		note := ""
+		for _, c := range cols {
+			if c.Name == name && c.Width > 0 {
+				note = strconv.Itoa(c.Width) + " wide"
+			}
+		}
+		items = append(items, checkItem{label: name, value: name, note: note, checked: hasColumn(cols, name)})
+	}
+	return &checklist{
+		title: "List columns",
+		items: items,
+		submit: func(names []string) tea.Cmd {
+			var next []config.Column
+			for _, c := range cols {
+				if slices.Contains(names, c.Name) {
+					next = append(next, c)
+				}
+			}
+			for _, name := range names {
+				if !hasColumn(next, name) {
+					next = append(next, config.Column{Name: name})
+				}
+			}
+			return saveColumns(next)
+		},
+	}
+}
+
+type columnsMsg struct {
+	columns []config.Column
+	err     error
+}
+
+func saveColumns(cols []config.Column) tea.Cmd {
+	return func() tea.Msg {
+		return columnsMsg{columns: cols, err: saveSetting(func(cfg *config.Config) { cfg.Columns = cols })}
+	}
+}
+
+// densitySavedMsg reports saving the list density.
+type densitySavedMsg struct{ err error }
+
+func saveDensity(compact bool) tea.Cmd {
+	return func() tea.Msg {
+		return densitySavedMsg{err: saveSetting(func(cfg *config.Config) { cfg.Compact = compact })}
+	}
+}
+
+// saveSetting changes one setting in the config file. It reads the file
+// again so that command-line overrides are not saved with it.
+func saveSetting(change func(*config.Config)) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		defaults := config.Default()
+		cfg = &defaults
+	}
+	change(cfg)
+	return config.Save(*cfg)
+}
+
+// delegate draws the unit list with the current columns and density.
+func (m model) delegate() itemDelegate {
+	return itemDelegate{columns: m.columns, compact: m.compact}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"strings"
+	"vigilix/internal/systemd"
+	"vigilix/internal/textdiff"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Runtime properties compared side by side.
+var compareProps = []string{
+	"ActiveState",
+	"SubState",
+	"Result",
+	"UnitFileState",
+	"FragmentPath",
+	"MainPID",
+	"NRestarts",
+	"ExecMainStatus",
+	"MemoryCurrent",
+	"TasksCurrent",
+	"User",
+	"Environment",
+	"ExecStart",
+}
+
+type compareMsg struct {
+	a, b         string
+	propsA       map[string]string
+	propsB       map[string]string
+	fileA, fileB string
+	err          error
+}
+
+func fetchCompare(a, b string) tea.Cmd {
+	return func() tea.Msg {
+		msg := compareMsg{a: a, b: b}
+		props, err := systemd.ShowUnitsProperties([]string{a, b}, compareProps...)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.propsA, msg.propsB = props[a], props[b]
+		if msg.fileA, err = systemd.GetUnitFileContent(a); err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.fileB, msg.err = systemd.GetUnitFileContent(b)
+		return msg
+	}
+}
+
+func renderCompare(msg compareMsg, width int) string {
+	colWidth := (width - 3) / 2
+	if colWidth < 10 {
+		colWidth = 10
+	}
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	changed := lipgloss.NewStyle().Foreground(orange)
+	removed := lipgloss.NewStyle().Foreground(red)
+	added := lipgloss.NewStyle().Foreground(green)
+
+	cell := func(s string, style lipgloss.Style) string {
+		return style.Render(fit(s, colWidth))
+	}
+	row := func(l, r string) string {
+		return l + dim.Render(" │ ") + r + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(row(cell(msg.a, heading), cell(msg.b, heading)))
+
+	b.WriteString("\n" + heading.Render("Runtime properties") + "\n")
+	for _, p := range compareProps {
+		va, vb := msg.propsA[p], msg.propsB[p]
+		style := lipgloss.NewStyle()
+		if va != vb {
+			style = changed
+		}
+		b.WriteString(dim.Render(p) + "\n")
+		b.WriteString(row(cell(orDash(va), style), cell(orDash(vb), style)))
+	}
+
+	b.WriteString("\n" + heading.Render("Unit files") + "\n")
+	for _, r := range textdiff.SideBySide(textdiff.Lines(msg.fileA, msg.fileB)) {
+		switch {
+		case r.Left != nil && r.Right != nil && *r.Left == *r.Right:
+			b.WriteString(row(cell(*r.Left, dim), cell(*r.Right, dim)))
+		case r.Left != nil && r.Right != nil:
+			b.WriteString(row(cell(*r.Left, changed), cell(*r.Right, changed)))
+		case r.Left != nil:
+			b.WriteString(row(cell(*r.Left, removed), cell("", dim)))
+		default:
+			b.WriteString(row(cell("", dim), cell(*r.Right, added)))
+		}
+	}
+	return b.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/compose"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// composePrefix marks a log stream that follows a Compose project rather
+// than a unit.
+const composePrefix = "compose:"
+
+type containersMsg struct {
+	projects   []compose.Project
+	standalone []compose.Container
+	err        error
+}
+
+func fetchContainers() tea.Msg {
+	projects, standalone, err := compose.List()
+	return containersMsg{projects: projects, standalone: standalone, err: err}
+}
+
+// composeActionMsg runs a project-level command; down asks first.
+type composeActionMsg struct {
+	verb    string // "up", "down", "restart" or "logs"
+	project compose.Project
+}
+
+// composeDoneMsg reports the outcome of a project command.
+type composeDoneMsg struct {
+	done    string
+	project string
+	err     error
+}
+
+// composePicker offers the project commands for every project.
+func composePicker(projects []compose.Project) *finder {
+	picks := make(map[string]composeActionMsg)
+	var labels []string
+	for _, p := range projects {
+		for _, verb := range []string{"logs", "restart", "up", "down"} {
+			label := p.Name + ": " + verb
+			picks[label] = composeActionMsg{verb: verb, project: p}
+			labels = append(labels, label)
+		}
+	}
+	return newFinder("compose project…", labels, func(label string) tea.Msg {
+		return picks[label]
+	})
+}
+
+// composeAction runs up or restart, which change no data, straight away.
+func composeAction(verb string, p compose.Project) tea.Cmd {
+	return func() tea.Msg {
+		if verb == "up" {
+			return composeDoneMsg{done: "Brought up", project: p.Name, err: compose.Up(p)}
+		}
+		return composeDoneMsg{done: "Restarted", project: p.Name, err: compose.Restart(p)}
+	}
+}
+
+func composeDownDialog(p compose.Project) *dialog {
+	return &dialog{
+		title: "Take down compose project " + p.Name + "?",
+		lines: []string{fmt.Sprintf("Its %d container(s) and networks are removed; volumes are kept.", len(p.Containers))},
+		confirm: func() tea.Msg {
+			return composeDoneMsg{done: "Took down", project: p.Name, err: compose.Down(p)}
+		},
+	}
+}
+
+// containerColor colors a container by its state.
+func containerColor(state string) lipgloss.Color {
+	switch state {
+	case "running":
+		return green
+	case "restarting", "paused", "created":
+		return yellow
+	case "dead":
+		return red
+	}
+	return comment
+}
+
+// renderContainers groups the containers under their Compose project.
+func renderContainers(msg containersMsg, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Containers") + "\n")
+	b.WriteString(dim.Render("Grouped by Docker Compose project. enter: logs, restart, up or down a project") + "\n\n")
+	if len(msg.projects) == 0 && len(msg.standalone) == 0 {
+		b.WriteString(dim.Render("No containers.") + "\n")
+		return b.String()
+	}
+
+	nameWidth := 0
+	for _, p := range msg.projects {
+		for _, c := range p.Containers {
+			nameWidth = max(nameWidth, len(c.Service))
+		}
+	}
+	for _, c := range msg.standalone {
+		nameWidth = max(nameWidth, len(c.Name))
+	}
+	nameWidth = min(nameWidth, width/3)
+
+	row := func(name string, c compose.Container) {
+		mark := lipgloss.NewStyle().Foreground(containerColor(c.State)).Render("●")
+		name = fit(name, nameWidth)
+		fmt.Fprintf(&b, "  %s %s  %s  %s\n", mark, name, c.Status, dim.Render(c.Image))
+	}
+	for _, p := range msg.projects {
+		summary := fmt.Sprintf("%d/%d running", p.Running(), len(p.Containers))
+		if p.WorkingDir != "" {
+			summary += " · " + p.WorkingDir
+		}
+		b.WriteString(heading.Render(p.Name) + "  " + dim.Render(summary) + "\n")
+		for _, c := range p.Containers {
+			row(c.Service, c)
+		}
+		b.WriteString("\n")
+	}
+	if len(msg.standalone) > 0 {
+		b.WriteString(heading.Render("Other containers") + "\n")
+		for _, c := range msg.standalone {
+			row(c.Name, c)
+		}
+	}
+	return b.String()
+}
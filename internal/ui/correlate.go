@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// correlateSpan is how far before and after a line the journal of
+	// every unit is shown.
+	correlateSpan = 5 * time.Second
+	// journalSliceLimit bounds the lines of a journal query.
+	journalSliceLimit = 2000
+	// journalUnitWidth is the width of the unit column.
+	journalUnitWidth = 28
+)
+
+// journalSliceMsg carries journal lines of several units for the Journal
+// view. Lines logged in the same second as at are marked. With highlight
+// set, the lines are grouped by unit and the text is highlighted in them.
+type journalSliceMsg struct {
+	title     string
+	lines     []systemd.LogLine
+	at        time.Time
+	highlight string
+	err       error
+}
+
+// correlateMsg asks for the journal of every unit around a time.
+type correlateMsg struct {
+	at time.Time
+}
+
+// lineTime is when a log line was logged: exactly for journal lines, from
+// the timestamp in its text otherwise.
+func lineTime(l systemd.LogLine, now time.Time) (time.Time, bool) {
+	if !l.Time.IsZero() {
+		return l.Time, true
+	}
+	loc := logTimestamp.FindStringIndex(l.Text)
+	if loc == nil {
+		return time.Time{}, false
+	}
+	return parseLogTime(l.Text[loc[0]:loc[1]], now)
+}
+
+// correlatePicker lists the log lines that have a timestamp, newest first,
+// to look up what every other unit logged at the time.
+func correlatePicker(lines []systemd.LogLine) *finder {
+	now := time.Now()
+	picks := make(map[string]time.Time)
+	var labels []string
+	for i := len(lines) - 1; i >= 0; i-- {
+		at, ok := lineTime(lines[i], now)
+		if !ok {
+			continue
+		}
+		label := truncate(lines[i].Text, jsonLabelWidth)
+		if _, dup := picks[label]; !dup {
+			picks[label] = at
+			labels = append(labels, label)
+		}
+	}
+	return newFinder("what else happened then…", labels, func(label string) tea.Msg {
+		return correlateMsg{at: picks[label]}
+	})
+}
+
+func fetchAround(at time.Time) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := systemd.JournalBetween(at.Add(-correlateSpan), at.Add(correlateSpan), journalSliceLimit)
+		title := fmt.Sprintf("All units, %s ± %s", at.Format("2006-01-02 15:04:05"), correlateSpan)
+		return journalSliceMsg{title: title, lines: lines, at: at, err: err}
+	}
+}
+
+// renderJournalSlice merges the lines of every unit in time order, with
+// the unit in front, and returns the row of the first marked line.
+func renderJournalSlice(msg journalSliceMsg, mode timeMode, width int) (string, int) {
+	if msg.highlight != "" {
+		return renderByUnit(msg, mode, width), 0
+	}
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	marked := lipgloss.NewStyle().Foreground(yellow)
+
+	var b strings.Builder
+	b.WriteString(heading.Render(msg.title) + "\n")
+	if len(msg.lines) >= journalSliceLimit {
+		b.WriteString(dim.Render(fmt.Sprintf("Only the last %d lines are shown.", journalSliceLimit)) + "\n")
+	}
+	b.WriteString("\n")
+	if len(msg.lines) == 0 {
+		b.WriteString(dim.Render("Nothing was logged then."))
+		return b.String(), 0
+	}
+
+	now := time.Now()
+	second := msg.at.Truncate(time.Second)
+	top, markRow := strings.Count(b.String(), "\n"), -1
+	for i, l := range msg.lines {
+		unit := fit(orDash(l.Unit), journalUnitWidth)
+		text := truncate(retime(l, mode, now), width-journalUnitWidth-3)
+		if !msg.at.IsZero() && l.Time.Truncate(time.Second).Equal(second) {
+			if markRow < 0 {
+				markRow = top + i
+			}
+			b.WriteString(marked.Render("▶ "+unit) + " " + text + "\n")
+			continue
+		}
+		b.WriteString("  " + dim.Render(unit) + " " + text + "\n")
+	}
+	return b.String(), max(markRow, 0)
+}
+
+// renderByUnit lists the lines of each unit under its name, units in the
+// order they first logged.
+func renderByUnit(msg journalSliceMsg, mode timeMode, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	match := lipgloss.NewStyle().Foreground(yellow).Bold(true).Render(msg.highlight)
+
+	var units []string
+	byUnit := make(map[string][]systemd.LogLine)
+	for _, l := range msg.lines {
+		unit := orDash(l.Unit)
+		if _, ok := byUnit[unit]; !ok {
+			units = append(units, unit)
+		}
+		byUnit[unit] = append(byUnit[unit], l)
+	}
+
+	var b strings.Builder
+	b.WriteString(heading.Render(msg.title) + "\n")
+	if len(msg.lines) >= journalSliceLimit {
+		b.WriteString(dim.Render(fmt.Sprintf("Only the last %d lines are shown.", journalSliceLimit)) + "\n")
+	}
+	if len(msg.lines) == 0 {
+		b.WriteString("\n" + dim.Render("No unit logged it."))
+		return b.String()
+	}
+	now := time.Now()
+	for _, unit := range units {
+		lines := byUnit[unit]
+		b.WriteString("\n" + heading.Render(unit) + dim.Render(fmt.Sprintf(" · %d lines", len(lines))) + "\n")
+		for _, l := range lines {
+			text := truncate(retime(l, mode, now), width-2)
+			b.WriteString("  " + strings.ReplaceAll(text, msg.highlight, match) + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+	"vigilix/internal/update"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// guarded wraps the root model so that a panic in Init, Update, View or a
+// command ends the program through tea.Quit. Bubble Tea then restores the
+// terminal as on a normal exit, instead of leaving it in raw mode mid-frame.
+type guarded struct {
+	model  tea.Model
+	report string // crash report path, once a panic was caught
+	err    error  // writing the report failed
+}
+
+// crashMsg carries a panic out of a command goroutine.
+type crashMsg struct {
+	value any
+	stack []byte
+}
+
+// Guard wraps m, the main UI or the kiosk, for tea.NewProgram. After the
+// program exits, CrashReport tells whether it ended in a panic.
+func Guard(m tea.Model) tea.Model {
+	return &guarded{model: m}
+}
+
+// CrashReport returns where the crash report was written if the program
+// returned by tea.Program.Run ended in a panic.
+func CrashReport(final tea.Model) (path string, crashed bool, err error) {
+	if r, ok := final.(*recorder); ok {
+		final = r.inner
+	}
+	g, ok := final.(*guarded)
+	if !ok || (g.report == "" && g.err == nil) {
+		return "", false, nil
+	}
+	return g.report, true, g.err
+}
+
+func (g *guarded) Init() (cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			cmd = tea.Quit
+		}
+	}()
+	return guardCmd(g.model.Init())
+}
+
+func (g *guarded) Update(msg tea.Msg) (_ tea.Model, cmd tea.Cmd) {
+	if g.crashed() {
+		return g, tea.Quit
+	}
+	if c, ok := msg.(crashMsg); ok {
+		g.crash(c.value, c.stack)
+		return g, tea.Quit
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			cmd = tea.Quit
+		}
+	}()
+	next, cmd := g.model.Update(msg)
+	g.model = next
+	return g, guardCmd(cmd)
+}
+
+func (g *guarded) View() (view string) {
+	if g.crashed() {
+		return ""
+	}
+	// View cannot return a command; the next message (the clock ticks every
+	// second) sees the crash and quits.
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			view = ""
+		}
+	}()
+	return g.model.View()
+}
+
+func (g *guarded) crashed() bool {
+	return g.report != "" || g.err != nil
+}
+
+func (g *guarded) crash(value any, stack []byte) {
+	if g.crashed() {
+		return
+	}
+	slog.Error("panic", "value", value, "stack", string(stack))
+	var summary string
+	if s, ok := g.model.(interface{ crashSummary() string }); ok {
+		summary = s.crashSummary()
+	}
+	path, err := writeCrashReport(value, stack, summary)
+	if err != nil {
+		// Keep the panic for the error message once the terminal is back.
+		g.err = fmt.Errorf("%v (panic: %v)", err, value)
+		return
+	}
+	g.report = path
+}
+
+// cmdsType is the type of tea.BatchMsg and of the message behind
+// tea.Sequence, which Bubble Tea does not export.
+var cmdsType = reflect.TypeFor[[]tea.Cmd]()
+
+// guardCmd runs cmd with a recover that turns a panic into a crashMsg.
+// Batches and sequences are unwrapped so that their commands are guarded
+// too.
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = crashMsg{value: r, stack: debug.Stack()}
+			}
+		}()
+		msg = cmd()
+		if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().ConvertibleTo(cmdsType) {
+			// The converted slice shares its elements with msg.
+			cmds := v.Convert(cmdsType).Interface().([]tea.Cmd)
+			for i := range cmds {
+				cmds[i] = guardCmd(cmds[i])
+			}
+		}
+		return msg
+	}
+}
+
+// crashSummary describes what the kiosk was showing, for the crash report.
+func (k kiosk) crashSummary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "view:      kiosk, page %s\n", kioskPages[k.page%len(kioskPages)])
+	fmt.Fprintf(&b, "size:      %dx%d\n", k.width, k.height)
+	fmt.Fprintf(&b, "scope:     %s\n", systemd.CurrentScope())
+	fmt.Fprintf(&b, "units:     %d\n", len(k.units))
+	return b.String()
+}
+
+// crashSummary describes what the UI was doing, for the crash report. It
+// must not panic itself, so it only reads plain fields.
+func (m model) crashSummary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "view:      %s\n", modeNames[m.viewMode])
+	fmt.Fprintf(&b, "pane:      %d\n", m.activePane)
+	fmt.Fprintf(&b, "size:      %dx%d\n", m.width, m.height)
+	fmt.Fprintf(&b, "scope:     %s\n", systemd.CurrentScope())
+	if systemd.Offline() {
+		fmt.Fprintf(&b, "offline:   %s\n", systemd.JournalDir())
+	}
+	fmt.Fprintf(&b, "units:     %d\n", len(m.allUnits))
+	fmt.Fprintf(&b, "dev mode:  %t\n", m.devMode)
+	fmt.Fprintf(&b, "busy:      %d\n", m.busy)
+	if m.streamingUnit != "" {
+		fmt.Fprintf(&b, "streaming: %s (%d lines)\n", m.streamingUnit, len(m.logLines))
+	}
+	var overlays []string
+	if m.finder != nil {
+		overlays = append(overlays, "finder")
+	}
+	if m.prompt != nil {
+		overlays = append(overlays, "prompt")
+	}
+	if m.dialog != nil {
+		overlays = append(overlays, "dialog")
+	}
+	if m.checklist != nil {
+		overlays = append(overlays, "checklist")
+	}
+	if len(overlays) > 0 {
+		fmt.Fprintf(&b, "overlays:  %s\n", strings.Join(overlays, ", "))
+	}
+	return b.String()
+}
+
+// writeCrashReport saves the panic, its stack and the model summary to a
+// new file in the state directory.
+func writeCrashReport(value any, stack []byte, summary string) (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405")+".txt")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "vigilix %s crashed at %s\n\n", update.Current(), now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %v\n\n", value)
+	b.WriteString("state:\n" + summary + "\n")
+	b.Write(stack)
+	return path, os.WriteFile(path, []byte(b.String()), 0o600)
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/config"
+	"vigilix/internal/dbstats"
+	"vigilix/internal/plugin"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dbStatsMsg opens the quick stats panel of a database unit.
+type dbStatsMsg struct {
+	db   config.Database
+	unit string
+}
+
+// fetchDBStats queries the database and answers like a panel plugin, so the
+// result is shown the same way.
+func fetchDBStats(db config.Database, unit string) tea.Cmd {
+	return func() tea.Msg {
+		p := config.Plugin{Name: "Database stats", Kind: plugin.KindPanel}
+		stats, err := dbstats.Collect(db, unit)
+		if err != nil {
+			return pluginMsg{plugin: p, unit: unit, err: fmt.Errorf("database stats for %s: %w", unit, err)}
+		}
+		width := 0
+		for _, s := range stats {
+			width = max(width, len(s.Label))
+		}
+		var b strings.Builder
+		for _, s := range stats {
+			fmt.Fprintf(&b, "%-*s  %s\n", width, s.Label, s.Value)
+		}
+		title := "Database stats"
+		if kind := dbstats.Kind(db, unit); kind != "" {
+			title = strings.ToUpper(kind[:1]) + kind[1:] + " stats"
+		}
+		return pluginMsg{plugin: p, unit: unit, resp: &plugin.Response{Title: title, Body: b.String()}}
+	}
+}
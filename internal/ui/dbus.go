@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type busMsg struct {
+	services []systemd.BusService
+	err      error
+}
+
+func fetchBus() tea.Msg {
+	services, err := systemd.ActivatableServices()
+	return busMsg{services: services, err: err}
+}
+
+// busPicker jumps to the unit behind a bus name.
+func busPicker(services []systemd.BusService) *finder {
+	units := make(map[string]string)
+	var labels []string
+	for _, s := range services {
+		if s.Unit == "" {
+			continue
+		}
+		label := s.Name + " → " + s.Unit
+		units[label] = s.Unit
+		labels = append(labels, label)
+	}
+	return newFinder("jump to the unit behind a bus name…", labels, func(label string) tea.Msg {
+		return jumpToUnitMsg{name: units[label]}
+	})
+}
+
+// renderBus lists bus-activatable names with their state and unit.
+func renderBus(services []systemd.BusService, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	running := lipgloss.NewStyle().Foreground(green)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("D-Bus activatable services") + "\n")
+	b.WriteString(dim.Render("Started on first bus access rather than at boot. J: jump to a unit") + "\n\n")
+	if len(services) == 0 {
+		b.WriteString(dim.Render("No activatable services found.") + "\n")
+		return b.String()
+	}
+
+	nameWidth := 0
+	for _, s := range services {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+	nameWidth = min(nameWidth, width/2)
+	for _, s := range services {
+		state := dim.Render("idle     ")
+		if s.Running {
+			state = running.Render(fmt.Sprintf("pid %-5d", s.PID))
+		}
+		unit := s.Unit
+		if unit == "" {
+			unit = dim.Render("(started by the bus daemon)")
+		}
+		name := fit(s.Name, nameWidth)
+		fmt.Fprintf(&b, "%s  %s  %s\n", name, state, unit)
+	}
+	return b.String()
+}
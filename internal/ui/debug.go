@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+	"vigilix/internal/supervise"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// frameBudget is the longest a frame may take to render for the UI to keep
+// up with typing; `vigilix bench` fails when the view takes longer.
+const frameBudget = 16 * time.Millisecond
+
+const debugTitle = "Background work"
+
+// frames keeps the render times of the last frames for the debug overlay.
+var frames frameTimes
+
+type frameTimes struct {
+	mu      sync.Mutex
+	recent  [120]time.Duration
+	n       int // frames rendered in total
+	slowest time.Duration
+	over    int // frames over budget
+}
+
+func (f *frameTimes) add(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recent[f.n%len(f.recent)] = d
+	f.n++
+	f.slowest = max(f.slowest, d)
+	if d > frameBudget {
+		f.over++
+	}
+}
+
+// summary describes the latest frame and the average over the recent ones.
+func (f *frameTimes) summary() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.n == 0 {
+		return "no frames yet"
+	}
+	count := min(f.n, len(f.recent))
+	var total time.Duration
+	for _, d := range f.recent[:count] {
+		total += d
+	}
+	last := f.recent[(f.n-1)%len(f.recent)]
+	return fmt.Sprintf("last %s · average %s over %d frames · slowest %s · %d of %d over the %s budget",
+		roundFrame(last), roundFrame(total/time.Duration(count)), count, roundFrame(f.slowest), f.over, f.n, frameBudget)
+}
+
+func roundFrame(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+// debugDialog lists the background goroutines and child processes, to tell
+// a hung subsystem from a slow one when reporting a problem, and how long
+// frames take to render. It is refreshed every second while open.
+func (m model) debugDialog() *dialog {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	heading := lipgloss.NewStyle().Bold(true)
+
+	lines := []string{
+		heading.Render("Goroutines") + dim.Render(fmt.Sprintf("  %d in total", runtime.NumGoroutine())),
+	}
+	tasks := supervise.Goroutines()
+	for _, t := range tasks {
+		lines = append(lines, fmt.Sprintf("  %3d  %s", t.Count, t.Name))
+	}
+	if len(tasks) == 0 {
+		lines = append(lines, dim.Render("  no background tasks"))
+	}
+
+	procs := supervise.Processes()
+	lines = append(lines, "", heading.Render("Subprocesses")+dim.Render(fmt.Sprintf("  %d running", len(procs))))
+	for _, p := range procs {
+		lines = append(lines, fmt.Sprintf("  %7d  %s", p.PID, truncate(p.Command, 60)))
+	}
+
+	lines = append(lines, "", heading.Render("Rendering"), "  "+frames.summary())
+
+	lines = append(lines, "", dim.Render(fmt.Sprintf("busy: %d · log stream: %s", m.busy, orDash(m.streamingUnit))))
+	return &dialog{title: debugTitle, lines: lines}
+}
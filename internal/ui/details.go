@@ -0,0 +1,346 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"vigilix/internal/certs"
+	"vigilix/internal/config"
+	"vigilix/internal/explain"
+	"vigilix/internal/probe"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Properties listed in the Details pane overview, in display order.
+var detailProps = []struct{ key, label string }{
+	{"Description", "Description"},
+	{"LoadState", "Load state"},
+	{"ActiveState", "Active state"},
+	{"SubState", "Sub state"},
+	{"UnitFileState", "Enabled"},
+	{"FragmentPath", "Fragment"},
+	{"MainPID", "Main PID"},
+	{"ExecMainStatus", "Last exit"}, // decoded with ExecMainCode
+	{"TriggeredBy", "Triggered by"},
+	{"Triggers", "Triggers"},
+	{"ActiveEnterTimestamp", "Active since"},
+	{"ConditionResult", "Conditions"},
+	{"ConditionTimestamp", "Checked at"},
+	{"AssertResult", "Asserts"},
+	{"AssertTimestamp", "Asserted at"},
+}
+
+// unitDetails is everything shown in the Details pane for one unit.
+type unitDetails struct {
+	name        string
+	props       map[string]string
+	conditions  []systemd.Condition
+	note        string
+	socket      *systemd.SocketStatus
+	path        *systemd.PathStatus
+	timings     []config.Timing
+	security    *systemd.Security
+	device      *systemd.DeviceInfo
+	waiting     []string // units whose jobs wait for this one
+	usage       *systemd.Usage
+	uptime      []config.Span
+	certs       []certs.Cert
+	certWarning time.Duration
+	probeURL    string
+	probes      []probe.Result
+	timeline    []timelineEntry
+	historyErr  error
+}
+
+type detailsMsg struct {
+	details unitDetails
+	err     error
+}
+
+func fetchDetails(name string) tea.Cmd {
+	keys := make([]string, len(detailProps), len(detailProps)+1)
+	for i, p := range detailProps {
+		keys[i] = p.key
+	}
+	keys = append(keys, "ExecMainCode")
+	return func() tea.Msg {
+		props, err := systemd.ShowProperties(name, keys...)
+		if err != nil {
+			return detailsMsg{err: err}
+		}
+		d := unitDetails{name: name, props: props}
+		switch {
+		case strings.HasSuffix(name, ".socket"):
+			d.socket, err = systemd.Socket(name)
+		case strings.HasSuffix(name, ".path"):
+			d.path, err = systemd.PathUnit(name)
+		case strings.HasSuffix(name, ".device") && !systemd.Offline():
+			d.device, err = systemd.Device(name)
+			if jobs, jobsErr := systemd.Jobs(); jobsErr == nil {
+				d.waiting = waitingOn(jobs, name)
+			}
+		}
+		if err != nil {
+			return detailsMsg{details: d, err: err}
+		}
+		if systemd.OwnsCgroup(name) && !systemd.Offline() {
+			if u, err := systemd.UnitUsageOf(name); err == nil {
+				d.usage = &u
+			}
+		}
+		if historyEnabled {
+			d.timeline, d.historyErr = fetchTimeline(name)
+		}
+		// Older systemd lacks the analysis; the section is just left out.
+		if strings.HasSuffix(name, ".service") && !systemd.Offline() {
+			d.security, _ = systemd.AnalyzeSecurity(name)
+		}
+		d.conditions, err = systemd.Conditions(name)
+		return detailsMsg{details: d, err: err}
+	}
+}
+
+func renderDetails(d unitDetails, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	label := lipgloss.NewStyle().Foreground(comment).Width(14)
+	value := lipgloss.NewStyle().Width(width - 15)
+
+	var b strings.Builder
+	b.WriteString(heading.Render(d.name) + "\n\n")
+	if path, ok := systemd.UnitPath(d.name); ok {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render("Path"), " ", value.Render(path)) + "\n")
+	}
+	for _, p := range detailProps {
+		v := d.props[p.key]
+		if p.key == "ExecMainStatus" {
+			v = explain.ExitStatus(d.props["ExecMainCode"], v)
+		}
+		if v == "" {
+			continue
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render(p.label), " ", value.Render(v)) + "\n")
+	}
+
+	if d.socket != nil {
+		b.WriteString("\n" + heading.Render("Socket") + "\n")
+		b.WriteString(renderSocket(d.socket, label, value))
+	}
+	if d.path != nil {
+		b.WriteString("\n" + heading.Render("Watched paths") + "\n")
+		b.WriteString(renderPathUnit(d.path, label, value))
+	}
+	if d.device != nil {
+		b.WriteString("\n" + heading.Render("Device") + "\n")
+		b.WriteString(renderDevice(d.device, d.waiting, label, value))
+	}
+
+	if d.usage != nil {
+		b.WriteString("\n" + heading.Render("Resources") + "\n")
+		b.WriteString(renderUsage(d.usage, label, value))
+	}
+
+	if d.note != "" {
+		b.WriteString("\n" + heading.Render("Notes") + "\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(yellow).Width(width).Render(d.note) + "\n")
+	}
+
+	if d.probeURL != "" {
+		b.WriteString("\n" + heading.Render("HTTP probe") + "\n")
+		b.WriteString(renderProbe(d.probeURL, d.probes, label, value))
+	}
+
+	if len(d.certs) > 0 {
+		b.WriteString("\n" + heading.Render("Certificates") + "\n")
+		b.WriteString(renderCerts(d.certs, d.certWarning, label, value))
+	}
+
+	if !systemd.Offline() {
+		b.WriteString("\n" + heading.Render("Availability") + "\n")
+		b.WriteString(renderAvailability(d.uptime, time.Now(), label, value))
+	}
+
+	if historyEnabled {
+		b.WriteString("\n" + heading.Render("History") + "\n")
+		b.WriteString(renderTimeline(d.timeline, d.historyErr))
+	}
+
+	b.WriteString("\n" + heading.Render("Action timings") + "\n")
+	b.WriteString(renderTimings(d.timings, width))
+
+	b.WriteString("\n" + heading.Render("Conditions & Asserts") + "\n")
+	b.WriteString(renderConditions(d.conditions, d.props))
+
+	if d.security != nil {
+		b.WriteString("\n" + heading.Render("Security exposure") + "\n")
+		b.WriteString(renderSecurity(d.security, width))
+	}
+	return b.String()
+}
+
+// renderUsage lists a unit's accounted resources. I/O and IP accounting are
+// off by default, so their rows point at the key that enables them.
+func renderUsage(u *systemd.Usage, label, value lipgloss.Style) string {
+	off := lipgloss.NewStyle().Foreground(comment).Render("accounting off · A: enable I/O and IP accounting")
+	row := func(name, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Top, label.Render(name), " ", value.Render(v)) + "\n"
+	}
+	var b strings.Builder
+	if u.HasCPU {
+		b.WriteString(row("CPU time", humanDuration(u.CPU)))
+	}
+	if u.HasMem {
+		b.WriteString(row("Memory", humanBytes(u.Memory)))
+	}
+	if u.HasIO {
+		b.WriteString(row("Disk I/O", fmt.Sprintf("%s read · %s written", humanBytes(u.IORead), humanBytes(u.IOWrite))))
+	} else {
+		b.WriteString(row("Disk I/O", off))
+	}
+	if u.HasIP {
+		b.WriteString(row("Network", fmt.Sprintf("%s in · %s out", humanBytes(u.IPIn), humanBytes(u.IPOut))))
+	} else {
+		b.WriteString(row("Network", off))
+	}
+	return b.String()
+}
+
+// enableAccountingDialog confirms turning on I/O and IP accounting, which
+// set-property also writes to a drop-in so it survives a reboot.
+func enableAccountingDialog(unit string) *dialog {
+	return &dialog{
+		title: "Enable I/O and IP accounting for " + unit + "?",
+		lines: []string{"Runs systemctl set-property " + unit + " IOAccounting=yes IPAccounting=yes; counting starts now."},
+		confirm: func() tea.Msg {
+			return actionResultMsg{err: systemd.EnableAccounting(unit), action: "Enabled accounting for the", unit: unit}
+		},
+	}
+}
+
+// exposureColor grades an exposure score the way systemd-analyze does.
+func exposureColor(score float64) lipgloss.Color {
+	switch {
+	case score < 5:
+		return green
+	case score < 7:
+		return yellow
+	case score < 9:
+		return orange
+	}
+	return red
+}
+
+// renderSecurity shows the overall score and the protections that are not
+// in place, costliest first.
+func renderSecurity(s *systemd.Security, width int) string {
+	var b strings.Builder
+	score := lipgloss.NewStyle().Bold(true).Foreground(exposureColor(s.Exposure))
+	fmt.Fprintf(&b, "%s  %s\n", score.Render(fmt.Sprintf("%.1f %s", s.Exposure, s.Level)),
+		lipgloss.NewStyle().Foreground(comment).Render("(0 = locked down, 10 = fully exposed)"))
+
+	var open []systemd.SecurityFinding
+	passed := 0
+	for _, f := range s.Findings {
+		switch {
+		case f.Set:
+			passed++
+		case f.Applicable && f.Exposure > 0:
+			open = append(open, f)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool { return open[i].Exposure > open[j].Exposure })
+
+	name := lipgloss.NewStyle().Width(34)
+	desc := lipgloss.NewStyle().Foreground(comment).Width(max(width-45, 10))
+	mark := lipgloss.NewStyle().Foreground(red).Render("✗")
+	for _, f := range open {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			mark+" ", name.Render(f.Name), " ", fmt.Sprintf("%.1f  ", f.Exposure), desc.Render(f.Description)) + "\n")
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(green).Render(fmt.Sprintf("✓ %d protections in place", passed)) + "\n")
+	return b.String()
+}
+
+func renderConditions(conds []systemd.Condition, props map[string]string) string {
+	if len(conds) == 0 {
+		return lipgloss.NewStyle().Foreground(comment).Render("None declared.") + "\n"
+	}
+
+	// Without a recorded check, pass/fail is unknown rather than "passed".
+	checked := props["ConditionTimestamp"] != "" || props["AssertTimestamp"] != ""
+
+	var b strings.Builder
+	for _, c := range conds {
+		mark := lipgloss.NewStyle().Foreground(comment).Render("?")
+		switch {
+		case c.Failed:
+			mark = lipgloss.NewStyle().Foreground(red).Render("✗")
+		case checked:
+			mark = lipgloss.NewStyle().Foreground(green).Render("✓")
+		}
+		kind := ""
+		if c.Assert {
+			kind = lipgloss.NewStyle().Foreground(orange).Render(" (assert)")
+		}
+		fmt.Fprintf(&b, "%s %s=%s%s\n", mark, c.Directive, c.Value, kind)
+	}
+	return b.String()
+}
+
+func renderSocket(s *systemd.SocketStatus, label, value lipgloss.Style) string {
+	row := func(l, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Top, label.Render(l), " ", value.Render(v)) + "\n"
+	}
+
+	listening := lipgloss.NewStyle().Foreground(red).Render("✗ not listening")
+	if s.Listening {
+		listening = lipgloss.NewStyle().Foreground(green).Render("✓ listening")
+	}
+
+	var b strings.Builder
+	b.WriteString(row("Status", listening))
+	for i, addr := range s.Listen {
+		l := ""
+		if i == 0 {
+			l = "Listen"
+		}
+		b.WriteString(row(l, addr))
+	}
+	b.WriteString(row("Accepted", strconv.Itoa(s.Accepted)))
+	b.WriteString(row("Open now", strconv.Itoa(s.Connections)))
+	if s.Refused > 0 {
+		b.WriteString(row("Refused", lipgloss.NewStyle().Foreground(orange).Render(strconv.Itoa(s.Refused))))
+	}
+	if len(s.Triggers) > 0 {
+		b.WriteString(row("Activates", strings.Join(s.Triggers, ", ")+"  (t: start now · J: jump)"))
+	}
+	return b.String()
+}
+
+func renderPathUnit(p *systemd.PathStatus, label, value lipgloss.Style) string {
+	row := func(l, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Top, label.Render(l), " ", value.Render(v)) + "\n"
+	}
+
+	var b strings.Builder
+	status := lipgloss.NewStyle().Foreground(comment).Render("not watching")
+	if p.Waiting {
+		status = lipgloss.NewStyle().Foreground(green).Render("✓ watching")
+	}
+	b.WriteString(row("Status", status))
+	for _, w := range p.Paths {
+		exists := lipgloss.NewStyle().Foreground(comment).Render("(missing)")
+		if w.Exists {
+			exists = lipgloss.NewStyle().Foreground(green).Render("(exists)")
+		}
+		b.WriteString(row(w.Condition, w.Path+" "+exists))
+	}
+	if len(p.Triggers) > 0 {
+		b.WriteString(row("Activates", strings.Join(p.Triggers, ", ")+"  (t: start now · J: jump)"))
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type jobsMsg struct {
+	jobs []systemd.Job
+	err  error
+}
+
+func fetchJobs() tea.Msg {
+	jobs, err := systemd.Jobs()
+	return jobsMsg{jobs: jobs, err: err}
+}
+
+// waitingOn lists the units whose jobs wait for a start job of unit, e.g.
+// the services ordered after a disk that has not shown up.
+func waitingOn(jobs []systemd.Job, unit string) []string {
+	var units []string
+	for _, j := range jobs {
+		if j.Unit == unit && j.Type == "start" {
+			units = append(units, j.Blocking...)
+		}
+	}
+	return units
+}
+
+// udevKeys are the udev properties worth showing first, in order.
+var udevKeys = []string{
+	"DEVNAME", "SUBSYSTEM", "DEVTYPE", "ID_VENDOR", "ID_MODEL", "ID_SERIAL",
+	"ID_PATH", "ID_FS_TYPE", "ID_FS_LABEL", "ID_FS_UUID", "ID_NET_NAME",
+	"SYSTEMD_WANTS", "SYSTEMD_READY",
+}
+
+func renderDevice(d *systemd.DeviceInfo, waiting []string, label, value lipgloss.Style) string {
+	row := func(l, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Top, label.Render(l), " ", value.Render(v)) + "\n"
+	}
+
+	var b strings.Builder
+	if len(waiting) > 0 {
+		warn := lipgloss.NewStyle().Foreground(orange).Bold(true)
+		b.WriteString(row("Waited on by", warn.Render(strings.Join(waiting, ", "))))
+	}
+	if d.SysfsPath == "" {
+		b.WriteString(row("Sysfs", lipgloss.NewStyle().Foreground(comment).Render("not plugged in")))
+		return b.String()
+	}
+	b.WriteString(row("Sysfs", d.SysfsPath))
+	if d.Driver != "" {
+		b.WriteString(row("Driver", d.Driver))
+	}
+
+	shown := make(map[string]bool)
+	for _, k := range udevKeys {
+		if v := d.Properties[k]; v != "" {
+			b.WriteString(row(k, v))
+			shown[k] = true
+		}
+	}
+	var rest []string
+	for k := range d.Properties {
+		if !shown[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	for _, k := range rest {
+		b.WriteString(row(k, d.Properties[k]))
+	}
+	return b.String()
+}
+
+// jobPicker picks a queued job to cancel.
+func jobPicker(jobs []systemd.Job) *finder {
+	byLabel := make(map[string]systemd.Job)
+	var labels []string
+	for _, j := range jobs {
+		label := fmt.Sprintf("%d %s/%s (%s)", j.ID, j.Unit, j.Type, j.State)
+		byLabel[label] = j
+		labels = append(labels, label)
+	}
+	return newFinder("cancel job…", labels, func(label string) tea.Msg {
+		return cancelJobMsg{job: byLabel[label]}
+	})
+}
+
+type cancelJobMsg struct{ job systemd.Job }
+
+func cancelJobDialog(j systemd.Job) *dialog {
+	lines := []string{fmt.Sprintf("%s of %s is %s.", j.Type, j.Unit, j.State)}
+	if len(j.Blocking) > 0 {
+		lines = append(lines, "Waiting for it: "+strings.Join(j.Blocking, ", "))
+	}
+	return &dialog{
+		title: fmt.Sprintf("Cancel job %d?", j.ID),
+		lines: lines,
+		confirm: func() tea.Msg {
+			return actionResultMsg{err: systemd.CancelJob(j.ID), action: "Cancelled the job of", unit: j.Unit}
+		},
+	}
+}
+
+// renderJobs lists the job queue, running jobs first.
+func renderJobs(jobs []systemd.Job, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Job queue") + "\n")
+	b.WriteString(dim.Render("Pending state changes. A unit stuck activating has a start job here; C: cancel a job") + "\n\n")
+	if len(jobs) == 0 {
+		b.WriteString(dim.Render("No jobs queued.") + "\n")
+		return b.String()
+	}
+
+	sorted := append([]systemd.Job(nil), jobs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].State == "running" && sorted[j].State != "running"
+	})
+	unitWidth := 0
+	for _, j := range sorted {
+		unitWidth = max(unitWidth, len(j.Unit))
+	}
+	unitWidth = min(unitWidth, width/2)
+	for _, j := range sorted {
+		state := dim.Render(fmt.Sprintf("%-8s", j.State))
+		if j.State == "running" {
+			state = lipgloss.NewStyle().Foreground(yellow).Render(fmt.Sprintf("%-8s", j.State))
+		}
+		fmt.Fprintf(&b, "%6d  %s  %-14s %s\n", j.ID, lipgloss.NewStyle().Width(unitWidth).Render(j.Unit), j.Type, state)
+		if len(j.Blocking) > 0 {
+			b.WriteString(lipgloss.NewStyle().Foreground(orange).Render("        blocks "+strings.Join(j.Blocking, ", ")) + "\n")
+		}
+	}
+	return b.String()
+}
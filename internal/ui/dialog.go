@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dialog is a modal box drawn over the screen. With a confirm command it asks
+// a yes/no question (enter/y runs it, esc/n cancels); without one it is
+// purely informational and any key dismisses it.
+type dialog struct {
+	title string
+	lines []string
+	// confirm is tracked as background work, so its result message must
+	// decrement model.busy.
+	confirm tea.Cmd
+}
+
+// showDialogMsg opens a dialog from an asynchronous command.
+type showDialogMsg struct {
+	dialog *dialog
+}
+
+// handleKey reports the command to run (if any) and whether the dialog
+// should close.
+func (d *dialog) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if d.confirm == nil {
+		return nil, true
+	}
+	switch msg.String() {
+	case "enter", "y":
+		return d.confirm, true
+	case "esc", "n", "q":
+		return nil, true
+	}
+	return nil, false
+}
+
+func (d *dialog) View(width int) string {
+	hint := "press any key to close"
+	if d.confirm != nil {
+		hint = "enter/y: confirm · esc/n: cancel"
+	}
+
+	body := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(cyan).Render(d.title),
+		"",
+	}
+	body = append(body, d.lines...)
+	body = append(body, "", lipgloss.NewStyle().Foreground(comment).Render(hint))
+
+	return lipgloss.NewStyle().
+		Border(panelBorder).
+		BorderForeground(purple).
+		Background(background).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(body, "\n"))
+}
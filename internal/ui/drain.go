@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/config"
+	"vigilix/internal/drain"
+	"vigilix/internal/supervise"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type drainDoneMsg struct {
+	unit  string
+	steps []drain.Step
+	err   error
+}
+
+// drainDialog describes the configured drain of the unit and runs it on
+// confirm.
+func drainDialog(unit string, d config.Drain) *dialog {
+	var lines []string
+	if d.Signal != "" {
+		lines = append(lines, "  • send "+d.Signal)
+	}
+	if len(d.Command) > 0 {
+		lines = append(lines, "  • run "+strings.Join(d.Command, " "))
+	}
+	var until []string
+	if d.Port > 0 {
+		until = append(until, fmt.Sprintf("no connection is open on port %d", d.Port))
+	}
+	if d.Probe != "" {
+		until = append(until, d.Probe+" answers")
+	}
+	if len(until) > 0 {
+		lines = append(lines, fmt.Sprintf("  • wait up to %s until %s", d.Timeout(), strings.Join(until, " and ")))
+	}
+	lines = append(lines, "  • stop "+unit)
+	return &dialog{
+		title:   "Drain " + unit,
+		lines:   lines,
+		confirm: runDrain(unit, d),
+	}
+}
+
+func runDrain(unit string, d config.Drain) tea.Cmd {
+	return func() tea.Msg {
+		steps, err := drain.Run(supervise.Context(), unit, d)
+		return drainDoneMsg{unit: unit, steps: steps, err: err}
+	}
+}
+
+// drainReportDialog lists the steps a drain took and how it ended.
+func drainReportDialog(msg drainDoneMsg) *dialog {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	var lines []string
+	for _, s := range msg.steps {
+		lines = append(lines, dim.Render(s.At.Format("15:04:05"))+" "+s.Text)
+	}
+	if msg.err != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(red).Render("✗ "+msg.err.Error()))
+	}
+	return &dialog{title: "Drain " + msg.unit, lines: lines}
+}
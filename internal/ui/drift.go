@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/baseline"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type driftMsg struct {
+	path     string
+	manifest *baseline.Manifest
+	drifts   []baseline.Drift
+	err      error
+}
+
+// fetchDrift loads the baseline manifest and compares it with the units'
+// load, active and unit file states.
+func fetchDrift(path string) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			var err error
+			if path, err = baseline.DefaultPath(); err != nil {
+				return driftMsg{err: err}
+			}
+		}
+		manifest, err := baseline.Load(path)
+		if err != nil || manifest == nil {
+			return driftMsg{path: path, err: err}
+		}
+		units, err := systemd.ListUnits()
+		if err != nil {
+			return driftMsg{path: path, err: err}
+		}
+		files, err := systemd.UnitFileStates()
+		if err != nil {
+			return driftMsg{path: path, err: err}
+		}
+		return driftMsg{path: path, manifest: manifest, drifts: baseline.Check(manifest, observedUnits(units, files))}
+	}
+}
+
+// observedUnits merges the loaded units with the installed unit files, so
+// that disabled units that are not loaded are seen too.
+func observedUnits(units []systemd.Unit, files map[string]string) []baseline.Unit {
+	seen := make(map[string]bool, len(units))
+	var out []baseline.Unit
+	for _, u := range units {
+		seen[u.Name] = true
+		out = append(out, baseline.Unit{
+			Name:   u.Name,
+			Loaded: u.LoadState == "loaded",
+			Active: u.ActiveState,
+			File:   files[u.Name],
+		})
+	}
+	for name, state := range files {
+		if !seen[name] {
+			out = append(out, baseline.Unit{Name: name, Active: "inactive", File: state})
+		}
+	}
+	return out
+}
+
+// reconcileChecklist offers every fix for the drifted units. Fixes for
+// listed units are checked; stopping or disabling unlisted ones is left to
+// the user.
+func reconcileChecklist(drifts []baseline.Drift) *checklist {
+	var items []checkItem
+	for _, d := range drifts {
+		for _, verb := range d.Fixes {
+			items = append(items, checkItem{
+				label:   verb + " " + d.Unit,
+				value:   verb + " " + d.Unit,
+				note:    string(d.Kind),
+				checked: d.Kind != baseline.Extra,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return &checklist{
+		title:  fmt.Sprintf("Reconcile %d drifted units", len(drifts)),
+		items:  items,
+		submit: reconcile,
+	}
+}
+
+// fixResult is one systemctl call made while reconciling.
+type fixResult struct {
+	verb, unit string
+	err        error
+}
+
+type reconciledMsg struct {
+	results []fixResult
+}
+
+var fixActions = map[string]func(string) error{
+	"enable":  systemd.EnableUnit,
+	"disable": systemd.DisableUnit,
+	"start":   systemd.StartUnit,
+	"stop":    systemd.StopUnit,
+}
+
+// reconcile runs the fixes in order, e.g. "enable nginx.service" before
+// "start nginx.service", and carries on past failures.
+func reconcile(fixes []string) tea.Cmd {
+	return func() tea.Msg {
+		var msg reconciledMsg
+		for _, fix := range fixes {
+			verb, unit, _ := strings.Cut(fix, " ")
+			msg.results = append(msg.results, fixResult{verb: verb, unit: unit, err: fixActions[verb](unit)})
+		}
+		return msg
+	}
+}
+
+// renderDrift lists the units that differ from the baseline.
+func renderDrift(msg driftMsg, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Baseline drift") + "\n")
+	if msg.manifest == nil {
+		b.WriteString(dim.Render("No baseline at "+msg.path+". List the expected units and their state there:") + "\n\n")
+		b.WriteString("units:\n  nginx.service: {enabled: true, active: true}\n  telnet.socket: {enabled: false, active: false}\n")
+		b.WriteString("strict:\n  - \"*.service\"   # enabled or running services that are not listed are extra\n")
+		return b.String()
+	}
+	b.WriteString(dim.Render(fmt.Sprintf("%s · %d units declared", msg.path, len(msg.manifest.Units))) + "\n")
+	if len(msg.drifts) == 0 {
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(green).Render("✓ No drift: every declared unit is in its expected state.") + "\n")
+		return b.String()
+	}
+	counts := make(map[baseline.Kind]int)
+	for _, d := range msg.drifts {
+		counts[d.Kind]++
+	}
+	b.WriteString(dim.Render(fmt.Sprintf("%d missing · %d extra · %d in the wrong state · enter: reconcile",
+		counts[baseline.Missing], counts[baseline.Extra], counts[baseline.Wrong])) + "\n\n")
+
+	kindStyle := map[baseline.Kind]lipgloss.Style{
+		baseline.Missing: lipgloss.NewStyle().Foreground(red),
+		baseline.Extra:   lipgloss.NewStyle().Foreground(orange),
+		baseline.Wrong:   lipgloss.NewStyle().Foreground(yellow),
+	}
+	nameWidth := 0
+	for _, d := range msg.drifts {
+		nameWidth = max(nameWidth, lipgloss.Width(d.Unit))
+	}
+	nameWidth = min(nameWidth, width/2)
+	for _, d := range msg.drifts {
+		kind := kindStyle[d.Kind].Render(fmt.Sprintf("%-8s", d.Kind))
+		name := fit(d.Unit, nameWidth)
+		line := kind + name + "  want " + d.Want + dim.Render("  have "+d.Have)
+		if len(d.Fixes) == 0 && d.Kind != baseline.Missing {
+			line += dim.Render("  (fix by hand)")
+		}
+		b.WriteString(truncate(line, width) + "\n")
+	}
+	return b.String()
+}
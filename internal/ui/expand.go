@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"vigilix/internal/systemd"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Properties shown when a row is expanded with `i`.
+var expandProps = []struct{ key, label string }{
+	{"FragmentPath", "Fragment"},
+	{"UnitFileState", "Enabled"},
+	{"ActiveEnterTimestamp", "Active since"},
+	{"MainPID", "Main PID"},
+}
+
+type unitPropsMsg struct {
+	name  string
+	props map[string]string
+	err   error
+}
+
+func fetchExpandProps(name string) tea.Cmd {
+	keys := make([]string, len(expandProps))
+	for i, p := range expandProps {
+		keys[i] = p.key
+	}
+	return func() tea.Msg {
+		props, err := systemd.ShowProperties(name, keys...)
+		return unitPropsMsg{name: name, props: props, err: err}
+	}
+}
+
+// expandedRow is the inline detail box drawn under the selected list row.
+type expandedRow struct {
+	name  string
+	props map[string]string
+}
+
+func (e expandedRow) view(width int) string {
+	var lines []string
+	labelStyle := lipgloss.NewStyle().Foreground(comment).Width(13)
+	for _, p := range expandProps {
+		value := "…"
+		if e.props != nil {
+			value = e.props[p.key]
+			if value == "" || (p.key == "MainPID" && value == "0") {
+				value = "-"
+			}
+		}
+		lines = append(lines, labelStyle.Render(p.label)+" "+value)
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(purple).
+		Background(background).
+		Foreground(foreground).
+		Padding(0, 1).
+		Width(width - 2).
+		MaxWidth(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// expandedRowOffset returns the sidebar-relative line just below the
+// selected row, accounting for the panel border, the custom header and the
+// list's own title/filter bar.
+func (m model) expandedRowOffset(headerHeight int) int {
+	titleHeight := 1
+	if m.list.FilterState() == list.Filtering {
+		titleHeight = lipgloss.Height(m.list.Styles.TitleBar.Render(m.list.FilterInput.View()))
+	}
+
+	d := m.delegate()
+	row := m.list.Index() - m.list.Paginator.Page*m.list.Paginator.PerPage
+	return 1 + headerHeight + titleHeight + row*(d.Height()+d.Spacing()) + d.Height()
+}
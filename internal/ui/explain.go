@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"vigilix/internal/explain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type explainMsg struct {
+	report *explain.Report
+	err    error
+}
+
+func fetchExplain(name string) tea.Cmd {
+	return func() tea.Msg {
+		r, err := explain.Unit(name)
+		return explainMsg{report: r, err: err}
+	}
+}
+
+// renderExplain lays out a report for the Why pane.
+func renderExplain(r *explain.Report, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	wrap := lipgloss.NewStyle().Width(width)
+
+	var b strings.Builder
+	b.WriteString(heading.Render(r.Unit) + "  " + lipgloss.NewStyle().Foreground(comment).Render(r.State) + "\n\n")
+	b.WriteString(wrap.Copy().Bold(true).Foreground(orange).Render(r.Summary) + "\n")
+
+	if len(r.Findings) > 0 {
+		b.WriteString("\n" + heading.Render("Findings") + "\n")
+		for _, f := range r.Findings {
+			b.WriteString(wrap.Render("• "+f) + "\n")
+		}
+	}
+
+	if len(r.Denials) > 0 {
+		b.WriteString("\n" + heading.Render("Denials") + "\n")
+		denied := wrap.Copy().Foreground(red)
+		for _, d := range r.Denials {
+			b.WriteString(denied.Render(d) + "\n")
+		}
+	}
+
+	if len(r.Logs) > 0 {
+		b.WriteString("\n" + heading.Render("Recent journal") + "\n")
+		logStyle := lipgloss.NewStyle().Foreground(comment)
+		for _, line := range r.Logs {
+			b.WriteString(logStyle.Render(line) + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"vigilix/internal/export"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportColumns maps the column names an export accepts to their values.
+var exportColumns = map[string]func(i item, now time.Time) string{
+	"name":        func(i item, _ time.Time) string { return i.unit.Name },
+	"load":        func(i item, _ time.Time) string { return i.unit.LoadState },
+	"active":      func(i item, _ time.Time) string { return i.unit.ActiveState },
+	"sub":         func(i item, _ time.Time) string { return i.unit.SubState },
+	"description": func(i item, _ time.Time) string { return i.unit.Description },
+	"since":       func(i item, now time.Time) string { return i.relativeState(now) },
+	"note":        func(i item, _ time.Time) string { return i.note },
+	"uptime24h":   func(i item, now time.Time) string { return availability(i.uptime, 24*time.Hour, now) },
+	"uptime7d":    func(i item, now time.Time) string { return availability(i.uptime, 7*24*time.Hour, now) },
+	"uptime30d":   func(i item, now time.Time) string { return availability(i.uptime, 30*24*time.Hour, now) },
+}
+
+const defaultExportColumns = "name,active,sub,description"
+
+type exportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// exportPrompt asks where to write the visible unit list and which columns
+// to include. The format follows the file extension.
+func (m model) exportPrompt() *prompt {
+	var items []item
+	for _, li := range m.list.VisibleItems() {
+		if i, ok := li.(item); ok {
+			items = append(items, i)
+		}
+	}
+	now := m.status.now
+	return newPrompt(
+		fmt.Sprintf("Export %d units", len(items)),
+		"<file.csv|.json|.md> [columns] · columns: name,load,active,sub,description,since,note,uptime24h,uptime7d,uptime30d",
+		"vigilix-units.md "+defaultExportColumns,
+		func(value string) tea.Cmd {
+			return func() tea.Msg { return exportUnits(items, value, now) }
+		},
+	)
+}
+
+func exportUnits(items []item, input string, now time.Time) tea.Msg {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return exportedMsg{err: fmt.Errorf("expected a file name")}
+	}
+	path := fields[0]
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return exportedMsg{err: err}
+		}
+		path = filepath.Join(home, rest)
+	}
+	format, err := export.FormatForPath(path)
+	if err != nil {
+		return exportedMsg{err: err}
+	}
+
+	columns := defaultExportColumns
+	if len(fields) > 1 {
+		columns = fields[1]
+	}
+	t := export.Table{Columns: strings.Split(columns, ",")}
+	for _, col := range t.Columns {
+		if exportColumns[col] == nil {
+			return exportedMsg{err: fmt.Errorf("unknown column %q", col)}
+		}
+	}
+	for _, i := range items {
+		row := make([]string, len(t.Columns))
+		for j, col := range t.Columns {
+			row[j] = exportColumns[col](i, now)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return exportedMsg{err: err}
+	}
+	if err := export.Write(f, format, t); err != nil {
+		f.Close()
+		return exportedMsg{err: err}
+	}
+	return exportedMsg{path: path, count: len(t.Rows), err: f.Close()}
+}
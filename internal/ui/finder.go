@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+const finderMaxResults = 10
+
+var finderKeys = struct {
+	Up, Down, Select, Close key.Binding
+}{
+	Up:     key.NewBinding(key.WithKeys("up", "ctrl+p", "ctrl+k")),
+	Down:   key.NewBinding(key.WithKeys("down", "ctrl+n", "ctrl+j")),
+	Select: key.NewBinding(key.WithKeys("enter")),
+	Close:  key.NewBinding(key.WithKeys("esc", "ctrl+f")),
+}
+
+// finder is a fuzzy picker overlay (like fzf). ctrl+f uses it to jump to
+// any unit, ignoring the dev-mode and list filters; other features reuse it
+// to pick from their own candidates.
+type finder struct {
+	input      textinput.Model
+	candidates []string
+	matches    fuzzy.Matches
+	cursor     int
+	pick       func(string) tea.Msg
+	// typed, when set, turns the query itself into a candidate listed
+	// before the fuzzy matches, e.g. a command with arguments.
+	typed func(query string) (string, bool)
+}
+
+// finderClosedMsg is emitted when the finder closes without a pick.
+type finderClosedMsg struct{}
+
+// jumpToUnitMsg selects a unit in the list.
+type jumpToUnitMsg struct {
+	name string
+}
+
+func newFinder(placeholder string, candidates []string, pick func(string) tea.Msg) *finder {
+	ti := textinput.New()
+	ti.Prompt = "❯ "
+	ti.Placeholder = placeholder
+	ti.Focus()
+
+	f := &finder{input: ti, candidates: candidates, pick: pick}
+	f.refresh()
+	return f
+}
+
+func (f *finder) refresh() {
+	f.cursor = 0
+	query := f.input.Value()
+	if query == "" {
+		f.matches = make(fuzzy.Matches, len(f.candidates))
+		for i, c := range f.candidates {
+			f.matches[i] = fuzzy.Match{Str: c, Index: i}
+		}
+		return
+	}
+	f.matches = fuzzy.Find(query, f.candidates)
+	if f.typed != nil {
+		if label, ok := f.typed(query); ok {
+			f.matches = append(fuzzy.Matches{{Str: label, Index: -1}}, f.matches...)
+		}
+	}
+}
+
+func (f *finder) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, finderKeys.Close):
+		return func() tea.Msg { return finderClosedMsg{} }
+	case key.Matches(msg, finderKeys.Select):
+		if f.cursor < len(f.matches) {
+			name := f.matches[f.cursor].Str
+			return tea.Sequence(
+				func() tea.Msg { return finderClosedMsg{} },
+				func() tea.Msg { return f.pick(name) },
+			)
+		}
+		return nil
+	case key.Matches(msg, finderKeys.Up):
+		if f.cursor > 0 {
+			f.cursor--
+		}
+		return nil
+	case key.Matches(msg, finderKeys.Down):
+		if f.cursor < len(f.matches)-1 && f.cursor < finderMaxResults-1 {
+			f.cursor++
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	before := f.input.Value()
+	f.input, cmd = f.input.Update(msg)
+	if f.input.Value() != before {
+		f.refresh()
+	}
+	return cmd
+}
+
+func (f *finder) View(width int) string {
+	innerWidth := width - 4
+	matchStyle := lipgloss.NewStyle().Foreground(pink).Bold(true)
+
+	lines := []string{f.input.View(), lipgloss.NewStyle().Foreground(comment).Render(strings.Repeat("─", innerWidth))}
+	for i, match := range f.matches {
+		if i >= finderMaxResults {
+			break
+		}
+		lines = append(lines, renderMatch(match, i == f.cursor, matchStyle))
+	}
+	if len(f.matches) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(comment).Render("no matches"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(panelBorder).
+		BorderForeground(purple).
+		Background(background).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderMatch highlights the characters that matched the query.
+func renderMatch(match fuzzy.Match, selected bool, matchStyle lipgloss.Style) string {
+	rowStyle := lipgloss.NewStyle().Foreground(foreground)
+	prefix := "  "
+	if selected {
+		rowStyle = rowStyle.Background(current)
+		matchStyle = matchStyle.Background(current)
+		prefix = "▸ "
+	}
+
+	matched := make(map[int]bool, len(match.MatchedIndexes))
+	for _, idx := range match.MatchedIndexes {
+		matched[idx] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(rowStyle.Render(prefix))
+	for i, r := range match.Str {
+		if matched[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteString(rowStyle.Render(string(r)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type firewallMsg struct {
+	firewalls []systemd.Firewall
+	err       error
+}
+
+func fetchFirewall() tea.Msg {
+	fws, err := systemd.Firewalls()
+	return firewallMsg{firewalls: fws, err: err}
+}
+
+// firewallPicker jumps to the unit owning a firewall.
+func firewallPicker(fws []systemd.Firewall) *finder {
+	var labels []string
+	for _, fw := range fws {
+		if fw.Unit != "" {
+			labels = append(labels, fw.Unit)
+		}
+	}
+	return newFinder("jump to firewall unit…", labels, func(unit string) tea.Msg {
+		return jumpToUnitMsg{name: unit}
+	})
+}
+
+// renderFirewall shows each firewall's unit state and a summary of its
+// active ruleset.
+func renderFirewall(fws []systemd.Firewall, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	bad := lipgloss.NewStyle().Foreground(red)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Firewall") + "\n")
+	b.WriteString(dim.Render("A service that is up but unreachable is often filtered here. J: jump to a firewall unit") + "\n\n")
+	if len(fws) == 0 {
+		b.WriteString(dim.Render("No firewalld, ufw, nftables or iptables unit and no nftables ruleset found.") + "\n")
+		return b.String()
+	}
+
+	for _, fw := range fws {
+		line := heading.Render(fw.Name) + "  " + stateBadge(fw.ActiveState)
+		if fw.Unit != "" {
+			line += "  " + dim.Render(fw.Unit+" · "+fw.Enabled)
+		}
+		b.WriteString(line + "\n")
+		switch {
+		case fw.RulesErr != nil:
+			b.WriteString(bad.Render("  cannot read the ruleset: "+fw.RulesErr.Error()) + "\n")
+		case fw.ActiveState != "active" && fw.Unit != "":
+			b.WriteString(dim.Render("  not filtering") + "\n")
+		case len(fw.Summary) == 0:
+			b.WriteString(dim.Render("  empty ruleset") + "\n")
+		}
+		for _, s := range fw.Summary {
+			b.WriteString(lipgloss.NewStyle().MaxWidth(width).Render(s) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/i18n"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// globVerbs are the commands the palette applies to a unit name pattern.
+// systemctl expands the pattern itself, against the loaded units; unit
+// file commands like enable take no patterns.
+var globVerbs = map[string]string{
+	"start":   "Started",
+	"stop":    "Stopped",
+	"restart": "Restarted",
+	"reload":  "Reloaded",
+}
+
+// parseGlobCommand reads "restart worker@*" as a verb and a pattern.
+func parseGlobCommand(query string) (verb, pattern string, ok bool) {
+	fields := strings.Fields(query)
+	if len(fields) != 2 || globVerbs[fields[0]] == "" || !strings.ContainsAny(fields[1], "*?[") {
+		return "", "", false
+	}
+	return fields[0], fields[1], true
+}
+
+// matchingUnits are the loaded units the pattern expands to.
+func (m model) matchingUnits(pattern string) []string {
+	var names []string
+	for _, u := range m.allUnits {
+		if systemd.MatchesAny([]string{pattern}, u.Name) {
+			names = append(names, u.Name)
+		}
+	}
+	return names
+}
+
+// globCommandLabel offers a typed glob command in the palette, e.g.
+// "restart worker@*  (3 units)".
+func (m model) globCommandLabel(query string) (string, bool) {
+	verb, pattern, ok := parseGlobCommand(query)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s  (%d units)", verb, pattern, len(m.matchingUnits(pattern))), true
+}
+
+// globDialog previews the units a glob command applies to and runs it on
+// confirm.
+func (m model) globDialog(verb, pattern string) *dialog {
+	title := verb + " " + pattern
+	if systemd.Offline() {
+		return &dialog{title: title, lines: []string{i18n.T(offlineActions)}}
+	}
+	units := m.matchingUnits(pattern)
+	if len(units) == 0 {
+		return &dialog{title: title, lines: []string{"No loaded unit matches " + pattern + "."}}
+	}
+	lines := []string{fmt.Sprintf("The following %d unit(s) match and will be %s:", len(units), strings.ToLower(globVerbs[verb])), ""}
+	lines = append(lines, previewLines(units)...)
+	return &dialog{title: title, lines: lines, confirm: runGlob(verb, pattern, units)}
+}
+
+// globDoneMsg reports a command run on a pattern.
+type globDoneMsg struct {
+	verb, pattern string
+	units         []string // as previewed
+	err           error
+}
+
+func runGlob(verb, pattern string, units []string) tea.Cmd {
+	return func() tea.Msg {
+		return globDoneMsg{verb: verb, pattern: pattern, units: units, err: systemd.GlobAction(verb, pattern)}
+	}
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"vigilix/internal/config"
+)
+
+// glyphSet holds the emoji the interface draws. Emoji are two cells wide,
+// so their ASCII stand-ins are too, keeping every column where it was.
+type glyphSet struct {
+	ascii        bool
+	Silence, Dev string
+}
+
+var (
+	emojiGlyphs = glyphSet{Silence: "🔕", Dev: "🚀"}
+	asciiGlyphs = glyphSet{ascii: true, Silence: "zz", Dev: ">>"}
+)
+
+// glyphs is the active set; see setASCII.
+var glyphs = emojiGlyphs
+
+// builtinIcons pick a list icon by name. They come after the user's
+// "icons" rules; the first matching rule wins. See iconMatches.
+var builtinIcons = []config.Icon{
+	{Pattern: "docker", Emoji: "🐳", ASCII: "dk"},
+	{Pattern: "dockerd", Emoji: "🐳", ASCII: "dk"},
+	{Pattern: "mongo", Emoji: "🍃", ASCII: "mg"},
+	{Pattern: "mongod", Emoji: "🍃", ASCII: "mg"},
+	{Pattern: "mongodb", Emoji: "🍃", ASCII: "mg"},
+	{Pattern: "postgres", Emoji: "🐘", ASCII: "pg"},
+	{Pattern: "postgresql", Emoji: "🐘", ASCII: "pg"},
+	{Pattern: "psql", Emoji: "🐘", ASCII: "pg"},
+	{Pattern: "mysql", Emoji: "🐬", ASCII: "my"},
+	{Pattern: "mysqld", Emoji: "🐬", ASCII: "my"},
+	{Pattern: "mariadb", Emoji: "🐬", ASCII: "my"},
+	{Pattern: "redis", Emoji: "🔺", ASCII: "rd"},
+	{Pattern: "nginx", Emoji: "🌐", ASCII: "ng"},
+	{Pattern: "apache", Emoji: "🪶", ASCII: "ap"},
+	{Pattern: "apache2", Emoji: "🪶", ASCII: "ap"},
+	{Pattern: "httpd", Emoji: "🪶", ASCII: "ap"},
+	{Pattern: "ssh", Emoji: "🔒", ASCII: "sh"},
+	{Pattern: "sshd", Emoji: "🔒", ASCII: "sh"},
+	{Pattern: "node", Emoji: "🟢", ASCII: "nd"},
+	{Pattern: "nodejs", Emoji: "🟢", ASCII: "nd"},
+	{Pattern: "npm", Emoji: "🟢", ASCII: "nd"},
+	{Pattern: "python", Emoji: "🐍", ASCII: "py"},
+	{Pattern: "python3", Emoji: "🐍", ASCII: "py"},
+	{Pattern: "go", Emoji: "🐹", ASCII: "go"},
+	{Pattern: "golang", Emoji: "🐹", ASCII: "go"},
+}
+
+// iconRules are the user's rules followed by the built-in ones; see
+// setIcons.
+var iconRules = builtinIcons
+
+// setIcons puts the user's icon rules ahead of the built-in ones. It
+// reports the first rule with a malformed glob pattern, which never
+// matches.
+func setIcons(user []config.Icon) error {
+	var err error
+	for _, ic := range user {
+		if _, matchErr := filepath.Match(ic.Pattern, ""); matchErr != nil && err == nil {
+			err = fmt.Errorf("icon pattern %q: %w", ic.Pattern, matchErr)
+		}
+	}
+	iconRules = append(slices.Clip(user), builtinIcons...)
+	return err
+}
+
+// iconMatches reports whether an icon rule applies to a unit name. A
+// pattern with glob characters must match the whole name, e.g.
+// "redis@*.service". A plain pattern must equal one of the words of the
+// name, which are separated by "-", "_", ".", "@" and the like, so "go"
+// matches "go-api.service" but not "gogs.service" or "cargo.service".
+func iconMatches(pattern, name string) bool {
+	if strings.ContainsAny(pattern, "*?[") {
+		ok, _ := filepath.Match(strings.ToLower(pattern), name)
+		return ok
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return slices.Contains(words, strings.ToLower(pattern))
+}
+
+// unitIcon returns the list icon for a unit name, falling back to the icon
+// of its type. Icons are two cells wide so that names line up; narrower
+// ones are padded.
+func unitIcon(name string) string {
+	name = strings.ToLower(name)
+	for _, ic := range iconRules {
+		if !iconMatches(ic.Pattern, name) {
+			continue
+		}
+		if glyphs.ascii {
+			if ic.ASCII == "" {
+				break
+			}
+			return fit(ic.ASCII, 2)
+		}
+		return fit(ic.Emoji, 2)
+	}
+	return typeIcon(name)
+}
+
+// asciiFallback replaces the remaining single-cell symbols, box drawing and
+// block characters one for one, so a finished frame can be made plain ASCII
+// without disturbing its layout.
+var asciiFallback = strings.NewReplacer(
+	// Borders: rounded, normal and double
+	"─", "-", "│", "|", "╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	"═", "=", "║", "|", "╔", "+", "╗", "+", "╚", "+", "╝", "+",
+	"█", "#", "▌", "|", "░", ":",
+	"▁", "_", "▂", "_", "▃", "-", "▄", "-", "▅", "=", "▆", "=", "▇", "#",
+	// Symbols
+	"✓", "v", "✗", "x", "•", "*", "·", "-", "…", ".", "—", "-",
+	"❯", ">", "▸", ">", "→", ">", "←", "<", "↑", "^", "↓", "v",
+	"●", "*", "○", "o", "✎", "*", "⟳", "~", "⬆", "^", "⚠", "!",
+)
+
+// setASCII switches between the Unicode and the ASCII-only glyphs.
+func setASCII(on bool) {
+	glyphs = emojiGlyphs
+	if on {
+		glyphs = asciiGlyphs
+	}
+}
+
+// plain makes a rendered frame ASCII-only when ASCII mode is on.
+func plain(s string) string {
+	if !glyphs.ascii {
+		return s
+	}
+	return asciiFallback.Replace(s)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/config"
+	"vigilix/internal/i18n"
+	"vigilix/internal/rollout"
+	"vigilix/internal/supervise"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type groupDoneMsg struct {
+	group string
+	steps []rollout.Step
+	err   error
+}
+
+// groupDialog previews the order a group is restarted in and runs it on
+// confirm.
+func groupDialog(g config.Group, probes map[string]string) *dialog {
+	title := "Restart group " + g.Name
+	if systemd.Offline() {
+		return &dialog{title: title, lines: []string{i18n.T(offlineActions)}}
+	}
+	if len(g.Units) == 0 {
+		return &dialog{title: title, lines: []string{"The group lists no units."}}
+	}
+	lines := []string{"Each unit is restarted once the one before it is healthy:", ""}
+	for i, unit := range g.Units {
+		check := "active"
+		if probes[unit] != "" {
+			check = "active and " + probes[unit] + " answers"
+		}
+		lines = append(lines, fmt.Sprintf("  %d. %s  (%s, within %s)", i+1, unit, check, g.HealthTimeout()))
+	}
+	if len(g.Rollback) > 0 {
+		lines = append(lines, "", "On failure: run "+strings.Join(g.Rollback, " ")+" and restart the units done so far, last first.")
+	} else {
+		lines = append(lines, "", "On failure the remaining units are left alone.")
+	}
+	return &dialog{title: title, lines: lines, confirm: runGroup(g, probes)}
+}
+
+func runGroup(g config.Group, probes map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		steps, err := rollout.Run(supervise.Context(), g, probes)
+		return groupDoneMsg{group: g.Name, steps: steps, err: err}
+	}
+}
+
+// groupReportDialog lists each step of a group restart.
+func groupReportDialog(msg groupDoneMsg) *dialog {
+	ok := lipgloss.NewStyle().Foreground(green).Render("✓")
+	fail := lipgloss.NewStyle().Foreground(red).Render("✗")
+	var lines []string
+	for _, s := range msg.steps {
+		if s.Err != nil {
+			lines = append(lines, fmt.Sprintf("%s %s: %v", fail, s.Text, s.Err))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s %s", ok, s.Text))
+		}
+	}
+	title := "Restarted group " + msg.group
+	if msg.err != nil {
+		title = "Restart of group " + msg.group + " failed"
+	}
+	return &dialog{title: title, lines: lines}
+}
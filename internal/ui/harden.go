@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hardeningFile is the drop-in vigilix writes; applying again replaces it.
+const hardeningFile = "50-vigilix-hardening.conf"
+
+// hardeningOptions are the directives offered for a service, keyed by the
+// systemd-analyze security finding they address. Options that commonly
+// break services (JITs, containers, netlink users) start unchecked.
+var hardeningOptions = []struct {
+	finding   string // prefix of the finding name
+	directive string
+	risky     bool
+}{
+	{"NoNewPrivileges=", "NoNewPrivileges=yes", false},
+	{"PrivateTmp=", "PrivateTmp=yes", false},
+	{"ProtectSystem=", "ProtectSystem=full", false},
+	{"ProtectHome=", "ProtectHome=read-only", false},
+	{"PrivateDevices=", "PrivateDevices=yes", false},
+	{"ProtectKernelTunables=", "ProtectKernelTunables=yes", false},
+	{"ProtectKernelModules=", "ProtectKernelModules=yes", false},
+	{"ProtectKernelLogs=", "ProtectKernelLogs=yes", false},
+	{"ProtectControlGroups=", "ProtectControlGroups=yes", false},
+	{"ProtectClock=", "ProtectClock=yes", false},
+	{"ProtectHostname=", "ProtectHostname=yes", false},
+	{"RestrictSUIDSGID=", "RestrictSUIDSGID=yes", false},
+	{"RestrictRealtime=", "RestrictRealtime=yes", false},
+	{"LockPersonality=", "LockPersonality=yes", false},
+	{"SystemCallArchitectures=", "SystemCallArchitectures=native", false},
+	{"ProtectProc=", "ProtectProc=invisible", true},
+	{"RestrictNamespaces=", "RestrictNamespaces=yes", true},
+	{"RestrictAddressFamilies=", "RestrictAddressFamilies=AF_UNIX AF_INET AF_INET6", true},
+	{"SystemCallFilter=", "SystemCallFilter=@system-service", true},
+	{"MemoryDenyWriteExecute=", "MemoryDenyWriteExecute=yes", true},
+}
+
+type hardenMsg struct {
+	unit     string
+	security *systemd.Security
+	err      error
+}
+
+func fetchHardening(unit string) tea.Cmd {
+	return func() tea.Msg {
+		s, err := systemd.AnalyzeSecurity(unit)
+		return hardenMsg{unit: unit, security: s, err: err}
+	}
+}
+
+// hardeningChecklist offers the directives whose findings are still open,
+// with the exposure each one would remove.
+func hardeningChecklist(unit string, s *systemd.Security) *checklist {
+	var items []checkItem
+	for _, opt := range hardeningOptions {
+		var gain float64
+		for _, f := range s.Findings {
+			if strings.HasPrefix(f.Name, opt.finding) && f.Applicable && !f.Set {
+				gain += f.Exposure
+			}
+		}
+		if gain == 0 {
+			continue
+		}
+		note := fmt.Sprintf("-%.1f", gain)
+		if opt.risky {
+			note += " · may break some services"
+		}
+		items = append(items, checkItem{label: opt.directive, value: opt.directive, note: note, checked: !opt.risky})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	before := s.Exposure
+	return &checklist{
+		title: fmt.Sprintf("Harden %s (exposure %.1f %s)", unit, s.Exposure, s.Level),
+		items: items,
+		submit: func(directives []string) tea.Cmd {
+			return applyHardening(unit, directives, before)
+		},
+	}
+}
+
+type hardenedMsg struct {
+	unit       string
+	path       string
+	before     float64
+	after      *systemd.Security
+	reloadErr  error // the drop-in was written but the manager did not reload
+	restartErr error
+	err        error
+}
+
+// applyHardening writes the drop-in, restarts the service and measures the
+// exposure again.
+func applyHardening(unit string, directives []string, before float64) tea.Cmd {
+	return func() tea.Msg {
+		content := "# Written by vigilix. Remove this file and run\n# `systemctl daemon-reload` to undo.\n[Service]\n" +
+			strings.Join(directives, "\n") + "\n"
+		path, err := systemd.WriteDropIn(unit, hardeningFile, content)
+		if err != nil && path != "" {
+			return hardenedMsg{unit: unit, path: path, before: before, reloadErr: err}
+		}
+		if err != nil {
+			return hardenedMsg{unit: unit, err: err}
+		}
+		if err := recordVersion(unit, "hardening drop-in written"); err != nil {
+			slog.Warn("recording a unit version", "unit", unit, "err", err)
+		}
+		msg := hardenedMsg{unit: unit, path: path, before: before}
+		msg.restartErr = systemd.RestartUnit(unit)
+		msg.after, msg.err = systemd.AnalyzeSecurity(unit)
+		return msg
+	}
+}
+
+// hardenedDialog reports the before/after scores. If the manager did not
+// reload or the service did not come back, confirming removes the drop-in
+// again.
+func hardenedDialog(msg hardenedMsg) *dialog {
+	lines := []string{"Wrote " + msg.path}
+	unit, path := msg.unit, msg.path
+	if msg.reloadErr != nil {
+		lines = append(lines, "", "✗ daemon-reload failed: "+msg.reloadErr.Error(),
+			unit+" was not restarted. Remove the drop-in again?")
+		return &dialog{title: "Hardening not applied to " + unit, lines: lines, confirm: func() tea.Msg {
+			removeErr := systemd.RemoveDropIn(path)
+			if err := recordVersion(unit, "hardening drop-in removed"); err != nil {
+				slog.Warn("recording a unit version", "unit", unit, "err", err)
+			}
+			return actionResultMsg{err: removeErr, action: "Removed the hardening drop-in of", unit: unit}
+		}}
+	}
+	if msg.after != nil {
+		lines = append(lines, fmt.Sprintf("Exposure: %.1f → %.1f %s", msg.before, msg.after.Exposure, msg.after.Level))
+	}
+	if msg.restartErr == nil {
+		lines = append(lines, "", "✓ "+msg.unit+" restarted with the new settings")
+		return &dialog{title: "Hardening applied", lines: lines}
+	}
+	lines = append(lines, "", "✗ restart failed: "+msg.restartErr.Error(),
+		"Remove the drop-in and restart with the previous settings?")
+	return &dialog{title: "Hardening broke " + unit, lines: lines, confirm: func() tea.Msg {
+		if err := systemd.RemoveDropIn(path); err != nil {
+			return actionResultMsg{err: err, action: "Restored", unit: unit}
+		}
+		if err := recordVersion(unit, "hardening drop-in removed"); err != nil {
+			slog.Warn("recording a unit version", "unit", unit, "err", err)
+		}
+		return actionResultMsg{err: systemd.RestartUnit(unit), action: "Restored", unit: unit}
+	}}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"vigilix/internal/i18n"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type systemStateMsg struct {
+	state string
+	err   error
+}
+
+func fetchSystemState() tea.Msg {
+	state, err := systemd.SystemState()
+	return systemStateMsg{state: state, err: err}
+}
+
+// systemStateColor picks the banner color for a manager state.
+func systemStateColor(state string) lipgloss.Color {
+	switch state {
+	case "running":
+		return green
+	case "degraded", "maintenance":
+		return red
+	case "starting", "initializing", "stopping":
+		return yellow
+	}
+	return comment
+}
+
+// healthBanner is the dashboard line describing the overall system state.
+func (m model) healthBanner() string {
+	if m.systemState == "" {
+		return ""
+	}
+	style := lipgloss.NewStyle().Bold(true).Foreground(systemStateColor(m.systemState))
+	text := i18n.Tf("System %s", m.systemState)
+	if m.systemState == "degraded" {
+		text += " · " + i18n.T("press ! to list failed units")
+	}
+	return style.Render(stateMark(healthMarkState(m.systemState)) + " " + text)
+}
+
+// healthMarkState maps a manager state onto the unit state whose mark
+// matches it, so the banner uses the same shapes as the list.
+func healthMarkState(state string) string {
+	switch state {
+	case "running":
+		return "active"
+	case "degraded", "maintenance":
+		return "failed"
+	case "starting", "initializing":
+		return "activating"
+	case "stopping":
+		return "deactivating"
+	}
+	return ""
+}
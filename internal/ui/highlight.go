@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightUnitFile renders `systemctl cat` output with INI highlighting.
+// systemctl prefixes every file with a "# /path" comment; those boundaries
+// are drawn as rules so drop-ins stand out from the main unit file.
+func highlightUnitFile(content string, width int) string {
+	section := lipgloss.NewStyle().Bold(true).Foreground(pink)
+	keyStyle := lipgloss.NewStyle().Foreground(cyan)
+	valueStyle := lipgloss.NewStyle().Foreground(foreground)
+	commentStyle := lipgloss.NewStyle().Foreground(comment).Italic(true)
+	boundary := lipgloss.NewStyle().Bold(true).Foreground(orange)
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	files := 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case isFileBoundary(lines, i):
+			path := strings.TrimPrefix(trimmed, "# ")
+			label := "unit file"
+			if files > 0 {
+				label = "drop-in"
+				out = append(out, "")
+			}
+			files++
+			out = append(out, boundary.Render(rule("── "+label+": "+path+" ", width)))
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			out = append(out, section.Render(line))
+		case strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";"):
+			out = append(out, commentStyle.Render(line))
+		default:
+			k, v, ok := strings.Cut(line, "=")
+			if !ok {
+				out = append(out, valueStyle.Render(line))
+				continue
+			}
+			out = append(out, keyStyle.Render(k)+commentStyle.Render("=")+valueStyle.Render(v))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// highlightConfig renders the Config view: the Quadlet source a unit was
+// generated from, if any, in a section of its own, then the unit file.
+func highlightConfig(content, quadletPath, quadlet string, width int) string {
+	unit := highlightUnitFile(content, width)
+	if quadletPath == "" {
+		return unit
+	}
+	boundary := lipgloss.NewStyle().Bold(true).Foreground(orange)
+	hint := lipgloss.NewStyle().Foreground(comment).Render("E edits it and regenerates the service")
+	return boundary.Render(rule("── Quadlet source: "+quadletPath+" ", width)) + "\n" + hint + "\n" +
+		highlightUnitFile(quadlet, width) + "\n\n" + unit
+}
+
+// isFileBoundary reports whether line i is systemctl's "# /path" header,
+// which starts the output or follows a blank line.
+func isFileBoundary(lines []string, i int) bool {
+	if !strings.HasPrefix(lines[i], "# /") {
+		return false
+	}
+	return i == 0 || strings.TrimSpace(lines[i-1]) == ""
+}
+
+// unitFilePaths returns the files `systemctl cat` output is made of: the
+// unit file, then its drop-ins.
+func unitFilePaths(content string) []string {
+	lines := strings.Split(content, "\n")
+	var paths []string
+	for i := range lines {
+		if isFileBoundary(lines, i) {
+			paths = append(paths, strings.TrimPrefix(strings.TrimSpace(lines[i]), "# "))
+		}
+	}
+	return paths
+}
+
+// rule pads s with box-drawing characters up to width.
+func rule(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		s += strings.Repeat("─", pad)
+	}
+	return s
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/history"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// historyEnabled mirrors the history setting for commands that run outside
+// the model, like fetchDetails.
+var historyEnabled bool
+
+// timelineWindow is how far back the Details pane looks in the history.
+const timelineWindow = 7 * 24 * time.Hour
+
+// timelineRows caps the timeline in the Details pane.
+const timelineRows = 15
+
+// recordStates queues a history event for every unit whose active state
+// changed between two refreshes.
+func (m *model) recordStates(prev, next []systemd.Unit, now time.Time) {
+	if !historyEnabled || prev == nil {
+		return
+	}
+	was := make(map[string]string, len(prev))
+	for _, u := range prev {
+		was[u.Name] = u.ActiveState
+	}
+	for _, u := range next {
+		if from, ok := was[u.Name]; ok && from != u.ActiveState {
+			m.historyBatch.Events = append(m.historyBatch.Events, history.Event{Time: now, Unit: u.Name, From: from, To: u.ActiveState})
+		}
+	}
+}
+
+// recordAudit queues a history entry for an action taken on a unit.
+func (m *model) recordAudit(unit, action string, err error) {
+	if !historyEnabled || unit == "" {
+		return
+	}
+	a := history.Audit{Time: time.Now(), Unit: unit, Action: action}
+	if err != nil {
+		a.Error = err.Error()
+	}
+	m.historyBatch.Audits = append(m.historyBatch.Audits, a)
+}
+
+type historyFlushedMsg struct{ err error }
+
+// flushHistory writes the queued records, a resource sample of every
+// accounted unit and the availability history, and applies the retention
+// policy when one is given. The database is only held open while writing so
+// `vigilix history` can read it meanwhile.
+func flushHistory(batch history.Batch, uptime config.Uptime, retention *config.Retention) tea.Cmd {
+	return func() tea.Msg {
+		return historyFlushedMsg{err: writeHistory(batch, uptime, retention)}
+	}
+}
+
+func writeHistory(batch history.Batch, uptime config.Uptime, retention *config.Retention) error {
+	now := time.Now()
+	// Samples are best effort: without accounting there are none.
+	if usage, err := systemd.UnitUsage(); err == nil {
+		for _, u := range usage {
+			batch.Samples = append(batch.Samples, history.Sample{
+				Time: now, Unit: u.Unit, CPU: u.CPU, Memory: u.Memory,
+				IORead: u.IORead, IOWrite: u.IOWrite, IPIn: u.IPIn, IPOut: u.IPOut,
+			})
+		}
+	}
+	store, err := history.Open(false)
+	if err != nil {
+		return err
+	}
+	if err := store.Write(batch); err != nil {
+		store.Close()
+		return err
+	}
+	if err := store.SaveUptime(uptime); err != nil {
+		store.Close()
+		return err
+	}
+	if retention != nil {
+		if _, err := store.Prune(*retention, now, false); err != nil {
+			store.Close()
+			return err
+		}
+	}
+	return store.Close()
+}
+
+// takeHistory hands over the queued records and the availability history
+// for writing, with the retention policy on the first write of a session.
+// Spans are never changed in place, so a shallow copy of the map is safe to
+// use from another goroutine.
+func (m *model) takeHistory() (history.Batch, config.Uptime, *config.Retention) {
+	batch := m.historyBatch
+	m.historyBatch = history.Batch{}
+	var retention *config.Retention
+	if !m.historyPruned {
+		m.historyPruned = true
+		retention = &m.cfg.Retention
+	}
+	return batch, maps.Clone(m.uptime), retention
+}
+
+// loadUptime reads the availability history from wherever it is kept.
+func loadUptime(useHistory bool) (config.Uptime, error) {
+	if !useHistory {
+		return config.LoadUptime()
+	}
+	store, err := history.Open(false)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	return store.LoadUptime()
+}
+
+// timelineEntry is a state change or an action in the Details timeline.
+type timelineEntry struct {
+	at   time.Time
+	text string
+	bad  bool
+}
+
+// fetchTimeline reads a unit's recent state changes and actions.
+func fetchTimeline(unit string) ([]timelineEntry, error) {
+	store, err := history.Open(true)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	since := time.Now().Add(-timelineWindow)
+	events, err := store.Events(unit, since)
+	if err != nil {
+		return nil, err
+	}
+	audits, err := store.Audits(unit, since)
+	if err != nil {
+		return nil, err
+	}
+	var entries []timelineEntry
+	for _, e := range events {
+		entries = append(entries, timelineEntry{at: e.Time, text: e.From + " → " + e.To, bad: e.To == "failed"})
+	}
+	for _, a := range audits {
+		text := a.Action
+		if a.Error != "" {
+			text += ": " + a.Error
+		}
+		entries = append(entries, timelineEntry{at: a.Time, text: text, bad: a.Error != ""})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].at.After(entries[j].at) })
+	return entries[:min(len(entries), timelineRows)], nil
+}
+
+// renderTimeline lists the latest history entries, newest first.
+func renderTimeline(entries []timelineEntry, err error) string {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	if err != nil {
+		return dim.Render("History unavailable: "+err.Error()) + "\n"
+	}
+	if len(entries) == 0 {
+		return dim.Render("Nothing recorded in the last 7 days.") + "\n"
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		text := e.text
+		if e.bad {
+			text = lipgloss.NewStyle().Foreground(red).Render(text)
+		}
+		fmt.Fprintf(&b, "%s  %s\n", dim.Render(e.at.Format("Jan 02 15:04:05")), text)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blurredSlowdown stretches the refresh and clock intervals while the
+// terminal is in the background: nobody is looking, so there is no point
+// in waking up as often.
+const blurredSlowdown = 5
+
+// spinnerShown reports whether the spinner is on screen. Its ticks re-render
+// the whole UI ten times a second, so they are paused whenever it is not.
+func (m model) spinnerShown() bool {
+	if m.blurred {
+		return false
+	}
+	return m.busy > 0 || (m.streamingUnit != "" && m.viewMode == ModeLogs)
+}
+
+// refreshInterval is how long until the next background refresh.
+func (m model) refreshInterval() time.Duration {
+	if m.blurred {
+		return m.cfg.RefreshInterval() * blurredSlowdown
+	}
+	return m.cfg.RefreshInterval()
+}
+
+// clockInterval is how often the status bar clock ticks.
+func (m model) clockInterval() time.Duration {
+	if m.blurred {
+		return time.Second * blurredSlowdown
+	}
+	return time.Second
+}
+
+// setFocus follows the terminal's focus reports. While blurred, the probe,
+// certificate and clock checks and the Top pane stop polling; coming back
+// runs the checks held back and refreshes the units at once rather than at
+// the next, stretched, tick.
+func (m *model) setFocus(focused bool) tea.Cmd {
+	m.blurred = !focused
+	if !focused {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, tick := range m.pausedTicks {
+		cmds = append(cmds, func() tea.Msg { return tick })
+	}
+	m.pausedTicks = nil
+	if !systemd.Offline() {
+		m.busy++
+		cmds = append(cmds, fetchUnits, fetchSystemState, fetchJobs)
+		if m.viewMode == ModeTop {
+			cmds = append(cmds, fetchTop)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// resumeSpinner restarts the spinner when it has to be shown again after
+// its ticks were dropped.
+func resumeSpinner(next tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m, ok := next.(model)
+	if !ok || !m.spinnerPaused || !m.spinnerShown() {
+		return next, cmd
+	}
+	m.spinnerPaused = false
+	return m, tea.Batch(cmd, m.spinner.Tick)
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"vigilix/internal/plugin"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inlineHeight is how many rows inline mode takes, status line included.
+const inlineHeight = 15
+
+// inlineBlocked are the keys that open a content view or move the focus to
+// it, neither of which inline mode shows.
+var inlineBlocked = []key.Binding{
+	keys.Enter, keys.Tab, keys.Config, keys.Versions, keys.Details, keys.Explain, keys.LogStats,
+	keys.Scheduled, keys.Compare, keys.Bus, keys.Jobs, keys.Top, keys.Containers, keys.Firewall,
+	keys.Drift, keys.Mounts, keys.Messages,
+}
+
+// inline handles a message in inline mode, which draws in the normal screen
+// below the prompt, e.g. in a tmux pane, and shows just the unit list. Keys
+// and palette commands that would open a content view are ignored, so the
+// list keeps the focus.
+func (m model) inline(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.prompt == nil && m.finder == nil && m.dialog == nil && m.checklist == nil &&
+			!m.list.SettingFilter() && key.Matches(msg, inlineBlocked...) {
+			return m, nil
+		}
+	case paletteKeyMsg:
+		if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(msg.key)}, inlineBlocked...) {
+			return m, nil
+		}
+	case openBootReportMsg, dbStatsMsg:
+		return m, nil
+	case runPluginMsg:
+		if msg.plugin.Kind == plugin.KindPanel {
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = min(msg.Height, inlineHeight)
+		m.help.Width = msg.Width
+		m.list.SetSize(m.width, m.height-1)
+		return m, nil
+	}
+
+	next, cmd := m.handle(msg)
+	if nm, ok := next.(model); ok {
+		nm.viewMode = ModeList
+		nm.activePane = PaneList
+		next = nm
+	}
+	return next, cmd
+}
+
+// inlineView is the unit list above a one-line status bar.
+func (m model) inlineView() string {
+	screen := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Height(m.height-1).MaxHeight(m.height-1).Render(m.list.View()),
+		m.statusBarView(),
+	)
+	if m.dialog != nil {
+		screen = overlayCenter(screen, m.dialog.View(m.overlayWidth()), m.width, m.height)
+	}
+	if m.prompt != nil {
+		screen = overlayCenter(screen, m.prompt.View(m.overlayWidth()), m.width, m.height)
+	}
+	if m.checklist != nil {
+		screen = overlayCenter(screen, m.checklist.View(m.overlayWidth()), m.width, m.height)
+	}
+	if m.finder != nil {
+		screen = overlayCenter(screen, m.finder.View(m.width-4), m.width, m.height)
+	}
+	return screen
+}
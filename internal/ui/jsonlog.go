@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jsonLabelWidth bounds the JSON lines listed in the picker.
+const jsonLabelWidth = 120
+
+// jsonPayload returns the JSON object a log line carries after its journal
+// or file prefix, if any.
+func jsonPayload(text string) (map[string]any, string, bool) {
+	i := strings.IndexByte(text, '{')
+	if i < 0 {
+		return nil, "", false
+	}
+	var obj map[string]any
+	if json.Unmarshal([]byte(text[i:]), &obj) != nil {
+		return nil, "", false
+	}
+	return obj, text[i:], true
+}
+
+// jsonField looks up a field by its dotted path, e.g. http.status, and
+// formats its value as it would be typed in a filter.
+func jsonField(obj map[string]any, path string) (string, bool) {
+	var v any = obj
+	for _, name := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if v, ok = m[name]; !ok {
+			return "", false
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "null", true
+	case map[string]any, []any:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// fieldMatch is one term of a log filter: the line's JSON field at path
+// must equal value, ignoring case.
+type fieldMatch struct {
+	path, value string
+}
+
+// parseLogFilter reads space-separated field=value terms, all of which must
+// match.
+func parseLogFilter(s string) ([]fieldMatch, error) {
+	var filter []fieldMatch
+	for _, term := range strings.Fields(s) {
+		path, value, ok := strings.Cut(term, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("expected field=value, got %q", term)
+		}
+		filter = append(filter, fieldMatch{path: path, value: value})
+	}
+	return filter, nil
+}
+
+func formatLogFilter(filter []fieldMatch) string {
+	terms := make([]string, len(filter))
+	for i, f := range filter {
+		terms[i] = f.path + "=" + f.value
+	}
+	return strings.Join(terms, " ")
+}
+
+// matchesFilter reports whether a line is JSON with every field of the
+// filter set to its value. Lines that are not JSON never match.
+func matchesFilter(l systemd.LogLine, filter []fieldMatch) bool {
+	obj, _, ok := jsonPayload(l.Text)
+	if !ok {
+		return false
+	}
+	for _, f := range filter {
+		if v, ok := jsonField(obj, f.path); !ok || !strings.EqualFold(v, f.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleLogs is the log buffer with the field filter applied. The view is
+// rebuilt on every streamed line, so each text is only decoded once per
+// filter; logMatches remembers the verdicts.
+func (m model) visibleLogs() []systemd.LogLine {
+	if len(m.logFilter) == 0 {
+		return m.logLines
+	}
+	// Forget lines long gone from the buffer.
+	if len(m.logMatches) > 2*len(m.logLines)+maxLogLines {
+		clear(m.logMatches)
+	}
+	var lines []systemd.LogLine
+	for _, l := range m.logLines {
+		ok, seen := m.logMatches[l.Text]
+		if !seen {
+			ok = matchesFilter(l, m.logFilter)
+			m.logMatches[l.Text] = ok
+		}
+		if ok {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// logFilterMsg sets the field filter of the log view.
+type logFilterMsg struct {
+	filter []fieldMatch
+	err    error
+}
+
+func logFilterPrompt(current []fieldMatch) *prompt {
+	return newPrompt(
+		"Filter JSON logs",
+		"field=value … · e.g. level=error request_id=abc12 · empty shows every line",
+		formatLogFilter(current),
+		func(value string) tea.Cmd {
+			return func() tea.Msg {
+				filter, err := parseLogFilter(value)
+				return logFilterMsg{filter: filter, err: err}
+			}
+		},
+	)
+}
+
+// jsonLineMsg asks to show a JSON log line pretty-printed.
+type jsonLineMsg struct {
+	text string
+}
+
+// jsonLinePicker lists the JSON lines of the log view, newest first.
+func jsonLinePicker(lines []systemd.LogLine) *finder {
+	picks := make(map[string]string)
+	var labels []string
+	for i := len(lines) - 1; i >= 0; i-- {
+		_, payload, ok := jsonPayload(lines[i].Text)
+		if !ok {
+			continue
+		}
+		label := truncate(lines[i].Text, jsonLabelWidth)
+		if _, dup := picks[label]; !dup {
+			picks[label] = payload
+			labels = append(labels, label)
+		}
+	}
+	return newFinder("JSON log line…", labels, func(label string) tea.Msg {
+		return jsonLineMsg{text: picks[label]}
+	})
+}
+
+// jsonLineDialog shows a JSON payload indented, cut to fit the screen.
+func jsonLineDialog(payload string, maxLines int) *dialog {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(payload), "", "  "); err != nil {
+		return &dialog{title: "JSON log line", lines: []string{err.Error()}}
+	}
+	lines := strings.Split(b.String(), "\n")
+	if maxLines > 1 && len(lines) > maxLines {
+		more := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1], fmt.Sprintf("… %d more lines", more))
+	}
+	return &dialog{title: "JSON log line", lines: lines}
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// kioskPages are the screens the kiosk cycles through.
+var kioskPages = []string{"System", "Failed units", "Top units"}
+
+// kiosk is a read-only dashboard for a wall display. It takes no actions;
+// the arrow keys only flip pages early.
+type kiosk struct {
+	cfg           config.Config
+	rotate        time.Duration
+	width, height int
+	page          int
+	now           time.Time
+
+	stats statsMsg
+	state string
+	units []systemd.Unit
+	top   topSample
+	res   kioskResources
+	err   error
+}
+
+// kioskResources is the host's overall CPU, memory and load.
+type kioskResources struct {
+	cpu     float64
+	memUsed uint64
+	memAll  uint64
+	load    [3]float64
+}
+
+type (
+	kioskPageMsg      struct{}
+	kioskRefreshMsg   struct{}
+	kioskResourcesMsg kioskResources
+)
+
+// NewKiosk returns the wall display dashboard, switching pages every rotate.
+func NewKiosk(cfg config.Config, rotate time.Duration) tea.Model {
+	setASCII(cfg.ASCII)
+	themeName := cfg.Theme
+	if cfg.ASCII {
+		themeName = "contrast"
+	}
+	setTheme(themeName)
+	return kiosk{cfg: cfg, rotate: rotate, now: time.Now()}
+}
+
+func (k kiosk) Init() tea.Cmd {
+	return tea.Batch(fetchStats, k.refresh(), kioskPageTick(k.rotate))
+}
+
+func kioskPageTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return kioskPageMsg{} })
+}
+
+// refresh polls everything shown, whichever page is up, so switching pages
+// never shows stale data.
+func (k kiosk) refresh() tea.Cmd {
+	return tea.Batch(fetchUnits, fetchSystemState, fetchTop, fetchKioskResources,
+		tea.Tick(k.cfg.RefreshInterval(), func(time.Time) tea.Msg { return kioskRefreshMsg{} }))
+}
+
+func fetchKioskResources() tea.Msg {
+	var r kioskResources
+	// Percent blocks for the interval to measure it.
+	if p, err := cpu.Percent(time.Second, false); err == nil && len(p) > 0 {
+		r.cpu = p[0]
+	}
+	if v, err := mem.VirtualMemory(); err == nil {
+		r.memUsed, r.memAll = v.Used, v.Total
+	}
+	if l, err := load.Avg(); err == nil {
+		r.load = [3]float64{l.Load1, l.Load5, l.Load15}
+	}
+	return kioskResourcesMsg(r)
+}
+
+func (k kiosk) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return k, tea.Quit
+		case "right", "l", " ":
+			k.page = (k.page + 1) % len(kioskPages)
+		case "left", "h":
+			k.page = (k.page + len(kioskPages) - 1) % len(kioskPages)
+		}
+	case tea.WindowSizeMsg:
+		k.width, k.height = msg.Width, msg.Height
+	case kioskPageMsg:
+		k.page = (k.page + 1) % len(kioskPages)
+		k.now = time.Now()
+		return k, kioskPageTick(k.rotate)
+	case kioskRefreshMsg:
+		k.now = time.Now()
+		return k, k.refresh()
+	case statsMsg:
+		k.stats = msg
+	case systemStateMsg:
+		k.state = msg.state
+	case []systemd.Unit:
+		k.units, k.err = msg, nil
+	case errMsg:
+		k.err = msg
+	case topMsg:
+		if msg.err == nil {
+			k.top.add(msg.usage, msg.at)
+		}
+	case kioskResourcesMsg:
+		k.res = kioskResources(msg)
+	}
+	return k, nil
+}
+
+func (k kiosk) View() string {
+	if k.width == 0 {
+		return ""
+	}
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var dots []string
+	for i := range kioskPages {
+		if i == k.page {
+			dots = append(dots, lipgloss.NewStyle().Foreground(purple).Render("●"))
+		} else {
+			dots = append(dots, dim.Render("○"))
+		}
+	}
+	title := heading.Render(k.stats.hostname + " · " + kioskPages[k.page])
+	right := strings.Join(dots, " ") + "  " + k.now.Format("15:04")
+	gap := max(k.width-lipgloss.Width(title)-lipgloss.Width(right)-2, 1)
+	header := " " + title + strings.Repeat(" ", gap) + right
+
+	var body string
+	switch k.page {
+	case 0:
+		body = k.systemPage()
+	case 1:
+		body = k.failedPage()
+	default:
+		body = renderTop(k.top, k.width-4)
+	}
+	if k.err != nil {
+		body = lipgloss.NewStyle().Foreground(red).Render(k.err.Error()) + "\n\n" + body
+	}
+
+	screen := lipgloss.JoinVertical(lipgloss.Left, header, "",
+		lipgloss.NewStyle().Padding(0, 2).MaxHeight(k.height-2).Render(body))
+	return plain(screen)
+}
+
+// systemPage shows the host's health in large, glanceable rows.
+func (k kiosk) systemPage() string {
+	label := lipgloss.NewStyle().Foreground(comment).Width(16)
+	var b strings.Builder
+	row := func(name, value string) {
+		b.WriteString(label.Render(name) + " " + value + "\n\n")
+	}
+
+	if k.state != "" {
+		style := lipgloss.NewStyle().Bold(true).Foreground(systemStateColor(k.state))
+		row("State", style.Render(stateMark(healthMarkState(k.state))+" "+k.state))
+	}
+	failed := 0
+	for _, u := range k.units {
+		if u.ActiveState == "failed" {
+			failed++
+		}
+	}
+	failedText := "none failed"
+	if failed > 0 {
+		failedText = lipgloss.NewStyle().Foreground(red).Bold(true).Render(fmt.Sprintf("%d failed", failed))
+	}
+	row("Units", fmt.Sprintf("%d · %s", len(k.units), failedText))
+
+	barWidth := min(max(k.width/3, 10), 50)
+	row("CPU", kioskBar(k.res.cpu, barWidth)+fmt.Sprintf(" %3.0f%%", k.res.cpu))
+	if k.res.memAll > 0 {
+		pct := float64(k.res.memUsed) / float64(k.res.memAll) * 100
+		row("Memory", kioskBar(pct, barWidth)+fmt.Sprintf(" %3.0f%% of %s", pct, humanBytes(k.res.memAll)))
+	}
+	row("Load", fmt.Sprintf("%.2f  %.2f  %.2f", k.res.load[0], k.res.load[1], k.res.load[2]))
+	if k.stats.uptime > 0 {
+		row("Uptime", humanDuration(time.Duration(k.stats.uptime)*time.Second))
+	}
+	if k.stats.os != "" {
+		row("System", k.stats.os+" · "+k.stats.kernel+" · "+systemdVersionLabel())
+	}
+	return b.String()
+}
+
+func kioskBar(percent float64, width int) string {
+	filled := min(int(percent/100*float64(width)), width)
+	return lipgloss.NewStyle().Foreground(usageColor(percent)).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(comment).Render(strings.Repeat("░", width-filled))
+}
+
+// failedPage lists the failed units by name.
+func (k kiosk) failedPage() string {
+	var failed []systemd.Unit
+	for _, u := range k.units {
+		if u.ActiveState == "failed" {
+			failed = append(failed, u)
+		}
+	}
+	if len(failed) == 0 {
+		return lipgloss.NewStyle().Foreground(green).Bold(true).Render(stateMark("active") + " No failed units")
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Name < failed[j].Name })
+
+	bad := lipgloss.NewStyle().Foreground(red).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	var b strings.Builder
+	for _, u := range failed {
+		b.WriteString(bad.Render(stateMark("failed")+" "+u.Name) + "  " + dim.Render(u.Description) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logCacheSize is how many units' log buffers are kept besides the one
+// showing.
+const logCacheSize = 8
+
+// resumeSeparator marks where a kept buffer left off when its unit's logs
+// are shown again.
+const resumeSeparator = "── lines since you left ──"
+
+// skippedSeparator stands in for the lines logged while away beyond the
+// last maxLogLines, which are not loaded.
+const skippedSeparator = "── %d lines skipped ──"
+
+// logBuffer is the log view of a unit that is no longer streaming.
+type logBuffer struct {
+	unit        string
+	lines       []systemd.LogLine
+	olderLoaded int
+}
+
+// logCache keeps the log buffers of the units viewed last, least recently
+// viewed first, so going back to a unit shows what was there before plus
+// what was logged since.
+type logCache struct {
+	buffers []logBuffer
+}
+
+func (c *logCache) put(b logBuffer) {
+	c.take(b.unit)
+	c.buffers = append(c.buffers, b)
+	if len(c.buffers) > logCacheSize {
+		c.buffers = c.buffers[len(c.buffers)-logCacheSize:]
+	}
+}
+
+// take removes the buffer of unit from the cache and returns it.
+func (c *logCache) take(unit string) (logBuffer, bool) {
+	for i, b := range c.buffers {
+		if b.unit == unit {
+			c.buffers = append(c.buffers[:i:i], c.buffers[i+1:]...)
+			return b, true
+		}
+	}
+	return logBuffer{}, false
+}
+
+// logCatchUpMsg carries what a unit logged while its buffer was kept.
+type logCatchUpMsg struct {
+	unit    string
+	lines   []systemd.LogLine
+	skipped int
+	err     error
+}
+
+// catchUpLogs reads what unit logged after the buffer's last cursor in one
+// go, rather than replaying every entry through the stream, and keeps at
+// most a buffer's worth of it.
+func catchUpLogs(unit, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		lines, skipped, err := systemd.LogsAfter(unit, cursor, maxLogLines)
+		return logCatchUpMsg{unit: unit, lines: lines, skipped: skipped, err: err}
+	}
+}
+
+// stashLogs moves the buffer of the streaming unit to the cache. Only
+// buffers with a journal position are kept: without one, the stream could
+// not resume where they end.
+func (m *model) stashLogs() {
+	if m.streamingUnit == "" || m.lastCursor() == "" {
+		return
+	}
+	m.logCache.put(logBuffer{unit: m.streamingUnit, lines: m.logLines, olderLoaded: m.olderLoaded})
+}
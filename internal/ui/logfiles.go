@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sync"
+	"vigilix/internal/supervise"
+	"vigilix/internal/systemd"
+)
+
+// logFiles returns the log files configured for a unit. Config keys may be
+// bare service names like in the CLI.
+func (m model) logFiles(unit string) []string {
+	var files []string
+	for name, paths := range m.cfg.LogFiles {
+		if systemd.UnitName(name) == unit {
+			files = append(files, paths...)
+		}
+	}
+	return files
+}
+
+// tailLogFiles follows the configured files and those the unit's output is
+// redirected to, sending their lines to out prefixed with the file name so
+// they can be told apart from journal lines.
+func tailLogFiles(ctx context.Context, unit string, configured []string, out chan<- systemd.LogLine) {
+	files := slices.Clone(configured)
+	if detected, err := systemd.OutputFiles(unit); err == nil {
+		for _, f := range detected {
+			if !slices.Contains(files, f) {
+				files = append(files, f)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, path := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lines := make(chan string)
+			done := make(chan error, 1)
+			supervise.Go("log file tail", func() { done <- systemd.TailFile(ctx, path, lines) })
+			prefix := "[" + filepath.Base(path) + "] "
+			for {
+				select {
+				case line := <-lines:
+					select {
+					case out <- systemd.LogLine{Text: prefix + line}:
+					case <-ctx.Done():
+						return
+					}
+				case err := <-done:
+					if err != nil {
+						select {
+						case out <- systemd.LogLine{Text: "── cannot follow " + path + ": " + err.Error() + " ──"}:
+						case <-ctx.Done():
+						}
+					}
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type logStatsMsg struct {
+	name   string
+	window systemd.StatsWindow
+	counts [7]int
+	err    error
+}
+
+func fetchLogStats(name string, window systemd.StatsWindow) tea.Cmd {
+	return func() tea.Msg {
+		counts, err := systemd.PriorityCounts(name, window)
+		return logStatsMsg{name: name, window: window, counts: counts, err: err}
+	}
+}
+
+// renderLogStats draws error/warning/info counts as a horizontal bar chart.
+func renderLogStats(msg logStatsMsg, width int) string {
+	c := msg.counts
+	rows := []struct {
+		label string
+		count int
+		color lipgloss.Color
+	}{
+		{"Errors", c[0] + c[1] + c[2] + c[3], red},
+		{"Warnings", c[4], orange},
+		{"Info", c[5] + c[6], cyan},
+	}
+
+	max, total := 0, 0
+	for _, r := range rows {
+		total += r.count
+		if r.count > max {
+			max = r.count
+		}
+	}
+
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	label := lipgloss.NewStyle().Width(10)
+
+	var b strings.Builder
+	b.WriteString(heading.Render(msg.name) + "  " + dim.Render("journal priorities, "+msg.window.String()) + "\n\n")
+
+	barWidth := width - 10 - 10
+	if barWidth < 1 {
+		barWidth = 1
+	}
+	for _, r := range rows {
+		n := 0
+		if max > 0 {
+			n = r.count * barWidth / max
+		}
+		if n == 0 && r.count > 0 {
+			n = 1
+		}
+		bar := lipgloss.NewStyle().Foreground(r.color).Render(strings.Repeat("█", n))
+		fmt.Fprintf(&b, "%s%s %d\n", label.Render(r.label), bar, r.count)
+	}
+
+	b.WriteString("\n" + dim.Render(fmt.Sprintf("%d entries total · press %% to change window (1h / 24h / boot)", total)))
+	return b.String()
+}
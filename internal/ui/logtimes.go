@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+)
+
+// timeMode is how the log view shows the timestamps of lines.
+type timeMode int
+
+const (
+	timesAsLogged timeMode = iota
+	timesLocal
+	timesUTC
+	timesRelative
+)
+
+func (t timeMode) String() string {
+	switch t {
+	case timesLocal:
+		return "local time"
+	case timesUTC:
+		return "UTC"
+	case timesRelative:
+		return "relative"
+	}
+	return "as logged"
+}
+
+func (t timeMode) next() timeMode {
+	return (t + 1) % (timesRelative + 1)
+}
+
+// logTimestamp finds the timestamp of a line in the formats services
+// commonly log: ISO 8601 / RFC 3339 with or without a zone, the common log
+// format of web servers, and syslog's, which journalctl uses.
+var logTimestamp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?` +
+	`|\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}` +
+	`|[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`)
+
+// Layouts tried on a matched timestamp. Those without a zone are read as
+// local time.
+var (
+	zonedLayouts = []string{"2006-01-02T15:04:05Z07:00", "2006-01-02T15:04:05Z0700", "02/Jan/2006:15:04:05 -0700"}
+	localLayouts = []string{"2006-01-02T15:04:05", time.Stamp}
+)
+
+// parseLogTime reads a timestamp matched by logTimestamp. Syslog
+// timestamps have no year; the one putting them closest to now is taken.
+func parseLogTime(s string, now time.Time) (time.Time, bool) {
+	if len(s) > 10 && s[10] == ' ' {
+		s = s[:10] + "T" + s[11:]
+	}
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range localLayouts {
+		t, err := time.ParseInLocation(layout, s, time.Local)
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			t = t.AddDate(now.Year(), 0, 0)
+			if t.After(now.Add(24 * time.Hour)) {
+				t = t.AddDate(-1, 0, 0)
+			}
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// formatLogTime renders a timestamp in the chosen mode.
+func formatLogTime(t time.Time, mode timeMode, now time.Time) string {
+	switch mode {
+	case timesUTC:
+		return t.UTC().Format("2006-01-02 15:04:05Z")
+	case timesRelative:
+		return humanDuration(now.Sub(t)) + " ago"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// retime replaces the first timestamp of a line with its rendering in the
+// chosen mode. Journal lines carry their exact time, which is used instead
+// of the seconds-precision text.
+func retime(l systemd.LogLine, mode timeMode, now time.Time) string {
+	if mode == timesAsLogged {
+		return l.Text
+	}
+	loc := logTimestamp.FindStringIndex(l.Text)
+	if loc == nil {
+		return l.Text
+	}
+	t := l.Time
+	if t.IsZero() {
+		var ok bool
+		if t, ok = parseLogTime(l.Text[loc[0]:loc[1]], now); !ok {
+			return l.Text
+		}
+	}
+	var b strings.Builder
+	b.WriteString(l.Text[:loc[0]])
+	b.WriteString(formatLogTime(t, mode, now))
+	b.WriteString(l.Text[loc[1]:])
+	return b.String()
+}
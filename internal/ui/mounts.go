@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"vigilix/internal/supervise"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// A hung network file system blocks statfs; give up on it rather than on
+// the whole view.
+const usageTimeout = 2 * time.Second
+
+type mountsMsg struct {
+	mounts []systemd.Mount
+	usage  map[string]*disk.UsageStat // by mount point
+	swaps  []systemd.Swap
+	// Swap problems leave the mounts usable.
+	swapErr error
+	err     error
+}
+
+func fetchMounts() tea.Msg {
+	mounts, err := systemd.Mounts()
+	if err != nil {
+		return mountsMsg{err: err}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	usage := make(map[string]*disk.UsageStat)
+	for _, mt := range mounts {
+		if mt.Automount || mt.ActiveState != "active" || mt.Where == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(where string) {
+			defer wg.Done()
+			done := make(chan *disk.UsageStat, 1)
+			// statfs hangs on unreachable network mounts; the supervisor
+			// shows such goroutines piling up.
+			supervise.Go("disk usage", func() {
+				u, err := disk.Usage(where)
+				if err != nil {
+					u = nil
+				}
+				done <- u
+			})
+			select {
+			case u := <-done:
+				mu.Lock()
+				usage[where] = u
+				mu.Unlock()
+			case <-time.After(usageTimeout):
+			}
+		}(mt.Where)
+	}
+	wg.Wait()
+
+	swaps, swapErr := systemd.Swaps()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return mountsMsg{mounts: mounts, usage: usage, swaps: swaps, swapErr: swapErr}
+}
+
+// usageColor flags file systems that are filling up.
+func usageColor(percent float64) lipgloss.Color {
+	switch {
+	case percent >= 90:
+		return red
+	case percent >= 80:
+		return orange
+	}
+	return green
+}
+
+// mountPicker picks a mount unit for remount/unmount.
+func mountPicker(mounts []systemd.Mount, title string, pick func(unit string) tea.Msg) *finder {
+	units := make(map[string]string)
+	var labels []string
+	for _, mt := range mounts {
+		if mt.Automount || mt.ActiveState != "active" {
+			continue
+		}
+		label := mt.Where + " (" + mt.Unit + ")"
+		units[label] = mt.Unit
+		labels = append(labels, label)
+	}
+	return newFinder(title, labels, func(label string) tea.Msg {
+		return pick(units[label])
+	})
+}
+
+// unmountMsg asks for confirmation before stopping a mount unit.
+type unmountMsg struct{ unit string }
+
+// remountMsg runs the remount straight away; it changes nothing on disk.
+type remountMsg struct{ unit string }
+
+func unmountDialog(unit string) *dialog {
+	return &dialog{
+		title: "Unmount " + unit + "?",
+		lines: []string{"Units that need it will be stopped too."},
+		confirm: func() tea.Msg {
+			return actionResultMsg{err: systemd.StopUnit(unit), action: "Unmounted", unit: unit}
+		},
+	}
+}
+
+func remount(unit string) tea.Cmd {
+	return func() tea.Msg {
+		return actionResultMsg{err: systemd.Remount(unit), action: "Remounted", unit: unit}
+	}
+}
+
+// renderMounts shows failed (auto)mounts first, then every mount with its
+// device and usage.
+func renderMounts(msg mountsMsg, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	bad := lipgloss.NewStyle().Foreground(red)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Mounts") + "\n")
+	b.WriteString(dim.Render("R: remount · U: unmount · W: swap on/off") + "\n\n")
+
+	var failed, automounts, mounts []systemd.Mount
+	for _, mt := range msg.mounts {
+		switch {
+		case mt.Failed():
+			failed = append(failed, mt)
+		case mt.Automount:
+			automounts = append(automounts, mt)
+		default:
+			mounts = append(mounts, mt)
+		}
+	}
+
+	if len(failed) > 0 {
+		b.WriteString(bad.Bold(true).Render(fmt.Sprintf("%s %d failed", stateMark("failed"), len(failed))) + "\n")
+		for _, mt := range failed {
+			fmt.Fprintf(&b, "%s  %s  %s\n", bad.Render(mt.Where), mt.Unit, dim.Render(mt.What+" · result: "+mt.Result))
+		}
+		b.WriteString("\n")
+	}
+
+	whereWidth := 0
+	for _, mt := range mounts {
+		whereWidth = max(whereWidth, len(mt.Where))
+	}
+	whereWidth = min(whereWidth, width/3)
+	const barWidth = 10
+	for _, mt := range mounts {
+		where := lipgloss.NewStyle().Width(whereWidth).Render(mt.Where)
+		usage := dim.Render(mt.SubState)
+		if u := msg.usage[mt.Where]; u != nil && u.Total > 0 {
+			filled := int(u.UsedPercent / 100 * barWidth)
+			bar := lipgloss.NewStyle().Foreground(usageColor(u.UsedPercent)).Render(strings.Repeat("█", filled)) +
+				dim.Render(strings.Repeat("░", barWidth-filled))
+			usage = fmt.Sprintf("%s %3.0f%% of %s", bar, u.UsedPercent, humanBytes(u.Total))
+		} else if mt.ActiveState == "active" {
+			usage = dim.Render("usage unknown")
+		}
+		fmt.Fprintf(&b, "%s  %s  %s\n", where, usage, dim.Render(mt.What+" ("+mt.Type+")"))
+	}
+
+	if len(automounts) > 0 {
+		b.WriteString("\n" + heading.Render("Automounts") + "\n")
+		for _, mt := range automounts {
+			fmt.Fprintf(&b, "%s  %s\n", mt.Where, dim.Render(mt.SubState))
+		}
+	}
+
+	b.WriteString("\n" + heading.Render("Swap") + "\n")
+	switch {
+	case msg.swapErr != nil:
+		b.WriteString(bad.Render(msg.swapErr.Error()) + "\n")
+	case len(msg.swaps) == 0:
+		b.WriteString(dim.Render("No swap configured.") + "\n")
+	}
+	for _, sw := range msg.swaps {
+		b.WriteString(renderSwap(sw, barWidth) + "\n")
+	}
+	return b.String()
+}
+
+// renderSwap is one line per swap device: usage when active, and for zram
+// how well it compresses.
+func renderSwap(sw systemd.Swap, barWidth int) string {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	line := stateMark(sw.ActiveState) + " " + sw.What
+	if sw.ActiveState != "active" || sw.Size == 0 {
+		return line + "  " + dim.Render(sw.ActiveState+" · "+sw.Unit)
+	}
+
+	percent := float64(sw.Used) / float64(sw.Size) * 100
+	filled := int(percent / 100 * float64(barWidth))
+	bar := lipgloss.NewStyle().Foreground(usageColor(percent)).Render(strings.Repeat("█", filled)) +
+		dim.Render(strings.Repeat("░", barWidth-filled))
+	line += fmt.Sprintf("  %s %s of %s", bar, humanBytes(sw.Used), humanBytes(sw.Size))
+
+	details := []string{sw.Type, fmt.Sprintf("priority %d", sw.Priority)}
+	if z := sw.Zram; z != nil {
+		details[0] = "zram"
+		if z.Algorithm != "" {
+			details = append(details, z.Algorithm)
+		}
+		if z.Compressed > 0 {
+			details = append(details, fmt.Sprintf("%.1fx compression, %s RAM", float64(z.Original)/float64(z.Compressed), humanBytes(z.MemoryUsed)))
+		}
+	}
+	if sw.Unit != "" {
+		details = append(details, sw.Unit)
+	}
+	return line + "  " + dim.Render(strings.Join(details, " · "))
+}
+
+// swapPicker picks a swap unit to turn on or off.
+func swapPicker(swaps []systemd.Swap) *finder {
+	units := make(map[string]systemd.Swap)
+	var labels []string
+	for _, sw := range swaps {
+		if sw.Unit == "" {
+			continue
+		}
+		verb := "swapon"
+		if sw.ActiveState == "active" {
+			verb = "swapoff"
+		}
+		label := verb + " " + sw.What
+		units[label] = sw
+		labels = append(labels, label)
+	}
+	return newFinder("swap on/off…", labels, func(label string) tea.Msg {
+		return swapToggleMsg{swap: units[label]}
+	})
+}
+
+type swapToggleMsg struct{ swap systemd.Swap }
+
+// swapToggle turns swap on straight away; turning it off moves everything
+// back into RAM, so that asks first.
+func swapToggle(sw systemd.Swap) (tea.Cmd, *dialog) {
+	if sw.ActiveState != "active" {
+		return func() tea.Msg {
+			return actionResultMsg{err: systemd.StartUnit(sw.Unit), action: "Enabled swap on", unit: sw.Unit}
+		}, nil
+	}
+	return nil, &dialog{
+		title: "Turn off swap on " + sw.What + "?",
+		lines: []string{fmt.Sprintf("%s swapped out will be moved back into RAM.", humanBytes(sw.Used))},
+		confirm: func() tea.Msg {
+			return actionResultMsg{err: systemd.StopUnit(sw.Unit), action: "Disabled swap on", unit: sw.Unit}
+		},
+	}
+}
+
+// humanBytes formats a size with binary units, e.g. "931.5G".
+func humanBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(n)/float64(div), "KMGTPE"[exp])
+}
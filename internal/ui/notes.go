@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"vigilix/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Marker appended to the titles of annotated units.
+const noteMarker = " ✎"
+
+type notesSavedMsg struct {
+	notes map[string]string
+	err   error
+}
+
+func notePrompt(unit, current string) *prompt {
+	return newPrompt(
+		"Note for "+unit,
+		"enter: save · esc: cancel · leave empty to remove the note",
+		current,
+		func(value string) tea.Cmd {
+			return func() tea.Msg {
+				notes, err := config.SetNote(unit, value)
+				return notesSavedMsg{notes: notes, err: err}
+			}
+		},
+	)
+}
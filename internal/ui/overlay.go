@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// overlay draws fg on top of bg with its top-left corner at (x, y).
+// Both strings may contain ANSI styling; cells outside fg are kept as-is.
+func overlay(bg, fg string, x, y int) string {
+	bgLines := strings.Split(bg, "\n")
+	fgLines := strings.Split(fg, "\n")
+	fgWidth := lipgloss.Width(fg)
+
+	for i, fgLine := range fgLines {
+		row := y + i
+		if row < 0 || row >= len(bgLines) {
+			continue
+		}
+		line := bgLines[row]
+		if w := ansi.StringWidth(line); w < x {
+			line += strings.Repeat(" ", x-w)
+		}
+
+		left := ansi.Truncate(line, x, "")
+		right := ansi.TruncateLeft(line, x+fgWidth, "")
+		pad := fgWidth - ansi.StringWidth(fgLine)
+		if pad < 0 {
+			pad = 0
+		}
+		bgLines[row] = left + fgLine + strings.Repeat(" ", pad) + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
+
+// overlayCenter draws fg centered over bg.
+func overlayCenter(bg, fg string, width, height int) string {
+	x := (width - lipgloss.Width(fg)) / 2
+	y := (height - lipgloss.Height(fg)) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	return overlay(bg, fg, x, y)
+}
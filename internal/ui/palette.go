@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+	"vigilix/internal/config"
+	"vigilix/internal/dbstats"
+	"vigilix/internal/i18n"
+	"vigilix/internal/plugin"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteKeys are the built-in unit commands offered by the command palette.
+// Picking one replays its key in the unit list.
+var paletteKeys = []key.Binding{
+	keys.Start, keys.Stop, keys.Restart, keys.Drain, keys.RestartFailed, keys.Trigger, keys.JumpTrigger,
+	keys.Config, keys.Details, keys.Explain, keys.LogStats, keys.Info,
+	keys.Compare, keys.Bus, keys.Schedule, keys.Scheduled, keys.CancelScheduled,
+	keys.Note, keys.Silence, keys.Export, keys.Messages,
+}
+
+// paletteKeyMsg replays a built-in command's key in the unit list.
+type paletteKeyMsg struct {
+	key string
+}
+
+// runPluginMsg invokes a plugin for a unit.
+type runPluginMsg struct {
+	plugin config.Plugin
+	unit   string
+}
+
+type pluginMsg struct {
+	plugin config.Plugin
+	unit   string
+	resp   *plugin.Response
+	err    error
+}
+
+// commandPalette lists the built-in commands plus the plugins that apply to
+// the selected unit.
+func (m model) commandPalette() *finder {
+	unit := ""
+	if i, ok := m.list.SelectedItem().(item); ok {
+		unit = i.unit.Name
+	}
+
+	picks := make(map[string]tea.Msg)
+	var labels []string
+	for _, b := range paletteKeys {
+		desc := []rune(i18n.T(b.Help().Desc))
+		label := string(unicode.ToUpper(desc[0])) + string(desc[1:]) + "  (" + b.Help().Key + ")"
+		picks[label] = paletteKeyMsg{key: b.Keys()[0]}
+		labels = append(labels, label)
+	}
+	picks["Boot report"] = openBootReportMsg{}
+	labels = append(labels, "Boot report")
+	for _, g := range m.cfg.Groups {
+		label := "Restart group: " + g.Name
+		picks[label] = showDialogMsg{dialog: groupDialog(g, m.cfg.Probes)}
+		labels = append(labels, label)
+	}
+	if unit != "" {
+		for _, p := range m.plugins {
+			if !plugin.Applies(p, unit) {
+				continue
+			}
+			label := "Action: " + p.Name
+			if p.Kind == plugin.KindPanel {
+				label = "Panel: " + p.Name
+			}
+			picks[label] = runPluginMsg{plugin: p, unit: unit}
+			labels = append(labels, label)
+		}
+		if db, ok := dbstats.Find(m.cfg.Databases, unit); ok {
+			picks["Panel: Database stats"] = dbStatsMsg{db: db, unit: unit}
+			labels = append(labels, "Panel: Database stats")
+		}
+	}
+
+	f := newFinder("run command… (or e.g. restart worker@*)", labels, func(label string) tea.Msg {
+		if msg, ok := picks[label]; ok {
+			return msg
+		}
+		verb, pattern, _ := parseGlobCommand(strings.SplitN(label, "  (", 2)[0])
+		return showDialogMsg{dialog: m.globDialog(verb, pattern)}
+	})
+	f.typed = m.globCommandLabel
+	return f
+}
+
+func runPlugin(p config.Plugin, unit string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := plugin.Run(p, unit)
+		return pluginMsg{plugin: p, unit: unit, resp: resp, err: err}
+	}
+}
+
+// renderPluginPanel shows a panel plugin's output.
+func renderPluginPanel(msg pluginMsg, width int) string {
+	title := msg.resp.Title
+	if title == "" {
+		title = msg.plugin.Name
+	}
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+	return heading.Render(title) + "  " + dim.Render(msg.unit) + "\n\n" +
+		lipgloss.NewStyle().Width(width).Render(msg.resp.Body)
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Files larger than this are cut off when viewed in the viewport.
+const maxViewedFileSize = 1 << 20
+
+type pathContentMsg struct {
+	path    string
+	content string
+	err     error
+}
+
+type editPathMsg struct {
+	path string
+}
+
+type editorDoneMsg struct {
+	path string
+	err  error
+}
+
+// pathPicker lists the paths referenced by the current unit file and hands
+// the chosen one to open.
+func pathPicker(refs []systemd.PathRef, placeholder string, open func(string) tea.Msg) *finder {
+	byLabel := make(map[string]string, len(refs))
+	labels := make([]string, len(refs))
+	for i, r := range refs {
+		labels[i] = r.Path + "  (" + r.Directive + ")"
+		byLabel[labels[i]] = r.Path
+	}
+	return newFinder(placeholder, labels, func(label string) tea.Msg {
+		return open(byLabel[label])
+	})
+}
+
+func readPath(path string) tea.Msg {
+	info, err := os.Stat(path)
+	if err != nil {
+		return pathContentMsg{path: path, err: err}
+	}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return pathContentMsg{path: path, err: err}
+		}
+		var b strings.Builder
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() {
+				name += "/"
+			}
+			b.WriteString(name + "\n")
+		}
+		return pathContentMsg{path: path, content: b.String()}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return pathContentMsg{path: path, err: err}
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxViewedFileSize))
+	if err != nil {
+		return pathContentMsg{path: path, err: err}
+	}
+
+	if bytes.IndexByte(data, 0) >= 0 {
+		return pathContentMsg{path: path, content: fmt.Sprintf("Binary file, %d bytes, mode %s", info.Size(), info.Mode())}
+	}
+	return pathContentMsg{path: path, content: string(data)}
+}
+
+func renderPathContent(msg pathContentMsg, width int) string {
+	header := lipgloss.NewStyle().Bold(true).Foreground(orange).Render(rule("── "+msg.path+" ", width))
+	hint := lipgloss.NewStyle().Foreground(comment).Render("esc, then c, returns to the unit file")
+	return header + "\n" + hint + "\n\n" + msg.content
+}
+
+// openInEditor suspends the UI and opens path in $EDITOR (vi by default).
+func openInEditor(path string) tea.Cmd {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorDoneMsg{path: path, err: err}
+	})
+}
+
+// configPaths lists the files of the unit in the Config view and the
+// paths they reference, led by its Quadlet source if it has one.
+func (m model) configPaths() []systemd.PathRef {
+	var refs []systemd.PathRef
+	if m.quadletSource != "" {
+		refs = append(refs, systemd.PathRef{Directive: "Quadlet source", Path: m.quadletSource})
+	}
+	for i, f := range unitFilePaths(m.configContent) {
+		directive := "unit file"
+		if i > 0 {
+			directive = "drop-in"
+		}
+		refs = append(refs, systemd.PathRef{Directive: directive, Path: f})
+	}
+	return append(refs, systemd.ReferencedPaths(m.configContent)...)
+}
+
+// regenerate reruns the unit generators, Quadlet among them, after its
+// source was edited.
+func regenerate(string) error {
+	return systemd.DaemonReload()
+}
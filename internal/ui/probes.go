@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/probe"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	probeHistory = 30 // results kept per unit
+	listSparkLen = 8  // results drawn in the unit list
+	maxProbeWait = 5 * time.Second
+	sparkBlocks  = "▁▂▃▄▅▆▇█"
+)
+
+type probesMsg map[string]probe.Result
+
+type probeTickMsg struct{}
+
+// fetchProbes probes every configured URL, keyed by full unit name.
+func fetchProbes(urls map[string]string, interval time.Duration) tea.Cmd {
+	byUnit := make(map[string]string, len(urls))
+	for name, url := range urls {
+		byUnit[systemd.UnitName(name)] = url
+	}
+	return func() tea.Msg {
+		return probesMsg(probe.RunAll(byUnit, min(interval, maxProbeWait)))
+	}
+}
+
+func probeTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return probeTickMsg{} })
+}
+
+// addProbes appends the latest results, keeping the last probeHistory.
+func (m *model) addProbes(results probesMsg) {
+	if m.probes == nil {
+		m.probes = make(map[string][]probe.Result)
+	}
+	for unit, r := range results {
+		list := append(m.probes[unit], r)
+		if len(list) > probeHistory {
+			list = list[len(list)-probeHistory:]
+		}
+		m.probes[unit] = list
+	}
+}
+
+// sparkline draws latencies scaled to the slowest shown; failed probes are
+// full red blocks.
+func sparkline(results []probe.Result) string {
+	blocks := []rune(sparkBlocks)
+	var slowest time.Duration
+	for _, r := range results {
+		if r.OK() {
+			slowest = max(slowest, r.Latency)
+		}
+	}
+	ok := lipgloss.NewStyle().Foreground(cyan)
+	bad := lipgloss.NewStyle().Foreground(red)
+	var b strings.Builder
+	for _, r := range results {
+		if !r.OK() {
+			b.WriteString(bad.Render(string(blocks[len(blocks)-1])))
+			continue
+		}
+		level := 0
+		if slowest > 0 {
+			level = int(float64(len(blocks)-1) * float64(r.Latency) / float64(slowest))
+		}
+		b.WriteString(ok.Render(string(blocks[level])))
+	}
+	return b.String()
+}
+
+// latencyText is a short latency, e.g. "42ms" or "1.2s".
+func latencyText(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// probeBadge is the list's sparkline of the latest probes with the last
+// latency, or its failure.
+func probeBadge(results []probe.Result) string {
+	if len(results) == 0 {
+		return ""
+	}
+	shown := results[max(len(results)-listSparkLen, 0):]
+	last := results[len(results)-1]
+	text := lipgloss.NewStyle().Foreground(comment).Render(latencyText(last.Latency))
+	if !last.OK() {
+		text = lipgloss.NewStyle().Foreground(red).Render("down")
+	}
+	return sparkline(shown) + " " + text
+}
+
+// renderProbe describes a unit's HTTP probe in Details.
+func renderProbe(url string, results []probe.Result, label, value lipgloss.Style) string {
+	var b strings.Builder
+	row := func(k, v string) {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render(k), " ", value.Render(v)) + "\n")
+	}
+	row("URL", url)
+	if len(results) == 0 {
+		row("Latency", "not probed yet")
+		return b.String()
+	}
+	last := results[len(results)-1]
+	if last.OK() {
+		row("Last", fmt.Sprintf("HTTP %d in %s, %s ago", last.Status, latencyText(last.Latency), humanDuration(time.Since(last.At))))
+	} else {
+		row("Last", lipgloss.NewStyle().Foreground(red).Render(last.Err.Error()))
+	}
+
+	var lo, hi, sum time.Duration
+	n, failed := 0, 0
+	for _, r := range results {
+		if !r.OK() {
+			failed++
+			continue
+		}
+		if n == 0 || r.Latency < lo {
+			lo = r.Latency
+		}
+		hi = max(hi, r.Latency)
+		sum += r.Latency
+		n++
+	}
+	if n > 0 {
+		row("Latency", fmt.Sprintf("%s min · %s avg · %s max", latencyText(lo), latencyText(sum/time.Duration(n)), latencyText(hi)))
+	}
+	row("Recent", fmt.Sprintf("%s  %d of %d failed", sparkline(results), failed, len(results)))
+	return b.String()
+}
+
+// probeURL returns the URL probed for a unit, if any.
+func (m model) probeURL(unit string) string {
+	for name, url := range m.cfg.Probes {
+		if systemd.UnitName(name) == unit {
+			return url
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prompt is a modal single-line text input. Enter submits the value, esc
+// cancels. Like dialog confirmations, the submitted command counts as
+// background work and must answer with a message that decrements busy.
+type prompt struct {
+	title  string
+	hint   string
+	input  textinput.Model
+	submit func(string) tea.Cmd
+}
+
+func newPrompt(title, hint, value string, submit func(string) tea.Cmd) *prompt {
+	ti := textinput.New()
+	ti.Prompt = "❯ "
+	ti.SetValue(value)
+	ti.CursorEnd()
+	ti.Focus()
+	return &prompt{title: title, hint: hint, input: ti, submit: submit}
+}
+
+// handleKey reports the command to run (if any) and whether the prompt
+// should close.
+func (p *prompt) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		return p.submit(p.input.Value()), true
+	case "esc":
+		return nil, true
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return cmd, false
+}
+
+func (p *prompt) View(width int) string {
+	return lipgloss.NewStyle().
+		Border(panelBorder).
+		BorderForeground(purple).
+		Background(background).
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Foreground(cyan).Render(p.title),
+			"",
+			p.input.View(),
+			"",
+			lipgloss.NewStyle().Foreground(comment).Render(p.hint),
+		))
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"vigilix/internal/recording"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recorder wraps the program's model to write every key, background event
+// and changed frame to a session recording.
+type recorder struct {
+	inner tea.Model
+	w     *recording.Writer
+	last  string // the last recorded frame
+}
+
+// Record wraps m so that the session is written to w.
+func Record(m tea.Model, w *recording.Writer) tea.Model {
+	return &recorder{inner: m, w: w}
+}
+
+func (r *recorder) Init() tea.Cmd {
+	return r.inner.Init()
+}
+
+func (r *recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		r.w.Add(recording.Entry{Kind: recording.Key, Data: msg.String()})
+	case tea.WindowSizeMsg:
+		r.w.Add(recording.Entry{Kind: recording.Size, Width: msg.Width, Height: msg.Height})
+	default:
+		if desc := describeMsg(msg); desc != "" {
+			r.w.Add(recording.Entry{Kind: recording.Event, Data: desc})
+		}
+	}
+	var cmd tea.Cmd
+	r.inner, cmd = r.inner.Update(msg)
+	return r, cmd
+}
+
+func (r *recorder) View() string {
+	view := r.inner.View()
+	if view != r.last {
+		r.last = view
+		r.w.Add(recording.Entry{Kind: recording.Frame, Data: view})
+	}
+	return view
+}
+
+// describeMsg summarises a message for the recording: its type, marked if
+// it carries an error (the error itself is in the frames, as a toast).
+// Timer ticks and cursor blinks return "".
+func describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case nil, clockTickMsg, spinner.TickMsg, tea.MouseMsg, tea.BatchMsg:
+		return ""
+	case actionResultMsg:
+		if msg.err != nil {
+			return fmt.Sprintf("%s %s: %v", msg.action, msg.unit, msg.err)
+		}
+		return msg.action + " " + msg.unit
+	case logLineMsg:
+		return "log: " + msg.line.Text
+	case error:
+		return "error: " + msg.Error()
+	}
+	name := strings.TrimPrefix(fmt.Sprintf("%T", msg), "ui.")
+	if strings.Contains(strings.ToLower(name), "blink") {
+		return ""
+	}
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("err"); f.IsValid() && f.Kind() == reflect.Interface && !f.IsNil() {
+			name += " (error)"
+		}
+	}
+	return name
+}
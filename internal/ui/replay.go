@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/recording"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxReplayGap caps the pause between two frames, so that idle stretches
+// of a recording play back quickly.
+const maxReplayGap = 3 * time.Second
+
+// replayFrame is one recorded screen with the keys and events that led to
+// it.
+type replayFrame struct {
+	at            time.Duration
+	view          string
+	width, height int
+	keys, events  []string
+}
+
+// replay plays a session recording back. It only shows the recorded
+// frames; nothing is sent to systemd.
+type replay struct {
+	header        recording.Header
+	frames        []replayFrame
+	pos           int
+	playing       bool
+	speed         float64
+	gen           int // invalidates ticks scheduled before a seek
+	width, height int
+}
+
+type replayTickMsg struct{ gen int }
+
+// NewReplay returns a player for a recording read with recording.Read.
+func NewReplay(cfg config.Config, header recording.Header, entries []recording.Entry, speed float64) tea.Model {
+	setASCII(cfg.ASCII)
+	themeName := cfg.Theme
+	if cfg.ASCII {
+		themeName = "contrast"
+	}
+	setTheme(themeName)
+
+	var frames []replayFrame
+	var pending replayFrame
+	for _, e := range entries {
+		switch e.Kind {
+		case recording.Key:
+			pending.keys = append(pending.keys, e.Data)
+		case recording.Event:
+			pending.events = append(pending.events, e.Data)
+		case recording.Size:
+			pending.width, pending.height = e.Width, e.Height
+		case recording.Frame:
+			pending.at, pending.view = e.At, e.Data
+			frames = append(frames, pending)
+			pending = replayFrame{width: pending.width, height: pending.height}
+		}
+	}
+	return &replay{header: header, frames: frames, playing: true, speed: speed}
+}
+
+func (r *replay) Init() tea.Cmd {
+	return r.next()
+}
+
+// next schedules the step to the following frame, after the time that
+// passed between them in the recording.
+func (r *replay) next() tea.Cmd {
+	if !r.playing || r.pos >= len(r.frames)-1 {
+		return nil
+	}
+	gap := min(r.frames[r.pos+1].at-r.frames[r.pos].at, maxReplayGap)
+	gen := r.gen
+	return tea.Tick(time.Duration(float64(gap)/r.speed), func(time.Time) tea.Msg { return replayTickMsg{gen: gen} })
+}
+
+func (r *replay) seek(pos int) tea.Cmd {
+	r.pos = max(0, min(pos, len(r.frames)-1))
+	r.gen++
+	return r.next()
+}
+
+func (r *replay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		r.width, r.height = msg.Width, msg.Height
+	case replayTickMsg:
+		if msg.gen == r.gen && r.playing && r.pos < len(r.frames)-1 {
+			r.pos++
+			return r, r.next()
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return r, tea.Quit
+		case " ", "space", "p":
+			r.playing = !r.playing
+			if r.playing && r.pos == len(r.frames)-1 {
+				return r, r.seek(0)
+			}
+			return r, r.seek(r.pos)
+		case "right", "l":
+			r.playing = false
+			return r, r.seek(r.pos + 1)
+		case "left", "h":
+			r.playing = false
+			return r, r.seek(r.pos - 1)
+		case "home", "g":
+			return r, r.seek(0)
+		case "end", "G":
+			return r, r.seek(len(r.frames) - 1)
+		case "+", "=":
+			r.speed = min(r.speed*2, 16)
+			return r, r.seek(r.pos)
+		case "-":
+			r.speed = max(r.speed/2, 0.25)
+			return r, r.seek(r.pos)
+		}
+	}
+	return r, nil
+}
+
+func (r *replay) View() string {
+	if r.width == 0 {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(comment)
+	if len(r.frames) == 0 {
+		return "The recording holds no frames.\n" + dim.Render("q: quit")
+	}
+	f := r.frames[r.pos]
+
+	// Two lines are kept for the bar; a frame recorded on a larger terminal
+	// is cut to fit.
+	lines := strings.Split(f.view, "\n")
+	lines = lines[:min(len(lines), max(r.height-2, 0))]
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, r.width, "")
+	}
+	for len(lines) < r.height-2 {
+		lines = append(lines, "")
+	}
+
+	state := "▶"
+	if !r.playing {
+		state = "⏸"
+	}
+	total := r.frames[len(r.frames)-1].at
+	info := fmt.Sprintf(" %s %s / %s · frame %d/%d · %gx · %s, %s",
+		state, clockDuration(f.at), clockDuration(total), r.pos+1, len(r.frames), r.speed,
+		r.header.Host, r.header.Started.Local().Format("2006-01-02 15:04:05"))
+	if f.width > r.width || f.height > r.height-2 {
+		info += fmt.Sprintf(" · recorded at %dx%d", f.width, f.height)
+	}
+	var input []string
+	if len(f.keys) > 0 {
+		input = append(input, "keys: "+strings.Join(f.keys, " "))
+	}
+	if len(f.events) > 0 {
+		input = append(input, "events: "+strings.Join(f.events, ", "))
+	}
+	if len(input) == 0 {
+		input = append(input, "space: play/pause · ←/→: step · +/-: speed · g/G: start/end · q: quit")
+	}
+	bar := lipgloss.NewStyle().Bold(true).Foreground(cyan).Render(truncate(info, r.width)) + "\n" +
+		dim.Render(truncate(" "+strings.Join(input, " · "), r.width))
+	return strings.Join(lines, "\n") + "\n" + bar
+}
+
+// clockDuration formats d as m:ss.
+func clockDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var schedulableActions = map[string]bool{"start": true, "stop": true, "restart": true}
+
+type scheduleResultMsg struct {
+	text string
+	err  error
+}
+
+type scheduledMsg struct {
+	actions []systemd.ScheduledAction
+	cancel  bool // open the cancel picker once loaded
+	err     error
+}
+
+// schedulePrompt asks for "<action> <when>" for the given unit.
+func schedulePrompt(unit string) *prompt {
+	return newPrompt(
+		"Schedule an action for "+unit,
+		"<start|stop|restart> <when> · e.g. restart 02:00 · stop +30m · start 2026-10-20 09:00",
+		"restart ",
+		func(value string) tea.Cmd { return scheduleAction(unit, value) },
+	)
+}
+
+func scheduleAction(unit, input string) tea.Cmd {
+	return func() tea.Msg {
+		action, when, _ := strings.Cut(strings.TrimSpace(input), " ")
+		if !schedulableActions[action] {
+			return scheduleResultMsg{err: fmt.Errorf("unknown action %q, expected start, stop or restart", action)}
+		}
+		timer, err := systemd.ScheduleAction(action, unit, when)
+		if err != nil {
+			return scheduleResultMsg{err: err}
+		}
+		return scheduleResultMsg{text: fmt.Sprintf("Scheduled %s of %s (%s)", action, unit, timer)}
+	}
+}
+
+func fetchScheduled(cancel bool) tea.Cmd {
+	return func() tea.Msg {
+		actions, err := systemd.ListScheduled()
+		return scheduledMsg{actions: actions, cancel: cancel, err: err}
+	}
+}
+
+func cancelScheduled(timer string) tea.Cmd {
+	return func() tea.Msg {
+		if err := systemd.CancelScheduled(timer); err != nil {
+			return scheduleResultMsg{err: err}
+		}
+		return scheduleResultMsg{text: "Cancelled " + timer}
+	}
+}
+
+// cancelPicker lets the user choose a pending action, then confirms.
+func cancelPicker(actions []systemd.ScheduledAction) *finder {
+	byLabel := make(map[string]systemd.ScheduledAction, len(actions))
+	labels := make([]string, len(actions))
+	for i, a := range actions {
+		labels[i] = scheduledLabel(a)
+		byLabel[labels[i]] = a
+	}
+	return newFinder("cancel scheduled action…", labels, func(label string) tea.Msg {
+		a := byLabel[label]
+		return showDialogMsg{&dialog{
+			title:   "Cancel scheduled action",
+			lines:   []string{a.Description, "due " + a.Next.Format("2006-01-02 15:04"), "", "timer: " + a.Timer},
+			confirm: cancelScheduled(a.Timer),
+		}}
+	})
+}
+
+func scheduledLabel(a systemd.ScheduledAction) string {
+	desc := strings.TrimPrefix(a.Description, "vigilix: ")
+	if a.Next.IsZero() {
+		return desc
+	}
+	return fmt.Sprintf("%s @ %s", desc, a.Next.Format("Jan 02 15:04"))
+}
+
+func renderScheduled(actions []systemd.ScheduledAction) string {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	if len(actions) == 0 {
+		return dim.Render("No scheduled actions. Press S on a unit to schedule one.")
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(cyan).Render("Pending scheduled actions") + "\n\n")
+	for _, a := range actions {
+		when := "unknown"
+		if !a.Next.IsZero() {
+			when = a.Next.Format("2006-01-02 15:04") + " (in " + humanDuration(time.Until(a.Next)) + ")"
+		}
+		fmt.Fprintf(&b, "• %s\n  %s\n", strings.TrimPrefix(a.Description, "vigilix: "), dim.Render(when+" · "+a.Timer))
+	}
+	b.WriteString("\n" + dim.Render("Press X to cancel one."))
+	return b.String()
+}
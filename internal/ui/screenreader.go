@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/i18n"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Screen reader mode renders the screen as a few plain sentences instead of
+// panels, and prints every change worth hearing (unit state transitions,
+// action results, errors) as a line of its own above them. It runs without
+// the alternate screen so those lines stay in the scrollback.
+
+// announced is what the last update had already told the user about.
+type announced struct {
+	status string
+	toast  time.Time
+	units  []systemd.Unit
+}
+
+func (m model) announced() announced {
+	a := announced{status: m.status.message, units: m.allUnits}
+	if n := len(m.toasts.history); n > 0 {
+		a.toast = m.toasts.history[n-1].created
+	}
+	return a
+}
+
+// announcements lists what changed since before, one sentence per line.
+func (m model) announcements(before announced) []string {
+	var lines []string
+	if before.units == nil && m.allUnits != nil {
+		lines = append(lines, i18n.Tf("Loaded %d units, %d failed.", len(m.allUnits), len(m.failedUnits())))
+	} else if before.units != nil {
+		was := make(map[string]systemd.Unit, len(before.units))
+		for _, u := range before.units {
+			was[u.Name] = u
+		}
+		for _, u := range m.allUnits {
+			old, ok := was[u.Name]
+			if ok && (old.ActiveState != u.ActiveState || old.SubState != u.SubState) {
+				lines = append(lines, i18n.Tf("%s is now %s (%s), was %s.", u.Name, u.ActiveState, u.SubState, old.ActiveState))
+			}
+		}
+	}
+
+	for _, t := range m.toasts.history {
+		if t.created.After(before.toast) {
+			lines = append(lines, fmt.Sprintf("%s: %s", t.level, t.text))
+		}
+	}
+	if m.status.message != "" && m.status.message != before.status {
+		lines = append(lines, m.status.message)
+	}
+	return lines
+}
+
+// announce wraps handle so that, in screen reader mode, the changes it made
+// are printed as plain lines.
+func (m model) announce(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := m.announced()
+	next, cmd := m.handle(msg)
+	nm, ok := next.(model)
+	if !ok {
+		return next, cmd
+	}
+	if lines := nm.announcements(before); len(lines) > 0 {
+		cmd = tea.Batch(cmd, tea.Println(linearize(strings.Join(lines, "\n"))))
+	}
+	return nm, cmd
+}
+
+// linearView is the screen reader rendering: a handful of sentences, no
+// borders and no column alignment.
+func (m model) linearView() string {
+	var lines []string
+	switch {
+	case m.dialog != nil:
+		lines = append(lines, linearize(m.dialog.View(m.width)))
+	case m.prompt != nil:
+		lines = append(lines, linearize(m.prompt.View(m.width)))
+	case m.checklist != nil:
+		lines = append(lines, linearize(m.checklist.View(m.width)))
+	case m.finder != nil:
+		lines = append(lines, linearize(m.finder.View(m.width)))
+	}
+
+	scope := systemd.CurrentScope().String()
+	if systemd.Offline() {
+		scope = "offline journal " + systemd.JournalDir()
+	}
+	summary := i18n.Tf("Vigilix, %s scope, %d units, %d failed.", scope, len(m.allUnits), len(m.failedUnits()))
+	if filter := m.filterSummary(); filter != "" {
+		summary += " " + i18n.Tf("Filter: %s.", filter)
+	}
+	lines = append(lines, summary)
+
+	if m.viewMode == ModeDashboard {
+		lines = append(lines, i18n.T("Press Enter to Start"))
+		return strings.Join(lines, "\n")
+	}
+
+	if i, ok := m.list.SelectedItem().(item); ok {
+		lines = append(lines, i18n.Tf("Unit %d of %d: %s, %s (%s). %s",
+			m.list.Index()+1, len(m.list.Items()), i.unit.Name, i.unit.ActiveState, i.unit.SubState, i.description()))
+	}
+	if m.viewMode != ModeList {
+		view := linearize(m.viewport.View())
+		if m.activePane == PaneContent {
+			lines = append(lines, i18n.Tf("%s view, focused:", modeLabel(m.viewMode)))
+		} else {
+			lines = append(lines, i18n.Tf("%s view:", modeLabel(m.viewMode)))
+		}
+		if view != "" {
+			lines = append(lines, view)
+		}
+	}
+
+	var hints []string
+	for _, b := range keys.ShortHelp() {
+		hints = append(hints, b.Help().Key+" "+b.Help().Desc)
+	}
+	lines = append(lines, i18n.T("Keys:")+" "+strings.Join(hints, ", ")+".")
+	return strings.Join(lines, "\n")
+}
+
+// modeLabel is the tab title of a content view.
+func modeLabel(mode int) string {
+	for _, t := range tabs {
+		if t.mode == mode {
+			return i18n.T(strings.TrimSpace(t.label))
+		}
+	}
+	return modeNames[mode]
+}
+
+// linearize strips styling, rules and alignment padding from rendered text:
+// lines made only of drawing characters are dropped, border characters at
+// either end are removed and runs of spaces collapse to one.
+func linearize(s string) string {
+	var out []string
+	for _, line := range strings.Split(ansi.Strip(s), "\n") {
+		line = strings.Trim(line, " │─╭╮╰╯═║╔╗╚╝┃━")
+		if line == "" {
+			continue
+		}
+		out = append(out, strings.Join(strings.Fields(line), " "))
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"vigilix/internal/screenshot"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type screenshotMsg struct {
+	path string
+	err  error
+}
+
+// screenshotPrompt asks where to save the frame on screen when the key was
+// pressed; the prompt itself is not in the picture.
+func (m model) screenshotPrompt() *prompt {
+	frame := m.View()
+	colors := screenshot.Colors{Foreground: string(foreground), Background: string(background)}
+	return newPrompt(
+		"Save screenshot",
+		"<file.svg|.ans|.txt> · .ans keeps the colors for cat or less -R",
+		"vigilix-"+time.Now().Format("20060102-150405")+".svg",
+		func(value string) tea.Cmd {
+			return func() tea.Msg { return saveScreenshot(frame, colors, value) }
+		},
+	)
+}
+
+func saveScreenshot(frame string, colors screenshot.Colors, path string) tea.Msg {
+	path = strings.TrimSpace(path)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return screenshotMsg{err: err}
+		}
+		path = filepath.Join(home, rest)
+	}
+	format, err := screenshot.FormatForPath(path)
+	if err != nil {
+		return screenshotMsg{err: err}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return screenshotMsg{err: err}
+	}
+	if err := screenshot.Write(f, format, frame, colors); err != nil {
+		f.Close()
+		return screenshotMsg{err: err}
+	}
+	return screenshotMsg{path: path, err: f.Close()}
+}
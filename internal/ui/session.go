@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"vigilix/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var modeNames = map[int]string{
+	ModeDashboard:  "dashboard",
+	ModeList:       "list",
+	ModeLogs:       "logs",
+	ModeConfig:     "config",
+	ModeDetails:    "details",
+	ModeExplain:    "explain",
+	ModeLogStats:   "logstats",
+	ModeScheduled:  "scheduled",
+	ModeCompare:    "compare",
+	ModeMessages:   "messages",
+	ModeBus:        "dbus",
+	ModeMounts:     "mounts",
+	ModeJobs:       "jobs",
+	ModeTop:        "top",
+	ModeContainers: "containers",
+	ModeFirewall:   "firewall",
+	ModeVersions:   "versions",
+	ModeDrift:      "drift",
+}
+
+func modeByName(name string) (int, bool) {
+	for mode, n := range modeNames {
+		if n == name {
+			return mode, true
+		}
+	}
+	return 0, false
+}
+
+// saveSession records where the user was so the next launch can restore it.
+func (m model) saveSession() error {
+	s := config.Session{
+		ViewMode:      modeNames[m.viewMode],
+		ContentPane:   m.activePane == PaneContent,
+		DevMode:       m.devMode,
+		Filter:        m.list.FilterValue(),
+		ContentOffset: m.viewport.YOffset,
+	}
+	if i, ok := m.list.SelectedItem().(item); ok {
+		s.SelectedUnit = i.unit.Name
+	}
+	return config.SaveSession(s)
+}
+
+// restoreSession applies a saved session once the first unit list is known.
+func (m *model) restoreSession(s *config.Session) tea.Cmd {
+	var cmds []tea.Cmd
+
+	m.devMode = s.DevMode
+	cmds = append(cmds, m.updateListItems())
+	if s.Filter != "" {
+		m.list.SetFilterText(s.Filter)
+	}
+	if s.SelectedUnit != "" {
+		for idx, li := range m.list.VisibleItems() {
+			if i, ok := li.(item); ok && i.unit.Name == s.SelectedUnit {
+				m.list.Select(idx)
+				break
+			}
+		}
+	}
+
+	mode, ok := modeByName(s.ViewMode)
+	if !ok {
+		return tea.Batch(cmds...)
+	}
+	m.viewMode = mode
+	if s.ContentPane {
+		m.activePane = PaneContent
+	}
+	m.restoreOffset = s.ContentOffset
+
+	i, ok := m.list.SelectedItem().(item)
+	switch {
+	case mode == ModeLogs && ok:
+		cmds = append(cmds, m.startStreaming(i.unit.Name))
+	case mode == ModeConfig && ok:
+		m.busy++
+		cmds = append(cmds, fetchConfig(i.unit.Name))
+	case mode == ModeDetails && ok:
+		m.busy++
+		cmds = append(cmds, fetchDetails(i.unit.Name))
+	case mode == ModeExplain && ok:
+		m.busy++
+		cmds = append(cmds, fetchExplain(i.unit.Name))
+	case mode == ModeLogStats && ok:
+		m.busy++
+		cmds = append(cmds, fetchLogStats(i.unit.Name, m.statsWindow))
+	case mode == ModeScheduled:
+		m.busy++
+		cmds = append(cmds, fetchScheduled(false))
+	case mode == ModeBus:
+		m.busy++
+		cmds = append(cmds, fetchBus)
+	case mode == ModeJobs:
+		cmds = append(cmds, fetchJobs)
+	case mode == ModeTop:
+		cmds = append(cmds, fetchTop)
+	case mode == ModeContainers:
+		m.busy++
+		cmds = append(cmds, fetchContainers)
+	case mode == ModeFirewall:
+		m.busy++
+		cmds = append(cmds, fetchFirewall)
+	case mode == ModeVersions && ok:
+		m.busy++
+		cmds = append(cmds, fetchVersions(i.unit.Name))
+	case mode == ModeDrift:
+		m.busy++
+		cmds = append(cmds, fetchDrift(m.cfg.Baseline))
+	case mode == ModeMounts:
+		m.busy++
+		cmds = append(cmds, fetchMounts)
+	case mode == ModeMessages:
+		m.refreshMessages()
+	}
+	return tea.Batch(cmds...)
+}
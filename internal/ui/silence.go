@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/notify"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type silencesMsg struct {
+	silences []notify.Silence
+	text     string
+	err      error
+}
+
+// silencePrompt asks for "<unit|*> <duration|off> [reason]".
+func silencePrompt(unit string) *prompt {
+	return newPrompt(
+		"Silence alerts",
+		"<unit or * for all> <duration or off> [reason] · e.g. * 2h deploy window",
+		unit+" 1h",
+		func(value string) tea.Cmd { return applySilence(value) },
+	)
+}
+
+func applySilence(input string) tea.Cmd {
+	return func() tea.Msg {
+		fields := strings.Fields(input)
+		if len(fields) < 2 {
+			return silencesMsg{err: fmt.Errorf("expected <unit|*> <duration|off>")}
+		}
+		unit, label := fields[0], fields[0]
+		if unit == "*" {
+			unit, label = "", "all units"
+		}
+
+		if fields[1] == "off" {
+			silences, err := notify.RemoveSilence(unit)
+			return silencesMsg{silences: silences, text: "Alerts resumed for " + label, err: err}
+		}
+
+		d, err := time.ParseDuration(fields[1])
+		if err != nil || d <= 0 {
+			return silencesMsg{err: fmt.Errorf("invalid duration %q", fields[1])}
+		}
+		s := notify.Silence{Unit: unit, Until: time.Now().Add(d), Reason: strings.Join(fields[2:], " ")}
+		silences, err := notify.AddSilence(s)
+		text := fmt.Sprintf("Silenced %s until %s", label, s.Until.Format("15:04"))
+		return silencesMsg{silences: silences, text: text, err: err}
+	}
+}
+
+// silenceSummary is the status bar text for the active silences.
+func (m model) silenceSummary() string {
+	active := notify.Active(m.silences, m.status.now)
+	if len(active) == 0 {
+		return ""
+	}
+	for _, s := range active {
+		if s.Global() {
+			return glyphs.Silence + " all until " + s.Until.Format("15:04")
+		}
+	}
+	if len(active) == 1 {
+		return fmt.Sprintf("%s %s until %s", glyphs.Silence, active[0].Unit, active[0].Until.Format("15:04"))
+	}
+	return fmt.Sprintf("%s %d silenced", glyphs.Silence, len(active))
+}
+
+// alertFailures raises an alert for every unit that entered the failed state
+// since the previous refresh, unless it is silenced or alerts are turned off.
+func (m *model) alertFailures(prev, next []systemd.Unit) {
+	if prev == nil || !m.cfg.Notifications {
+		return // first load: failures are pre-existing, not new
+	}
+	was := make(map[string]string, len(prev))
+	for _, u := range prev {
+		was[u.Name] = u.ActiveState
+	}
+	now := time.Now()
+	for _, u := range next {
+		if u.ActiveState != "failed" || was[u.Name] == "failed" {
+			continue
+		}
+		if notify.Silenced(m.silences, u.Name, now) {
+			continue
+		}
+		m.toasts.push(toastError, u.Name+" entered the failed state")
+	}
+	m.refreshMessages()
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Services that write to stdout log everything at the same priority, so
+// their errors and warnings are recognized by their words.
+var (
+	errorWords   = regexp.MustCompile(`(?i)\b(error|err|fatal|panic|crit|critical|failed|failure|exception)\b`)
+	warningWords = regexp.MustCompile(`(?i)\b(warn|warning)\b`)
+)
+
+// problemColor reports whether a log line is an error or a warning, by its
+// journal priority or its words, and the color to show it in.
+func problemColor(l systemd.LogLine) (lipgloss.Color, bool) {
+	p, err := strconv.Atoi(l.Priority)
+	switch {
+	case err == nil && p <= 3, errorWords.MatchString(l.Text):
+		return red, true
+	case err == nil && p == 4, warningWords.MatchString(l.Text):
+		return orange, true
+	}
+	return "", false
+}
+
+// pinnedRows is the height of the pane above the logs in the split log
+// view, divider included: a third of the content pane.
+func (m model) pinnedRows() int {
+	if !m.splitLogs || m.viewMode != ModeLogs {
+		return 0
+	}
+	return m.contentRows / 3
+}
+
+// fitViewport leaves the pinned pane its rows whenever the split log view
+// is entered or left; a viewport following the stream stays at the bottom.
+func fitViewport(next tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m, ok := next.(model)
+	if !ok || m.viewport.Height == m.contentRows-m.pinnedRows() {
+		return next, cmd
+	}
+	follow := m.viewport.AtBottom()
+	m.viewport.Height = m.contentRows - m.pinnedRows()
+	if follow {
+		m.viewport.GotoBottom()
+	}
+	return m, cmd
+}
+
+// pinnedView shows the latest errors and warnings of the buffer above the
+// full stream, so they stay in sight however chatty the unit is.
+func (m model) pinnedView(rows, width int) string {
+	var problems []string
+	now := time.Now()
+	for i := len(m.logLines) - 1; i >= 0 && len(problems) < rows-1; i-- {
+		if color, ok := problemColor(m.logLines[i]); ok {
+			text := retime(m.logLines[i], m.logTimes, now)
+			problems = append(problems, lipgloss.NewStyle().Foreground(color).Render(truncate(text, width)))
+		}
+	}
+	slices.Reverse(problems)
+	if len(problems) == 0 {
+		problems = append(problems, lipgloss.NewStyle().Foreground(comment).Render("No errors or warnings so far"))
+	}
+	for len(problems) < rows-1 {
+		problems = append(problems, "")
+	}
+	title := "── errors & warnings "
+	divider := title + strings.Repeat("─", max(0, width-len([]rune(title))))
+	problems = append(problems, lipgloss.NewStyle().Foreground(comment).Render(divider))
+	return strings.Join(problems, "\n")
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+	"vigilix/internal/notify"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stalledFor reports how long a unit has been activating or deactivating
+// when that exceeds its own timeout or the configured stall threshold,
+// whichever is shorter, and zero otherwise.
+func (m model) stalledFor(u systemd.Unit, now time.Time) time.Duration {
+	t := m.unitTimes[u.Name]
+	var limit time.Duration
+	switch u.ActiveState {
+	case "activating":
+		limit = t.timeoutStart
+	case "deactivating":
+		limit = t.timeoutStop
+	default:
+		return 0
+	}
+	if threshold := m.cfg.StallThreshold(); threshold > 0 && (limit == 0 || threshold < limit) {
+		limit = threshold
+	}
+	if limit == 0 || t.stateChange.IsZero() {
+		return 0
+	}
+	if elapsed := now.Sub(t.stateChange); elapsed > limit {
+		return elapsed
+	}
+	return 0
+}
+
+// alertStalls raises a notification the first time a unit is seen stalled,
+// so hung starts are noticed without watching the list.
+func (m *model) alertStalls() {
+	now := time.Now()
+	seen := make(map[string]bool)
+	for _, u := range m.allUnits {
+		d := m.stalledFor(u, now)
+		if d == 0 {
+			continue
+		}
+		seen[u.Name] = true
+		if m.stalls[u.Name] || !m.cfg.Notifications || notify.Silenced(m.silences, u.Name, now) {
+			continue
+		}
+		m.toasts.push(toastError, fmt.Sprintf("%s has been %s for %s · press K to cancel or kill it", u.Name, u.ActiveState, humanDuration(d)))
+	}
+	if len(seen) > 0 {
+		m.refreshMessages()
+	}
+	m.stalls = seen
+}
+
+type killUnitMsg struct{ unit, signal string }
+
+// stallPicker offers the ways out of a hung start or stop: cancelling the
+// unit's queued job, or killing its processes. It is nil when the unit is
+// neither starting nor stopping.
+func (m model) stallPicker(u systemd.Unit) *finder {
+	if u.ActiveState != "activating" && u.ActiveState != "deactivating" {
+		return nil
+	}
+	picks := make(map[string]tea.Msg)
+	var labels []string
+	for _, j := range m.jobs {
+		if j.Unit == u.Name {
+			label := fmt.Sprintf("cancel %s job %d (%s)", j.Type, j.ID, j.State)
+			picks[label] = cancelJobMsg{job: j}
+			labels = append(labels, label)
+		}
+	}
+	for _, signal := range []string{"SIGTERM", "SIGKILL"} {
+		label := "kill with " + signal
+		picks[label] = killUnitMsg{unit: u.Name, signal: signal}
+		labels = append(labels, label)
+	}
+	return newFinder(u.Name+" is "+u.ActiveState+"…", labels, func(label string) tea.Msg {
+		return picks[label]
+	})
+}
+
+func killDialog(unit, signal string) *dialog {
+	return &dialog{
+		title: fmt.Sprintf("Send %s to %s?", signal, unit),
+		lines: []string{"Every process of the unit receives the signal; its start or stop job then fails."},
+		confirm: func() tea.Msg {
+			return actionResultMsg{err: systemd.KillUnit(unit, signal), action: "Sent " + signal + " to the", unit: unit}
+		},
+	}
+}
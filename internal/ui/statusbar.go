@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+	"time"
+	"vigilix/internal/i18n"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// How long an action result stays in the status bar.
+const statusMessageTTL = 5 * time.Second
+
+const (
+	defaultHint = "Tab: Switch | d: Dev Mode | Enter: View | s/x/r: Control"
+	inlineHint  = "/: Filter | d: Dev Mode | s/x/r: Control | q: Quit"
+)
+
+type clockTickMsg time.Time
+
+func clockTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return clockTickMsg(t)
+	})
+}
+
+// statusBar holds the state behind the segmented footer.
+type statusBar struct {
+	message string
+	expires time.Time
+	now     time.Time
+}
+
+func (s *statusBar) setMessage(msg string) {
+	s.message = msg
+	s.expires = time.Now().Add(statusMessageTTL)
+}
+
+// tick advances the clock and drops the message once it has expired.
+func (s *statusBar) tick(now time.Time) {
+	s.now = now
+	if s.message != "" && now.After(s.expires) {
+		s.message = ""
+	}
+}
+
+func (m model) statusBarView() string {
+	st := currentTheme.StatusBar
+
+	scope := strings.ToUpper(systemd.CurrentScope().String())
+	if systemd.Offline() {
+		scope = "OFFLINE " + systemd.JournalDir()
+	}
+	left := []string{st.Scope.Render(scope)}
+	if m.stats.hostname != "" && !systemd.Offline() {
+		left = append(left, st.Host.Render(m.stats.hostname))
+	}
+	if m.systemState != "" {
+		left = append(left, st.Health.Copy().Background(systemStateColor(m.systemState)).Render(m.systemState))
+	}
+	if m.clock.Timezone != "" && clockSkewed(m.clock) {
+		left = append(left, st.Health.Copy().Background(yellow).Render(i18n.T("clock unsynced")))
+	}
+	if filter := m.filterSummary(); filter != "" {
+		left = append(left, st.Filter.Render(filter))
+	}
+	if silence := m.silenceSummary(); silence != "" {
+		left = append(left, st.Silence.Render(silence))
+	}
+
+	switch {
+	case m.status.message == "" && m.cfg.Inline:
+		left = append(left, st.Hint.Render(i18n.T(inlineHint)))
+	case m.status.message == "":
+		left = append(left, st.Hint.Render(i18n.T(defaultHint)))
+	default:
+		left = append(left, st.Message.Render(m.status.message))
+	}
+
+	var right []string
+	if m.update != "" {
+		right = append(right, st.Update.Render(i18n.Tf("⬆ %s available", m.update)))
+	}
+	if m.spinnerShown() {
+		right = append(right, st.Spinner.Render(m.spinner.View()))
+	}
+	now := m.status.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	right = append(right, st.Clock.Render(now.Format("15:04:05")))
+
+	leftStr := lipgloss.JoinHorizontal(lipgloss.Top, left...)
+	rightStr := lipgloss.JoinHorizontal(lipgloss.Top, right...)
+
+	gapWidth := m.width - lipgloss.Width(leftStr) - lipgloss.Width(rightStr)
+	if gapWidth < 0 {
+		gapWidth = 0
+	}
+	gap := st.Bar.Render(strings.Repeat(" ", gapWidth))
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(leftStr + gap + rightStr)
+}
+
+// filterSummary describes the filters currently narrowing the unit list.
+func (m model) filterSummary() string {
+	var parts []string
+	if m.devMode {
+		parts = append(parts, "dev")
+	}
+	if m.failedOnly {
+		parts = append(parts, "failed")
+	}
+	if m.typeFilter != "" {
+		parts = append(parts, m.typeFilter)
+	}
+	if v := m.list.FilterValue(); v != "" {
+		parts = append(parts, "/"+v)
+	}
+	return strings.Join(parts, " ")
+}
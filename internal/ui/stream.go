@@ -0,0 +1,318 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"vigilix/internal/compose"
+	"vigilix/internal/supervise"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	reconnectBaseDelay = time.Second
+	reconnectMaxDelay  = 30 * time.Second
+	// streamStopTimeout bounds how long switching units or quitting waits
+	// for a stream's processes to exit.
+	streamStopTimeout = 2 * time.Second
+)
+
+// Separators inserted into the log buffer.
+const (
+	restartSeparator   = "── unit restarted ──"
+	reconnectSeparator = "── log stream reconnected ──"
+)
+
+// maxLogLines is how many streamed lines the log buffer keeps.
+const maxLogLines = 1000
+
+// logLineMsg carries one line of the stream with the given id.
+type logLineMsg struct {
+	id   int
+	line systemd.LogLine
+}
+
+// streamEndedMsg reports that a stream's processes exited on their own;
+// err says why, if they failed.
+type streamEndedMsg struct {
+	id   int
+	unit string
+	err  error
+}
+
+// reconnectMsg fires when it is time to retry a dropped stream.
+type reconnectMsg struct {
+	unit string
+}
+
+// reconnectDelay backs off exponentially with the number of failed attempts.
+func reconnectDelay(attempt int) time.Duration {
+	d := reconnectBaseDelay
+	for i := 0; i < attempt && d < reconnectMaxDelay; i++ {
+		d *= 2
+	}
+	if d > reconnectMaxDelay {
+		d = reconnectMaxDelay
+	}
+	return d
+}
+
+func scheduleReconnect(unit string, attempt int) tea.Cmd {
+	return tea.Tick(reconnectDelay(attempt), func(time.Time) tea.Msg {
+		return reconnectMsg{unit: unit}
+	})
+}
+
+// logStream is one run of journalctl, or docker compose logs, with the
+// unit's log files followed alongside it.
+type logStream struct {
+	id     int
+	unit   string
+	lines  chan systemd.LogLine
+	cancel context.CancelFunc
+	done   chan struct{} // closed once its processes and goroutines exited
+	err    error         // set before lines is closed
+}
+
+// streamManager owns the stream of the Logs view. The model holds it by
+// pointer, so every copy Bubble Tea makes of the model sees the same
+// stream, and replacing or stopping it always reaches the processes it
+// started. Messages carry the stream's id; those of replaced streams are
+// dropped, so exactly one command waits on the running stream.
+type streamManager struct {
+	current *logStream
+	lastID  int
+}
+
+// start replaces the running stream with one following unit from the
+// journal entry after the cursor, or its recent lines when after is empty,
+// and returns the command receiving its first line.
+func (s *streamManager) start(unit, after string, files []string) tea.Cmd {
+	stopped := s.stop()
+	s.lastID++
+	ctx, cancel := context.WithCancel(supervise.Context())
+	st := &logStream{id: s.lastID, unit: unit, lines: make(chan systemd.LogLine), cancel: cancel, done: make(chan struct{})}
+	s.current = st
+	slog.Debug("log stream started", "unit", unit, "id", st.id)
+
+	supervise.Go("log stream", func() {
+		defer close(st.done)
+		st.err = follow(ctx, unit, after, files, st.lines)
+		close(st.lines)
+	})
+	return tea.Batch(stopped, st.next())
+}
+
+// next returns the command receiving the running stream's next line.
+func (s *streamManager) next() tea.Cmd {
+	if s.current == nil {
+		return nil
+	}
+	return s.current.next()
+}
+
+// active reports whether id is the running stream.
+func (s *streamManager) active(id int) bool {
+	return s.current != nil && s.current.id == id
+}
+
+// stop cancels the running stream and returns the command waiting for its
+// processes to exit, so none outlive a switch to another unit or vigilix
+// itself. Waiting in Update would freeze the UI meanwhile.
+func (s *streamManager) stop() tea.Cmd {
+	st := s.current
+	if st == nil {
+		return nil
+	}
+	s.current = nil
+	st.cancel()
+	return func() tea.Msg {
+		select {
+		case <-st.done:
+			slog.Debug("log stream stopped", "unit", st.unit, "id", st.id)
+		case <-time.After(streamStopTimeout):
+			slog.Warn("log stream did not stop in time", "unit", st.unit, "id", st.id)
+		}
+		return nil
+	}
+}
+
+func (st *logStream) next() tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-st.lines
+		if !ok {
+			return streamEndedMsg{id: st.id, unit: st.unit, err: st.err}
+		}
+		return logLineMsg{id: st.id, line: line}
+	}
+}
+
+// follow streams the logs of a unit, or of a Compose project, to out until
+// ctx is cancelled or the stream ends. Files are followed alongside the
+// journal and stop with it, so a reconnect restarts both.
+func follow(ctx context.Context, unit, after string, files []string, out chan<- systemd.LogLine) error {
+	if project, ok := strings.CutPrefix(unit, composePrefix); ok {
+		return followCompose(ctx, project, out)
+	}
+
+	tailCtx, stopTails := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	if !systemd.Offline() {
+		wg.Add(1)
+		supervise.Go("log file tails", func() {
+			defer wg.Done()
+			tailLogFiles(tailCtx, unit, files, out)
+		})
+	}
+	err := systemd.StreamLogs(ctx, unit, after, out)
+	stopTails()
+	wg.Wait()
+	return err
+}
+
+// followCompose streams a Compose project's logs, which have no cursors:
+// a reconnect starts over from docker's tail.
+func followCompose(ctx context.Context, project string, out chan<- systemd.LogLine) error {
+	lines := make(chan string)
+	var err error
+	supervise.Go("compose logs", func() {
+		err = compose.StreamLogs(ctx, project, lines)
+		close(lines)
+	})
+	for line := range lines {
+		select {
+		case out <- systemd.LogLine{Text: line}:
+		case <-ctx.Done():
+		}
+	}
+	return err
+}
+
+// appendLogLine adds a line without a journal position, such as a
+// separator, to the buffer.
+func (m *model) appendLogLine(line string) {
+	m.appendLog(systemd.LogLine{Text: line})
+}
+
+// appendLog adds a line to the buffer, keeping the last 1000 lines plus any
+// older ones loaded on request, and refreshes the viewport when the logs are
+// showing.
+func (m *model) appendLog(lines ...systemd.LogLine) {
+	m.logLines = append(m.logLines, lines...)
+	if limit := maxLogLines + m.olderLoaded; len(m.logLines) > limit {
+		m.logLines = m.logLines[len(m.logLines)-limit:]
+	}
+	if m.viewMode == ModeLogs {
+		m.viewport.SetContent(m.logContent())
+		m.viewport.GotoBottom()
+	}
+}
+
+// logContent is the log buffer as shown, filtered by JSON fields: raw with
+// timestamps in the chosen mode, or as access log columns.
+func (m model) logContent() string {
+	if m.accessLog {
+		return renderAccessLog(logTexts(m.visibleLogs()), m.accessClass, m.viewport.Width)
+	}
+	lines := m.visibleLogs()
+	texts := make([]string, len(lines))
+	now := time.Now()
+	for i, l := range lines {
+		texts[i] = retime(l, m.logTimes, now)
+	}
+	return joinLines(texts)
+}
+
+// noteRestart inserts a separator when the streamed unit was (re)started
+// since the last refresh. Only later activations count, so the first
+// timestamp seen for a unit never produces a separator.
+func (m *model) noteRestart(prev, next unitTimes) {
+	if prev.activeEnter.IsZero() || !next.activeEnter.After(prev.activeEnter) {
+		return
+	}
+	m.appendLogLine(restartSeparator)
+}
+
+// logTexts returns the text of each buffered line.
+func logTexts(lines []systemd.LogLine) []string {
+	texts := make([]string, len(lines))
+	for i, l := range lines {
+		texts[i] = l.Text
+	}
+	return texts
+}
+
+func joinLines(lines []string) string {
+	return strings.Join(lines, "\n")
+}
+
+// olderLogsChunk is how many lines one "load older" fetches.
+const olderLogsChunk = 200
+
+// olderLogsMsg carries journal lines from before the start of the buffer.
+type olderLogsMsg struct {
+	unit  string
+	lines []systemd.LogLine
+	err   error
+}
+
+// firstCursor and lastCursor return the journal positions of the oldest and
+// newest journal lines in the buffer, or "" when it holds none.
+func (m model) firstCursor() string {
+	for _, l := range m.logLines {
+		if l.Cursor != "" {
+			return l.Cursor
+		}
+	}
+	return ""
+}
+
+func (m model) lastCursor() string {
+	for i := len(m.logLines) - 1; i >= 0; i-- {
+		if c := m.logLines[i].Cursor; c != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+// loadOlderLogs fetches the chunk of journal lines preceding the buffer, so
+// paging up past its top scrolls further back.
+func (m *model) loadOlderLogs() tea.Cmd {
+	if m.loadingOlder {
+		return nil
+	}
+	if strings.HasPrefix(m.streamingUnit, composePrefix) {
+		m.status.setMessage("Older lines cannot be loaded for Compose projects")
+		return nil
+	}
+	unit, cursor := m.streamingUnit, m.firstCursor()
+	if cursor == "" {
+		m.status.setMessage("No journal lines to load older ones before")
+		return nil
+	}
+	m.loadingOlder = true
+	m.busy++
+	return func() tea.Msg {
+		lines, err := systemd.OlderLogs(unit, cursor, olderLogsChunk)
+		return olderLogsMsg{unit: unit, lines: lines, err: err}
+	}
+}
+
+// prependLogs inserts older lines at the top of the buffer and keeps the
+// lines on screen where they were.
+func (m *model) prependLogs(lines []systemd.LogLine) {
+	before := strings.Count(m.logContent(), "\n")
+	m.logLines = append(slices.Clone(lines), m.logLines...)
+	m.olderLoaded += len(lines)
+	if m.viewMode == ModeLogs {
+		content := m.logContent()
+		m.viewport.SetContent(content)
+		m.viewport.SetYOffset(m.viewport.YOffset + strings.Count(content, "\n") - before)
+	}
+}
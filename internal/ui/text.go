@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Text is measured in terminal cells, never bytes or runes: emoji and CJK
+// characters take two cells, combining marks none, and ANSI styling none.
+// Cutting a string by byte index can split a multi-byte character and
+// leave invalid UTF-8 on screen, so all truncation and padding goes
+// through these helpers.
+
+// truncate shortens s to at most width cells, ending in "…" when it was
+// cut. Styling is kept and wide characters are never split.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Truncate(s, width, "…")
+}
+
+// pad fills s with spaces up to width cells.
+func pad(s string, width int) string {
+	if w := ansi.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// fit truncates or pads s to exactly width cells, for table columns.
+// Unlike lipgloss' Width, it never wraps a long value onto a second line.
+func fit(s string, width int) string {
+	return pad(truncate(s, width), width)
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// palette is the set of colors a theme is drawn with.
+type palette struct {
+	Background, Current, Foreground, Comment lipgloss.Color
+	Cyan, Green, Orange, Pink                lipgloss.Color
+	Purple, Red, Yellow                      lipgloss.Color
+}
+
+var palettes = map[string]palette{
+	"dracula": {
+		Background: "#282a36", Current: "#44475a", Foreground: "#f8f8f2", Comment: "#6272a4",
+		Cyan: "#8be9fd", Green: "#50fa7b", Orange: "#ffb86c", Pink: "#ff79c6",
+		Purple: "#2d57ff", Red: "#ff5555", Yellow: "#f1fa8c",
+	},
+	"nord": {
+		Background: "#2e3440", Current: "#3b4252", Foreground: "#eceff4", Comment: "#616e88",
+		Cyan: "#88c0d0", Green: "#a3be8c", Orange: "#d08770", Pink: "#b48ead",
+		Purple: "#5e81ac", Red: "#bf616a", Yellow: "#ebcb8b",
+	},
+	"light": {
+		Background: "#fafafa", Current: "#e5e5e6", Foreground: "#383a42", Comment: "#a0a1a7",
+		Cyan: "#0184bc", Green: "#50a14f", Orange: "#c18401", Pink: "#a626a4",
+		Purple: "#4078f2", Red: "#e45649", Yellow: "#986801",
+	},
+	// The color-blind palettes avoid telling states apart by red versus
+	// green: success is blue and failure orange, which stay distinct with
+	// deuteranopia and protanopia respectively.
+	"deuteranopia": {
+		Background: "#1e1e28", Current: "#3a3a4a", Foreground: "#f2f2f2", Comment: "#7a7a8c",
+		Cyan: "#88ccee", Green: "#56b4e9", Orange: "#e69f00", Pink: "#cc79a7",
+		Purple: "#5d6bd6", Red: "#d55e00", Yellow: "#f0e442",
+	},
+	"protanopia": {
+		Background: "#1e1e28", Current: "#3a3a4a", Foreground: "#f2f2f2", Comment: "#7a7a8c",
+		Cyan: "#88ccee", Green: "#5aa9f0", Orange: "#ffc20a", Pink: "#b99ad8",
+		Purple: "#5d6bd6", Red: "#e66100", Yellow: "#f0e442",
+	},
+	// contrast sticks to the 16 ANSI colors, which every terminal and the
+	// Linux console can show, and keeps text bright on black.
+	"contrast": {
+		Background: "0", Current: "4", Foreground: "15", Comment: "7",
+		Cyan: "14", Green: "10", Orange: "11", Pink: "13",
+		Purple: "12", Red: "9", Yellow: "11",
+	},
+}
+
+// themeNames lists the available themes in a stable order.
+func themeNames() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setTheme switches every color and style to the named palette.
+func setTheme(name string) error {
+	p, ok := palettes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+	background, current, foreground, comment = p.Background, p.Current, p.Foreground, p.Comment
+	cyan, green, orange, pink = p.Cyan, p.Green, p.Orange, p.Pink
+	purple, red, yellow = p.Purple, p.Red, p.Yellow
+
+	buildStyles()
+	currentTheme = defaultTheme()
+	return nil
+}
+
+func init() {
+	setTheme("dracula")
+}
+
+// theme holds the component styles that can be swapped as a unit.
+type theme struct {
+	StatusBar statusBarTheme
+	Toast     toastTheme
+}
+
+// statusBarTheme styles each status bar segment independently.
+type statusBarTheme struct {
+	Bar     lipgloss.Style
+	Scope   lipgloss.Style
+	Host    lipgloss.Style
+	Health  lipgloss.Style
+	Filter  lipgloss.Style
+	Silence lipgloss.Style
+	Update  lipgloss.Style
+	Message lipgloss.Style
+	Hint    lipgloss.Style
+	Spinner lipgloss.Style
+	Clock   lipgloss.Style
+}
+
+// toastTheme styles the transient notification boxes per level.
+type toastTheme struct {
+	Info    lipgloss.Style
+	Success lipgloss.Style
+	Error   lipgloss.Style
+}
+
+func defaultTheme() theme {
+	segment := lipgloss.NewStyle().Padding(0, 1)
+	toast := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Foreground(foreground).
+		Padding(0, 1)
+
+	return theme{
+		StatusBar: statusBarTheme{
+			Bar:     lipgloss.NewStyle().Background(current).Foreground(foreground),
+			Scope:   segment.Copy().Bold(true).Foreground(background).Background(purple),
+			Host:    segment.Copy().Foreground(background).Background(cyan),
+			Health:  segment.Copy().Bold(true).Foreground(background),
+			Filter:  segment.Copy().Foreground(background).Background(yellow),
+			Silence: segment.Copy().Foreground(background).Background(orange),
+			Update:  segment.Copy().Foreground(background).Background(green),
+			Message: segment.Copy().Foreground(orange).Background(current),
+			Hint:    segment.Copy().Foreground(comment).Background(current),
+			Spinner: segment.Copy().Background(current),
+			Clock:   segment.Copy().Foreground(foreground).Background(comment),
+		},
+		Toast: toastTheme{
+			Info:    toast.Copy().BorderForeground(cyan),
+			Success: toast.Copy().BorderForeground(green),
+			Error:   toast.Copy().BorderForeground(red),
+		},
+	}
+}
+
+var currentTheme theme
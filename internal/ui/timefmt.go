@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// humanDuration formats d using its two most significant units, e.g. "3d 4h".
+func humanDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// shortDuration formats sub-minute durations precisely, e.g. "1.24s" or
+// "85ms", for measured transitions.
+func shortDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return humanDuration(d)
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
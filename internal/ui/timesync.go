@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"errors"
+	"os/exec"
+	"time"
+	"vigilix/internal/i18n"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The clock's synchronization changes rarely; checking every minute
+// catches a lost time server soon enough.
+const timeSyncInterval = time.Minute
+
+// clockSkewWarning is the offset from the time server past which a clock
+// counts as off even while synchronized.
+const clockSkewWarning = time.Second
+
+type timeSyncMsg struct {
+	status systemd.ClockStatus
+	err    error
+}
+
+type timeSyncTickMsg struct{}
+
+func fetchTimeSync() tea.Msg {
+	status, err := systemd.GetClockStatus()
+	return timeSyncMsg{status: status, err: err}
+}
+
+func timeSyncTick() tea.Cmd {
+	return tea.Tick(timeSyncInterval, func(time.Time) tea.Msg { return timeSyncTickMsg{} })
+}
+
+// clockUnavailable reports whether the clock can never be read in this
+// session: timedatectl is missing, or vigilix browses an offline journal.
+func clockUnavailable(err error) bool {
+	return errors.Is(err, exec.ErrNotFound) || errors.Is(err, systemd.ErrOffline)
+}
+
+// clockSkewed reports whether the clock cannot be trusted: it is not
+// synchronized, or drifted from the time server by more than a second.
+// Skew breaks TLS validity checks and lining up logs across hosts.
+func clockSkewed(c systemd.ClockStatus) bool {
+	if !c.Synchronized {
+		return true
+	}
+	return c.HasOffset && (c.Offset > clockSkewWarning || c.Offset < -clockSkewWarning)
+}
+
+// clockBanner is the dashboard line describing the clock: time zone, sync
+// state and offset, or a warning when it is off.
+func (m model) clockBanner() string {
+	c := m.clock
+	if c.Timezone == "" {
+		return ""
+	}
+	text := c.Timezone
+	switch {
+	case !c.Synchronized && !c.NTP:
+		text = i18n.T("Clock not synchronized (NTP is off)") + " · " + text
+	case !c.Synchronized:
+		text = i18n.T("Clock not synchronized") + " · " + text
+	default:
+		text = i18n.T("Clock synchronized") + " · " + text
+	}
+	if c.HasOffset {
+		text += " · " + i18n.Tf("offset %s", formatOffset(c.Offset))
+	}
+	if c.Server != "" {
+		text += " · " + c.Server
+	}
+
+	if clockSkewed(c) {
+		warning := lipgloss.NewStyle().Foreground(comment).Render(i18n.T("time skew breaks TLS and log correlation"))
+		return lipgloss.JoinVertical(lipgloss.Center,
+			lipgloss.NewStyle().Bold(true).Foreground(yellow).Render(stateMark("failed")+" "+text),
+			warning)
+	}
+	return lipgloss.NewStyle().Foreground(comment).Render(stateMark("active") + " " + text)
+}
+
+// formatOffset shows a clock offset with its sign and three significant
+// decimals, e.g. "+1.042ms" or "-2.5s".
+func formatOffset(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	switch {
+	case d >= time.Second:
+		d = d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		d = d.Round(time.Microsecond)
+	}
+	return sign + d.String()
+}
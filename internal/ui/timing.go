@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// How many recent measurements the Details pane lists.
+const timingRows = 5
+
+type timedActionMsg struct {
+	unit    string
+	action  string // "start", "stop" or "restart"
+	done    string // e.g. "Started"
+	t       systemd.Transition
+	timings map[string][]config.Timing
+	err     error
+}
+
+// timedAction runs a start/stop/restart, measures it and records the result
+// in the unit's timing history.
+func timedAction(action, unit, done string) tea.Cmd {
+	return func() tea.Msg {
+		t, err := systemd.TimedAction(action, unit)
+		if err != nil {
+			return timedActionMsg{unit: unit, action: action, done: done, err: err}
+		}
+		timings, err := config.AddTiming(unit, config.Timing{
+			Action:       action,
+			At:           t.Started,
+			Total:        t.Total,
+			Queued:       t.Queued(),
+			Deactivating: t.Deactivating,
+			Activating:   t.Activating,
+		})
+		if err != nil {
+			err = fmt.Errorf("saving timing history: %w", err)
+		}
+		return timedActionMsg{unit: unit, action: action, done: done, t: t, timings: timings, err: err}
+	}
+}
+
+// describeTransition summarizes a measurement, e.g.
+// "1.24s (queued 20ms · activating 1.22s)".
+func describeTransition(total, queued, deactivating, activating time.Duration) string {
+	var phases []string
+	if queued > 0 {
+		phases = append(phases, "queued "+shortDuration(queued))
+	}
+	if deactivating > 0 {
+		phases = append(phases, "deactivating "+shortDuration(deactivating))
+	}
+	if activating > 0 {
+		phases = append(phases, "activating "+shortDuration(activating))
+	}
+	if len(phases) == 0 {
+		return shortDuration(total)
+	}
+	return shortDuration(total) + " (" + strings.Join(phases, " · ") + ")"
+}
+
+// renderTimings shows per-action averages and the latest measurements.
+func renderTimings(history []config.Timing, width int) string {
+	dim := lipgloss.NewStyle().Foreground(comment)
+	if len(history) == 0 {
+		return dim.Render("No actions measured yet; start, stop or restart the unit from vigilix.") + "\n"
+	}
+
+	var b strings.Builder
+	for _, action := range []string{"start", "stop", "restart"} {
+		var sum, worst time.Duration
+		n := 0
+		for _, t := range history {
+			if t.Action == action {
+				sum += t.Total
+				worst = max(worst, t.Total)
+				n++
+			}
+		}
+		if n > 0 {
+			fmt.Fprintf(&b, "%-8s avg %s · max %s over %d\n", action, shortDuration(sum/time.Duration(n)), shortDuration(worst), n)
+		}
+	}
+	b.WriteString("\n")
+
+	wrap := lipgloss.NewStyle().Width(width)
+	for i := len(history) - 1; i >= 0 && i >= len(history)-timingRows; i-- {
+		t := history[i]
+		line := fmt.Sprintf("%s %-8s %s", t.At.Format("01-02 15:04"), t.Action,
+			describeTransition(t.Total, t.Queued, t.Deactivating, t.Activating))
+		b.WriteString(wrap.Render(dim.Render(line)) + "\n")
+	}
+	return b.String()
+}
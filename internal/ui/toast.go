@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	toastTTL      = 4 * time.Second
+	toastErrorTTL = 8 * time.Second
+	maxToasts     = 4
+	maxHistory    = 200
+	toastWidth    = 40
+)
+
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastSuccess
+	toastError
+)
+
+func (l toastLevel) String() string {
+	switch l {
+	case toastSuccess:
+		return "OK"
+	case toastError:
+		return "ERROR"
+	}
+	return "INFO"
+}
+
+type toast struct {
+	level   toastLevel
+	text    string
+	created time.Time
+	expires time.Time
+}
+
+// toastStack keeps the visible toasts plus a bounded history of all of them.
+type toastStack struct {
+	visible []toast
+	history []toast
+}
+
+func (t *toastStack) push(level toastLevel, text string) {
+	ttl := toastTTL
+	if level == toastError {
+		ttl = toastErrorTTL
+	}
+	now := time.Now()
+	n := toast{level: level, text: text, created: now, expires: now.Add(ttl)}
+
+	t.visible = append(t.visible, n)
+	if len(t.visible) > maxToasts {
+		t.visible = t.visible[len(t.visible)-maxToasts:]
+	}
+	t.history = append(t.history, n)
+	if len(t.history) > maxHistory {
+		t.history = t.history[len(t.history)-maxHistory:]
+	}
+}
+
+func (t *toastStack) info(text string)    { t.push(toastInfo, text) }
+func (t *toastStack) success(text string) { t.push(toastSuccess, text) }
+func (t *toastStack) error(err error)     { t.push(toastError, err.Error()) }
+
+// expire drops toasts whose lifetime has passed.
+func (t *toastStack) expire(now time.Time) {
+	kept := t.visible[:0]
+	for _, n := range t.visible {
+		if now.Before(n.expires) {
+			kept = append(kept, n)
+		}
+	}
+	t.visible = kept
+}
+
+// view renders the visible toasts stacked newest-last.
+func (t toastStack) view() string {
+	if len(t.visible) == 0 {
+		return ""
+	}
+	var rendered []string
+	for _, n := range t.visible {
+		rendered = append(rendered, toastStyle(n.level).Width(toastWidth).Render(n.text))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
+
+// historyView renders the message history for the Messages pane.
+func (t toastStack) historyView() string {
+	if len(t.history) == 0 {
+		return "No messages yet."
+	}
+	var b strings.Builder
+	for i := len(t.history) - 1; i >= 0; i-- {
+		n := t.history[i]
+		level := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(toastStyle(n.level).GetBorderTopForeground()).
+			Width(6).
+			Render(n.level.String())
+		fmt.Fprintf(&b, "%s %s %s\n", n.created.Format("15:04:05"), level, n.text)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func toastStyle(level toastLevel) lipgloss.Style {
+	switch level {
+	case toastSuccess:
+		return currentTheme.Toast.Success
+	case toastError:
+		return currentTheme.Toast.Error
+	}
+	return currentTheme.Toast.Info
+}
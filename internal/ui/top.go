@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// topRows caps the Top pane; the long tail of idle units is noise.
+const topRows = 30
+
+type topMsg struct {
+	usage []systemd.Usage
+	at    time.Time
+	err   error
+}
+
+func fetchTop() tea.Msg {
+	usage, err := systemd.UnitUsage()
+	return topMsg{usage: usage, at: time.Now(), err: err}
+}
+
+// topSample keeps two consecutive accounting snapshots: CPU load is the
+// difference in CPU time between them.
+type topSample struct {
+	prev, cur     map[string]systemd.Usage
+	prevAt, curAt time.Time
+	byMemory      bool
+}
+
+// add makes usage the current snapshot.
+func (s *topSample) add(usage []systemd.Usage, at time.Time) {
+	s.prev, s.prevAt = s.cur, s.curAt
+	s.cur = make(map[string]systemd.Usage, len(usage))
+	for _, u := range usage {
+		s.cur[u.Unit] = u
+	}
+	s.curAt = at
+}
+
+// topRow is one unit in the Top pane.
+type topRow struct {
+	usage systemd.Usage
+	cpu   float64 // percent of one CPU; negative until there are two samples
+}
+
+// rows returns the heaviest units, by CPU load or by memory.
+func (s topSample) rows() []topRow {
+	elapsed := s.curAt.Sub(s.prevAt)
+	var rows []topRow
+	for name, u := range s.cur {
+		r := topRow{usage: u, cpu: -1}
+		if p, ok := s.prev[name]; ok && u.HasCPU && p.HasCPU && elapsed > 0 && u.CPU >= p.CPU {
+			r.cpu = float64(u.CPU-p.CPU) / float64(elapsed) * 100
+		}
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if s.byMemory && a.usage.Memory != b.usage.Memory {
+			return a.usage.Memory > b.usage.Memory
+		}
+		if !s.byMemory && a.cpu != b.cpu {
+			return a.cpu > b.cpu
+		}
+		return a.usage.Unit < b.usage.Unit
+	})
+	return rows[:min(len(rows), topRows)]
+}
+
+// openTop shows the Top pane. Pressing the key again while it is open
+// switches between sorting by CPU and by memory.
+func (m *model) openTop() tea.Cmd {
+	if m.viewMode == ModeTop {
+		m.top.byMemory = !m.top.byMemory
+		m.viewport.SetContent(renderTop(m.top, m.viewport.Width))
+		return nil
+	}
+	m.viewMode = ModeTop
+	m.activePane = PaneContent
+	m.top = topSample{byMemory: m.top.byMemory}
+	m.viewport.SetContent("Reading cgroup accounting…")
+	return fetchTop
+}
+
+// topPicker jumps to one of the units shown in the Top pane.
+func topPicker(s topSample) *finder {
+	var labels []string
+	units := make(map[string]string)
+	for _, r := range s.rows() {
+		label := fmt.Sprintf("%s (%s)", r.usage.Unit, humanBytes(r.usage.Memory))
+		units[label] = r.usage.Unit
+		labels = append(labels, label)
+	}
+	return newFinder("jump to unit…", labels, func(label string) tea.Msg {
+		return jumpToUnitMsg{name: units[label]}
+	})
+}
+
+// renderTop draws the heaviest units with their CPU load and memory, like
+// systemd-cgtop.
+func renderTop(s topSample, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var b strings.Builder
+	sortedBy := "CPU"
+	if s.byMemory {
+		sortedBy = "memory"
+	}
+	b.WriteString(heading.Render("Top units by "+sortedBy) + "\n")
+	b.WriteString(dim.Render("From cgroup accounting, refreshed live; I/O and network are totals since start, – where that accounting is off (enable it from Details). ^: sort by CPU/memory · enter: jump to a unit") + "\n\n")
+	rows := s.rows()
+	if len(rows) == 0 {
+		b.WriteString(dim.Render("No accounting data; enable CPUAccounting/MemoryAccounting or DefaultCPUAccounting in system.conf.") + "\n")
+		return b.String()
+	}
+
+	var maxMem uint64
+	unitWidth := 0
+	for _, r := range rows {
+		maxMem = max(maxMem, r.usage.Memory)
+		unitWidth = max(unitWidth, lipgloss.Width(r.usage.Unit))
+	}
+	unitWidth = min(unitWidth, width/3)
+	barWidth := max(width-unitWidth-56, 0)
+
+	fmt.Fprintln(&b, dim.Render(fmt.Sprintf("%-*s  %6s  %8s %8s %8s %8s %8s", unitWidth, "UNIT", "CPU%", "MEMORY", "READ", "WRITE", "IN", "OUT")))
+	for _, r := range rows {
+		cpu := "     –"
+		if r.cpu >= 0 {
+			cpu = lipgloss.NewStyle().Foreground(usageColor(r.cpu)).Render(fmt.Sprintf("%6.1f", r.cpu))
+		}
+		mem := "       –"
+		bar := ""
+		if r.usage.HasMem {
+			mem = fmt.Sprintf("%8s", humanBytes(r.usage.Memory))
+			if maxMem > 0 {
+				bar = lipgloss.NewStyle().Foreground(purple).Render(strings.Repeat("█", int(float64(barWidth)*float64(r.usage.Memory)/float64(maxMem))))
+			}
+		}
+		io := dim.Render(fmt.Sprintf("%8s %8s", "–", "–"))
+		if r.usage.HasIO {
+			io = fmt.Sprintf("%8s %8s", humanBytes(r.usage.IORead), humanBytes(r.usage.IOWrite))
+		}
+		ip := dim.Render(fmt.Sprintf("%8s %8s", "–", "–"))
+		if r.usage.HasIP {
+			ip = fmt.Sprintf("%8s %8s", humanBytes(r.usage.IPIn), humanBytes(r.usage.IPOut))
+		}
+		name := fit(r.usage.Unit, unitWidth)
+		fmt.Fprintf(&b, "%s  %s  %s %s %s %s\n", name, cpu, mem, io, ip, bar)
+	}
+	return b.String()
+}
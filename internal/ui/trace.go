@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"regexp"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// traceSearchMsg asks for the journal lines of every unit mentioning a
+// trace ID.
+type traceSearchMsg struct {
+	id string
+}
+
+// traceID extracts the trace ID of a line: the pattern's first group, or
+// its whole match when it has no group.
+func traceID(re *regexp.Regexp, text string) (string, bool) {
+	match := re.FindStringSubmatch(text)
+	switch {
+	case match == nil:
+		return "", false
+	case len(match) > 1 && match[1] != "":
+		return match[1], true
+	}
+	return match[0], match[0] != ""
+}
+
+// tracePicker lists the log lines carrying a trace ID, newest first.
+func tracePicker(re *regexp.Regexp, lines []systemd.LogLine) *finder {
+	picks := make(map[string]string)
+	var labels []string
+	for i := len(lines) - 1; i >= 0; i-- {
+		id, ok := traceID(re, lines[i].Text)
+		if !ok {
+			continue
+		}
+		label := truncate(lines[i].Text, jsonLabelWidth)
+		if _, dup := picks[label]; !dup {
+			picks[label] = id
+			labels = append(labels, label)
+		}
+	}
+	return newFinder("trace ID of line…", labels, func(label string) tea.Msg {
+		return traceSearchMsg{id: picks[label]}
+	})
+}
+
+func fetchTrace(id string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := systemd.SearchJournal(id, journalSliceLimit)
+		return journalSliceMsg{title: "Trace " + id, lines: lines, highlight: id, err: err}
+	}
+}
@@ -1,16 +1,28 @@
 package ui
 
 import (
-	"context"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"strings"
+	"time"
+	"vigilix/internal/bootreport"
+	"vigilix/internal/certs"
+	"vigilix/internal/compose"
+	"vigilix/internal/config"
+	"vigilix/internal/history"
+	"vigilix/internal/i18n"
+	"vigilix/internal/notify"
+	"vigilix/internal/plugin"
+	"vigilix/internal/probe"
 	"vigilix/internal/systemd"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -18,98 +30,210 @@ import (
 	"github.com/shirou/gopsutil/v3/host"
 )
 
-// --- Color Scheme (Dracula-inspired) ---
+// --- Color Scheme ---
+// Set from the active palette by setTheme.
 var (
-	background = lipgloss.Color("#282a36")
-	current    = lipgloss.Color("#44475a")
-	foreground = lipgloss.Color("#f8f8f2")
-	comment    = lipgloss.Color("#6272a4")
-	cyan       = lipgloss.Color("#8be9fd")
-	green      = lipgloss.Color("#50fa7b")
-	orange     = lipgloss.Color("#ffb86c")
-	pink       = lipgloss.Color("#ff79c6")
-	purple     = lipgloss.Color("#2d57ff")
-	red        = lipgloss.Color("#ff5555")
-	yellow     = lipgloss.Color("#f1fa8c")
+	background lipgloss.Color
+	current    lipgloss.Color
+	foreground lipgloss.Color
+	comment    lipgloss.Color
+	cyan       lipgloss.Color
+	green      lipgloss.Color
+	orange     lipgloss.Color
+	pink       lipgloss.Color
+	purple     lipgloss.Color
+	red        lipgloss.Color
+	yellow     lipgloss.Color
 )
 
 // --- Styles ---
 var (
+	baseStyle         lipgloss.Style
+	panelStyle        lipgloss.Style
+	focusedPanelStyle lipgloss.Style
+	activeTabStyle    lipgloss.Style
+	inactiveTabStyle  lipgloss.Style
+	titleStyle        lipgloss.Style
+)
+
+// Panel Borders
+var panelBorder = lipgloss.Border{
+	Top:         "─",
+	Bottom:      "─",
+	Left:        "│",
+	Right:       "│",
+	TopLeft:     "╭",
+	TopRight:    "╮",
+	BottomLeft:  "╰",
+	BottomRight: "╯",
+}
+
+// buildStyles derives the shared styles from the current colors.
+func buildStyles() {
 	// Base
 	baseStyle = lipgloss.NewStyle().Foreground(foreground)
 
-	// Panel Borders
-	panelBorder = lipgloss.Border{
-		Top:         "─",
-		Bottom:      "─",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "╰",
-		BottomRight: "╯",
-	}
-
 	panelStyle = baseStyle.Copy().
-			Border(panelBorder).
-			BorderForeground(comment)
+		Border(panelBorder).
+		BorderForeground(comment)
 
 	focusedPanelStyle = panelStyle.Copy().
-				BorderForeground(purple)
+		BorderForeground(purple)
 
 	// Tabs
 	activeTabStyle = baseStyle.Copy().
-			Bold(true).
-			Foreground(background).
-			Background(purple).
-			Padding(0, 1)
+		Bold(true).
+		Foreground(background).
+		Background(purple).
+		Padding(0, 1)
 
 	inactiveTabStyle = baseStyle.Copy().
-				Foreground(comment).
-				Padding(0, 1)
+		Foreground(comment).
+		Padding(0, 1)
 
 	// Titles
 	titleStyle = baseStyle.Copy().
-			Bold(true).
-			Padding(0, 1).
-			Foreground(cyan)
-)
+		Bold(true).
+		Padding(0, 1).
+		Foreground(cyan)
+}
 
 // --- Help Keys ---
 type keyMap struct {
-	Up, Down, Left, Right key.Binding
-	Enter, Esc, Tab       key.Binding
-	Start, Stop, Restart  key.Binding
-	Config                key.Binding
-	Quit                  key.Binding
+	Up, Down, Left, Right  key.Binding
+	Enter, Esc, Tab        key.Binding
+	Start, Stop, Restart   key.Binding
+	Reload, ResetFailed    key.Binding
+	Enable, Undo, Drain    key.Binding
+	Config, Messages       key.Binding
+	Info, Find, Explain    key.Binding
+	Palette, Screenshot    key.Binding
+	Debug, Split           key.Binding
+	LogTimes, Correlate    key.Binding
+	Trace                  key.Binding
+	Details, LogStats      key.Binding
+	RestartFailed          key.Binding
+	Schedule, Scheduled    key.Binding
+	CancelScheduled        key.Binding
+	Compare                key.Binding
+	OpenPath, EditPath     key.Binding
+	Note, Silence, Export  key.Binding
+	Columns, Density       key.Binding
+	Trigger, JumpTrigger   key.Binding
+	Bus, FailedOnly        key.Binding
+	TypeFilter             key.Binding
+	Harden                 key.Binding
+	Mounts                 key.Binding
+	Remount, Unmount       key.Binding
+	Swap                   key.Binding
+	Jobs, CancelJob        key.Binding
+	Stalled, Top           key.Binding
+	Accounting             key.Binding
+	Containers, Firewall   key.Binding
+	Versions, Drift        key.Binding
+	AccessLog, StatusClass key.Binding
+	JSONLine, FieldFilter  key.Binding
+	Quit                   key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Tab, k.Enter, k.Config, k.Start, k.Stop, k.Quit}
+	return translated([]key.Binding{k.Tab, k.Enter, k.Config, k.Start, k.Stop, k.Quit})
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
+	groups := [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.Esc, k.Tab},
-		{k.Start, k.Stop, k.Restart, k.Config},
+		{k.Enter, k.Esc, k.Tab, k.Find, k.Palette, k.Screenshot, k.Debug},
+		{k.Start, k.Stop, k.Restart, k.Reload, k.ResetFailed, k.Enable, k.Undo, k.Drain, k.RestartFailed, k.FailedOnly, k.TypeFilter, k.Trigger, k.JumpTrigger, k.Stalled},
+		{k.Schedule, k.Scheduled, k.CancelScheduled},
+		{k.Config, k.Details, k.Explain, k.LogStats, k.Messages, k.Info, k.Compare, k.Bus, k.Mounts, k.Jobs, k.Top, k.Containers, k.Firewall, k.Versions, k.Drift},
+		{k.OpenPath, k.EditPath, k.Note, k.Silence, k.Export, k.Columns, k.Density, k.Harden, k.Accounting, k.AccessLog, k.StatusClass, k.Split, k.JSONLine, k.FieldFilter, k.LogTimes, k.Correlate, k.Trace},
 		{k.Quit},
 	}
+	for i, g := range groups {
+		groups[i] = translated(g)
+	}
+	return groups
+}
+
+// translated returns copies of the bindings with their help text run
+// through the message catalog.
+func translated(bindings []key.Binding) []key.Binding {
+	out := make([]key.Binding, len(bindings))
+	for i, b := range bindings {
+		b.SetHelp(b.Help().Key, i18n.T(b.Help().Desc))
+		out[i] = b
+	}
+	return out
 }
 
 var keys = keyMap{
-	Up:      key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
-	Down:    key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
-	Left:    key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "left")),
-	Right:   key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "right")),
-	Enter:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
-	Esc:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
-	Tab:     key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "switch pane")),
-	Start:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "start")),
-	Stop:    key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
-	Restart: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "restart")),
-	Config:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "config")),
-	Quit:    key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Left:     key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "left")),
+	Right:    key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "right")),
+	Enter:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+	Esc:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+	Tab:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "switch pane")),
+	Start:    key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "start")),
+	Stop:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
+	Restart:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "restart")),
+	Config:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "config")),
+	Messages: key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "messages")),
+	Info:     key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "expand row")),
+	Find:     key.NewBinding(key.WithKeys("ctrl+f"), key.WithHelp("ctrl+f", "find unit")),
+	Palette:  key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "command palette")),
+	Explain:  key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "why this state")),
+	Details:  key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "details")),
+	LogStats: key.NewBinding(key.WithKeys("%"), key.WithHelp("%", "log stats")),
+
+	RestartFailed:   key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "restart all failed")),
+	Schedule:        key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "schedule action")),
+	Scheduled:       key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "scheduled actions")),
+	CancelScheduled: key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "cancel scheduled")),
+	Compare:         key.NewBinding(key.WithKeys("="), key.WithHelp("=", "compare two units")),
+	OpenPath:        key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "view referenced path")),
+	EditPath:        key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit referenced path")),
+	Note:            key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "edit note")),
+	Silence:         key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "silence alerts")),
+	Export:          key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "export unit list")),
+	Trigger:         key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "start triggered unit")),
+	JumpTrigger:     key.NewBinding(key.WithKeys("J"), key.WithHelp("J", "jump to triggered unit")),
+	Bus:             key.NewBinding(key.WithKeys("B"), key.WithHelp("B", "D-Bus activatable")),
+	FailedOnly:      key.NewBinding(key.WithKeys("!"), key.WithHelp("!", "only failed units")),
+	Harden:          key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "harden service")),
+	Mounts:          key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "mounts & disk usage")),
+	Remount:         key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "remount")),
+	Unmount:         key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "unmount")),
+	Swap:            key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "swap on/off")),
+	Jobs:            key.NewBinding(key.WithKeys("Q"), key.WithHelp("Q", "job queue")),
+	CancelJob:       key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "cancel job")),
+	Top:             key.NewBinding(key.WithKeys("^"), key.WithHelp("^", "top units by CPU/memory")),
+	Accounting:      key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "enable I/O & IP accounting")),
+	AccessLog:       key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "access log columns")),
+	StatusClass:     key.NewBinding(key.WithKeys("0", "2", "3", "4", "5"), key.WithHelp("2-5/0", "filter status class")),
+	Containers:      key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "containers & compose projects")),
+	Firewall:        key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "firewall status")),
+	Screenshot:      key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save screenshot")),
+	Debug:           key.NewBinding(key.WithKeys("f12"), key.WithHelp("F12", "background tasks")),
+	Versions:        key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "unit file versions")),
+	Drift:           key.NewBinding(key.WithKeys("~"), key.WithHelp("~", "baseline drift")),
+	Columns:         key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "list columns")),
+	Density:         key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "compact/comfortable list")),
+	TypeFilter:      key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "filter by unit type")),
+	Reload:          key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "reload")),
+	ResetFailed:     key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "reset failed state")),
+	Enable:          key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "enable/disable")),
+	Undo:            key.NewBinding(key.WithKeys("ctrl+z"), key.WithHelp("ctrl+z", "undo stop/disable")),
+	Drain:           key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "drain & stop")),
+	Split:           key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "pin errors above logs")),
+	JSONLine:        key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "pretty-print JSON line")),
+	FieldFilter:     key.NewBinding(key.WithKeys("&"), key.WithHelp("&", "filter JSON log fields")),
+	LogTimes:        key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "log times: local/UTC/relative")),
+	Correlate:       key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "what else happened then")),
+	Trace:           key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "search trace ID in all units")),
+	Stalled:         key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "cancel/kill hung start")),
+	Quit:            key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
 }
 
 // --- Model ---
@@ -124,71 +248,118 @@ const (
 	ModeList
 	ModeLogs
 	ModeConfig
+	ModeDetails
+	ModeExplain
+	ModeLogStats
+	ModeScheduled
+	ModeCompare
+	ModeMessages
+	ModePlugin
+	ModeBus
+	ModeMounts
+	ModeJobs
+	ModeTop
+	ModeContainers
+	ModeFirewall
+	ModeVersions
+	ModeDrift
+	ModeJournal
+	ModeBoot
 )
 
+// tabs lists the content views in header order.
+var tabs = []struct {
+	mode  int
+	label string
+}{
+	{ModeLogs, " Logs "},
+	{ModeConfig, " Config "},
+	{ModeDetails, " Details "},
+	{ModeExplain, " Why "},
+	{ModeLogStats, " Stats "},
+	{ModeScheduled, " Scheduled "},
+	{ModeCompare, " Compare "},
+	{ModeBus, " D-Bus "},
+	{ModeMounts, " Mounts "},
+	{ModeJobs, " Jobs "},
+	{ModeTop, " Top "},
+	{ModeContainers, " Containers "},
+	{ModeFirewall, " Firewall "},
+	{ModeVersions, " Versions "},
+	{ModeDrift, " Drift "},
+	{ModeJournal, " Journal "},
+	{ModeBoot, " Boot "},
+	{ModeMessages, " Messages "},
+	{ModePlugin, " Plugin "},
+}
+
 type item struct {
-	unit systemd.Unit
+	unit    systemd.Unit
+	times   unitTimes
+	note    string
+	waiters int           // jobs waiting for this unit's start job
+	stalled time.Duration // how long it has been stuck starting or stopping
+	cert    *certs.Cert   // a certificate expiring soon
+	probes  []probe.Result
+	uptime  []config.Span
 }
 
 func (i item) Title() string {
-	// Icon + Name
-	icon := "📦"
-	name := strings.ToLower(i.unit.Name)
-	if strings.Contains(name, "docker") {
-		icon = "🐳"
-	}
-	if strings.Contains(name, "mongo") {
-		icon = "🍃"
-	}
-	if strings.Contains(name, "postgres") || strings.Contains(name, "psql") {
-		icon = "🐘"
-	}
-	if strings.Contains(name, "mysql") || strings.Contains(name, "mariadb") {
-		icon = "🐬"
-	}
-	if strings.Contains(name, "redis") {
-		icon = "🔺"
-	}
-	if strings.Contains(name, "nginx") {
-		icon = "🌐"
-	}
-	if strings.Contains(name, "apache") || strings.Contains(name, "httpd") {
-		icon = "🪶"
-	}
-	if strings.Contains(name, "ssh") {
-		icon = "🔒"
+	icon := unitIcon(i.unit.Name)
+	title := fmt.Sprintf("%s %s", icon, i.unit.Name)
+	if i.note != "" {
+		title += noteMarker
 	}
-	if strings.Contains(name, "node") || strings.Contains(name, "npm") {
-		icon = "🟢"
+	return title
+}
+
+func (i item) Description() string {
+	// State Mark + Active State + Description
+	return fmt.Sprintf("%s %s | %s", stateMark(i.unit.ActiveState), i.unit.ActiveState, i.description())
+}
+
+// FilterValue also matches the path behind mount and device units, so
+// "/mnt/data" finds mnt-data.mount.
+func (i item) FilterValue() string {
+	if path, ok := systemd.UnitPath(i.unit.Name); ok {
+		return i.unit.Name + " " + path
 	}
-	if strings.Contains(name, "python") {
-		icon = "🐍"
+	return i.unit.Name
+}
+
+// description is the unit's description, falling back to the readable path
+// for mount and device units that lack one.
+func (i item) description() string {
+	if i.unit.Description != "" && i.unit.Description != i.unit.Name {
+		return i.unit.Description
 	}
-	if strings.Contains(name, "go") {
-		icon = "🐹"
+	if path, ok := systemd.UnitPath(i.unit.Name); ok {
+		return path
 	}
+	return i.unit.Description
+}
 
-	return fmt.Sprintf("%s %s", icon, i.unit.Name)
+// itemDelegate draws a unit as its name and state badge over a line of
+// the other columns, or all on one line when compact.
+type itemDelegate struct {
+	columns []config.Column
+	compact bool
 }
 
-func (i item) Description() string {
-	// Status Dot + Load State + Description
-	status := "⚪"
-	if i.unit.ActiveState == "active" {
-		status = "🟢"
-	} else if i.unit.ActiveState == "failed" {
-		status = "🔴"
+func (d itemDelegate) Height() int {
+	if d.compact {
+		return 1
 	}
-
-	return fmt.Sprintf("%s %s | %s", status, i.unit.ActiveState, i.unit.Description)
+	return 2
 }
 
-func (i item) FilterValue() string { return i.unit.Name }
-
-type itemDelegate struct{}
+func (d itemDelegate) Spacing() int {
+	if d.compact {
+		return 0
+	}
+	return 1
+}
 
-func (d itemDelegate) Height() int                               { return 2 }
-func (d itemDelegate) Spacing() int                              { return 1 }
 func (d itemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
 func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	i, ok := listItem.(item)
@@ -206,27 +377,13 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	titleStyle := baseStyle.Copy().Bold(true)
 	descStyle := baseStyle.Copy().Foreground(comment)
 
-	// Status Badge
-	activeState := i.unit.ActiveState
-	statusColor := comment
-	statusFg := foreground
-
-	switch activeState {
-	case "active":
-		statusColor = green
-		statusFg = lipgloss.Color("#000000")
-	case "failed":
-		statusColor = red
-	case "inactive":
-		statusColor = lipgloss.Color("#44475a") // Dark gray
+	statusBadge := ""
+	if hasColumn(d.columns, "state") {
+		statusBadge = stateBadge(i.unit.ActiveState)
+	}
+	if spark := probeBadge(i.probes); spark != "" {
+		statusBadge = strings.TrimSpace(spark + " " + statusBadge)
 	}
-
-	statusBadge := lipgloss.NewStyle().
-		Background(statusColor).
-		Foreground(statusFg).
-		Padding(0, 1).
-		Bold(true).
-		Render(strings.ToUpper(activeState))
 
 	// Selection Special Handling
 	isSelected := index == m.Index()
@@ -252,19 +409,35 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		innerWidth = 0
 	}
 
-	// 4. Layout Line 1 (Title + Badge)
+	// 4. Stall + Waiters, ahead of the columns
+	waiting := ""
+	if i.stalled > 0 {
+		waiting = lipgloss.NewStyle().Foreground(red).Bold(true).Render("⚠ stalled") + " · "
+	}
+	if i.cert != nil {
+		waiting += certBadge(*i.cert, time.Now()) + " · "
+	}
+	if i.waiters > 0 {
+		waiting += lipgloss.NewStyle().Foreground(orange).Bold(true).Render(fmt.Sprintf("⚠ %d waiting", i.waiters)) + " · "
+	}
 	badgeWidth := lipgloss.Width(statusBadge)
-	availableTitleWidth := innerWidth - badgeWidth - 2 // 2 chars gap
 
-	titleStr := i.Title()
-	if lipgloss.Width(titleStr) > availableTitleWidth {
-		if availableTitleWidth > 3 {
-			titleStr = titleStr[:availableTitleWidth-3] + "..."
-		} else {
-			titleStr = "" // Hide if too small
-		}
+	// Compact: Title, then the columns, then the badge, on a single line.
+	// Titles get a fixed share so that the columns line up.
+	if d.compact {
+		titleWidth := innerWidth * 2 / 5
+		restWidth := max(innerWidth-titleWidth-badgeWidth-3, 0)
+		rest := truncate(waiting+descStyle.Render(i.columnLine(d.columns, time.Now())), restWidth)
+		content := titleStyle.Render(fit(i.Title(), titleWidth)) + "  " + pad(rest, restWidth) + " " + statusBadge
+		fmt.Fprint(w, itemStyle.Width(innerWidth+itemStyle.GetHorizontalPadding()).Render(content))
+		return
 	}
 
+	// 5. Layout Line 1 (Title + Badge)
+	availableTitleWidth := innerWidth - badgeWidth - 2 // 2 chars gap
+
+	titleStr := truncate(i.Title(), availableTitleWidth)
+
 	left1 := titleStyle.Render(titleStr)
 	gapSize := innerWidth - lipgloss.Width(left1) - badgeWidth
 	if gapSize < 0 {
@@ -274,31 +447,34 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 	line1 := left1 + gap + statusBadge
 
-	// 5. Layout Line 2 (Description)
-	descStr := i.unit.Description
-	if lipgloss.Width(descStr) > innerWidth {
-		if innerWidth > 3 {
-			descStr = descStr[:innerWidth-3] + "..."
-		} else {
-			descStr = ""
-		}
-	}
-	line2 := descStyle.Render(descStr)
+	// 6. Layout Line 2 (Columns)
+	descWidth := innerWidth - lipgloss.Width(waiting)
+	descStr := truncate(i.columnLine(d.columns, time.Now()), descWidth)
+	line2 := waiting + descStyle.Render(descStr)
 
-	// 6. Combine and Render
+	// 7. Combine and Render
 	content := fmt.Sprintf("%s\n%s", line1, line2)
 
-	// Force the style to take full width so background fills properly
-	fmt.Fprint(w, itemStyle.Width(innerWidth).Render(content))
+	// Force the style to take full width so background fills properly. The
+	// width includes the padding, so lines of exactly innerWidth never wrap.
+	fmt.Fprint(w, itemStyle.Width(innerWidth+itemStyle.GetHorizontalPadding()).Render(content))
 }
 
+// Shown when an action is attempted on an offline journal.
+const offlineActions = "Actions are disabled while browsing an offline journal"
+
 type errMsg error
 type actionResultMsg struct {
 	err    error
 	action string
+	unit   string
+}
+type configMsg struct {
+	unit    string
+	content string
+	quadlet string // the Quadlet file the unit was generated from
+	source  string // its content
 }
-type logLineMsg string
-type configMsg string
 type statsMsg struct {
 	hostname string
 	os       string
@@ -317,30 +493,109 @@ type model struct {
 	activePane int
 	viewMode   int
 	devMode    bool
+	failedOnly bool
+	typeFilter string // unit type the list is limited to, e.g. "timer"
 
 	// Layout
 	width, height int
 
 	// Data
 	allUnits      []systemd.Unit
-	logLines      []string
+	unitTimes     map[string]unitTimes
+	cfg           config.Config
+	plugins       []config.Plugin
+	notes         map[string]string
+	busServices   []systemd.BusService
+	mounts        []systemd.Mount
+	swaps         []systemd.Swap
+	jobs          []systemd.Job
+	top           topSample
+	stalls        map[string]bool // stalled units already alerted on
+	certs         map[string][]certs.Cert
+	certAlerts    map[string]bool // unit and source of certificates alerted on
+	probes        map[string][]probe.Result
+	timings       map[string][]config.Timing
+	uptime        config.Uptime
+	uptimeSaved   time.Time
+	historyBatch  history.Batch // records not written to the history store yet
+	historyPruned bool          // retention applied this session
+	update        string        // newer release tag, if the update check found one
+	systemState   string        // from systemctl is-system-running
+	silences      []notify.Silence
+	clock         systemd.ClockStatus
+	logLines      []systemd.LogLine
+	olderLoaded   int // lines loaded above the stream's start
+	loadingOlder  bool
+	logMatches    map[string]bool
+	logCache      logCache     // buffers of units whose logs were shown before
+	splitLogs     bool         // errors and warnings pinned above the logs
+	logFilter     []fieldMatch // JSON field terms the shown log lines match
+	logTimes      timeMode     // how log timestamps are shown
+	contentRows   int          // height of the content pane's viewport when not split
 	configContent string
+	bootReport    bootreport.Report
+	configUnit    string
+	quadletSource string
+	quadletText   string
 	streamingUnit string
+	projects      []compose.Project
+	firewalls     []systemd.Firewall
+	drift         driftMsg
+	columns       []config.Column
+	compact       bool // one line per unit
+	versions      versionsMsg
+	reconnecting  bool
+	accessLog     bool // requests as columns, for web servers
+	accessClass   int  // status class shown in the access log, 0 for all
+	reconnects    int  // consecutive failed reconnect attempts
 	stats         statsMsg
+	statsWindow   systemd.StatsWindow
+	expanded      *expandedRow
+	finder        *finder
+	dialog        *dialog
+	prompt        *prompt
+	checklist     *checklist
+	compareMark   string // first unit picked for comparison
+
+	// Session restore, applied once the first unit list arrives
+	restore       *config.Session
+	restoreOffset int
 
 	// Async
-	logCtx    context.Context
-	logCancel context.CancelFunc
-	logChan   chan string
+	streams *streamManager
 
 	// Meta
-	err           error
-	statusMessage string
+	err    error
+	status statusBar
+	toasts toastStack
+	busy   int // in-flight background commands
+
+	// Recent stops and disables that can still be undone
+	undo undoJournal
+
+	// When every unit was last refreshed, rather than only the watched ones
+	lastFullRefresh time.Time
+
+	// Idle: the terminal is in the background, the spinner's ticks were
+	// dropped while it was hidden, and the polling ticks held back until
+	// it comes back
+	blurred, spinnerPaused bool
+	pausedTicks            []tea.Msg
 }
 
-func NewModel() model {
+func NewModel(cfg config.Config) model {
+	// 0. Theme, before any styles are copied into components. Consoles that
+	// need ASCII rarely do more than 16 colors, so they get the contrast one.
+	setASCII(cfg.ASCII)
+	themeName := cfg.Theme
+	if cfg.ASCII {
+		themeName = "contrast"
+	}
+	themeErr := setTheme(themeName)
+
 	// 1. List - Custom Delegate
-	delegate := itemDelegate{}
+	columns, columnsErr := validColumns(cfg.Columns)
+	delegate := itemDelegate{columns: columns, compact: cfg.Compact}
 
 	l := list.New(nil, delegate, 0, 0)
 	l.Title = "Units"
@@ -360,39 +615,239 @@ func NewModel() model {
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(pink)
 
-	return model{
-		list:          l,
-		viewport:      vp,
-		help:          help.New(),
-		spinner:       s,
-		activePane:    PaneList,
-		viewMode:      ModeDashboard,
-		devMode:       true,
-		logLines:      []string{},
-		statusMessage: "Ready",
+	m := model{
+		cfg:        cfg,
+		list:       l,
+		columns:    columns,
+		compact:    cfg.Compact,
+		viewport:   vp,
+		help:       help.New(),
+		spinner:    s,
+		activePane: PaneList,
+		viewMode:   ModeDashboard,
+		devMode:    true,
+		logLines:   []systemd.LogLine{},
+		streams:    &streamManager{},
+		busy:       1, // initial fetchUnits
+	}
+
+	if themeErr != nil {
+		m.toasts.error(themeErr)
+	}
+	if columnsErr != nil {
+		m.toasts.error(columnsErr)
+	}
+	if err := setIcons(cfg.Icons); err != nil {
+		m.toasts.error(err)
+	}
+	// Plugins are listed in the palette by name, so only the first of a
+	// name is kept.
+	pluginNames := make(map[string]bool)
+	for _, p := range cfg.Plugins {
+		if err := plugin.Validate(p); err != nil {
+			m.toasts.error(err)
+			continue
+		}
+		if pluginNames[p.Name] {
+			m.toasts.error(fmt.Errorf("plugin %q is defined more than once; only the first is used", p.Name))
+			continue
+		}
+		pluginNames[p.Name] = true
+		m.plugins = append(m.plugins, p)
+	}
+
+	// 4. Last session
+	session, err := config.LoadSession()
+	if err != nil {
+		m.toasts.error(fmt.Errorf("restoring session: %w", err))
+	}
+	m.restore = session
+	if cfg.Inline {
+		// Inline mode has no dashboard or content views to restore.
+		m.viewMode = ModeList
+		if session != nil {
+			m.restore = &config.Session{DevMode: session.DevMode, Filter: session.Filter, SelectedUnit: session.SelectedUnit}
+		}
+	}
+
+	// 5. Unit notes
+	if m.notes, err = config.LoadNotes(); err != nil {
+		m.toasts.error(fmt.Errorf("loading notes: %w", err))
+	}
+
+	// 6. Alert silences
+	if m.silences, err = notify.LoadSilences(); err != nil {
+		m.toasts.error(fmt.Errorf("loading silences: %w", err))
+	}
+
+	// 7. Action timing history
+	if m.timings, err = config.LoadTimings(); err != nil {
+		m.toasts.error(fmt.Errorf("loading timings: %w", err))
+	}
+
+	// 8. Availability and state history
+	historyEnabled = cfg.History && !systemd.Offline()
+	unitVersionsEnabled = cfg.UnitVersions && !systemd.Offline()
+	if m.uptime, err = loadUptime(historyEnabled); err != nil {
+		m.toasts.error(fmt.Errorf("loading availability history: %w", err))
+		m.uptime = config.Uptime{}
 	}
+	m.uptimeSaved = time.Now()
+
+	return m
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		fetchUnits,
 		m.spinner.Tick,
 		fetchStats,
-	)
+		fetchSystemState,
+		fetchJobs,
+		fetchTimeSync,
+		clockTick(m.clockInterval()),
+		refreshTick(m.refreshInterval()),
+	}
+	if m.cfg.CheckUpdates {
+		cmds = append(cmds, checkUpdate)
+	}
+	if len(m.cfg.Certificates) > 0 {
+		cmds = append(cmds, fetchCerts(m.cfg.Certificates))
+	}
+	if len(m.cfg.Probes) > 0 {
+		cmds = append(cmds, fetchProbes(m.cfg.Probes, m.cfg.ProbeInterval()))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case spinner.TickMsg:
+		if !m.spinnerShown() {
+			m.spinnerPaused = true
+			return m, nil
+		}
+	case tea.FocusMsg, tea.BlurMsg:
+		_, focused := msg.(tea.FocusMsg)
+		cmd := m.setFocus(focused)
+		return resumeSpinner(m, cmd)
+	}
+	if m.cfg.ScreenReader {
+		return resumeSpinner(m.announce(msg))
+	}
+	if m.cfg.Inline {
+		return resumeSpinner(m.inline(msg))
+	}
+	return resumeSpinner(fitViewport(m.handle(msg)))
+}
+
+func (m model) handle(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The finder overlay captures all input except ctrl+c
+		if m.finder != nil && msg.String() != "ctrl+c" {
+			return m, m.finder.Update(msg)
+		}
+
+		// Modal dialogs capture all input except ctrl+c
+		if m.dialog != nil && msg.String() != "ctrl+c" {
+			cmd, closed := m.dialog.handleKey(msg)
+			if closed {
+				m.dialog = nil
+			}
+			if cmd != nil {
+				m.busy++
+			}
+			return m, cmd
+		}
+
+		if m.prompt != nil && msg.String() != "ctrl+c" {
+			cmd, closed := m.prompt.handleKey(msg)
+			if closed {
+				m.prompt = nil
+				if cmd != nil {
+					m.busy++
+				}
+			}
+			return m, cmd
+		}
+
+		if m.checklist != nil && msg.String() != "ctrl+c" {
+			cmd, closed := m.checklist.handleKey(msg)
+			if closed {
+				m.checklist = nil
+				if cmd != nil {
+					m.busy++
+				}
+			}
+			return m, cmd
+		}
+
 		// Global Quit
 		if key.Matches(msg, keys.Quit) {
-			if m.logCancel != nil {
-				m.logCancel()
+			stopped := m.streams.stop()
+			if !m.cfg.Inline {
+				m.saveSession() // best effort; there is nowhere left to report errors
+			}
+			m.saveUptime()
+			return m, tea.Sequence(stopped, tea.Quit)
+		}
+
+		// Fuzzy finder, available everywhere
+		if key.Matches(msg, keys.Find) {
+			m.expanded = nil
+			m.finder = newFinder("jump to unit…", m.unitNames(), func(name string) tea.Msg {
+				return jumpToUnitMsg{name: name}
+			})
+			if m.viewMode == ModeDashboard {
+				m.viewMode = ModeList
+			}
+			return m, textinput.Blink
+		}
+
+		// Command palette, available everywhere except while typing a filter
+		if key.Matches(msg, keys.Palette) && !m.list.SettingFilter() {
+			m.expanded = nil
+			m.finder = m.commandPalette()
+			if m.viewMode == ModeDashboard {
+				m.viewMode = ModeList
+			}
+			return m, textinput.Blink
+		}
+
+		// Screenshot of the current frame, available everywhere
+		if key.Matches(msg, keys.Screenshot) {
+			m.prompt = m.screenshotPrompt()
+			return m, textinput.Blink
+		}
+
+		// Background work, for debugging hangs; available everywhere
+		if key.Matches(msg, keys.Debug) {
+			m.dialog = m.debugDialog()
+			return m, nil
+		}
+
+		// Undo the last stop or disable, available everywhere
+		if key.Matches(msg, keys.Undo) {
+			if e, ok := m.undo.pop(time.Now()); ok {
+				m.busy++
+				return m, e.undo()
+			}
+			m.status.setMessage("Nothing to undo")
+			return m, nil
+		}
+
+		// Batch restart of failed units, available everywhere
+		if key.Matches(msg, keys.RestartFailed) && !m.list.SettingFilter() {
+			if systemd.Offline() {
+				m.status.setMessage(i18n.T(offlineActions))
+				return m, nil
 			}
-			return m, tea.Quit
+			m.dialog = m.restartFailedDialog()
+			return m, nil
 		}
 
 		// Dashboard Interaction
@@ -402,10 +857,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewMode = ModeList
 				m.activePane = PaneList
 				return m, nil
+			case "!":
+				m.viewMode = ModeList
+				m.activePane = PaneList
+				m.failedOnly = true
+				return m, m.updateListItems()
 			}
 			return m, nil
 		}
 
+		// An expanded row collapses on the next keypress
+		if m.expanded != nil {
+			m.expanded = nil
+			return m, nil
+		}
+
 		// Global Tab Navigation
 		if key.Matches(msg, keys.Tab) {
 			if m.activePane == PaneList {
@@ -419,9 +885,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Filter Toggle (d)
 		if msg.String() == "d" {
 			m.devMode = !m.devMode
-			m.updateListItems()
-			m.statusMessage = fmt.Sprintf("Dev Mode: %v", m.devMode)
-			return m, nil
+			m.status.setMessage(fmt.Sprintf("Dev Mode: %v", m.devMode))
+			return m, m.updateListItems()
 		}
 
 		// If filtering, list handles input
@@ -430,6 +895,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Failed-only filter, also reached from the degraded banner
+		if key.Matches(msg, keys.FailedOnly) {
+			m.failedOnly = !m.failedOnly
+			return m, m.updateListItems()
+		}
+		if key.Matches(msg, keys.TypeFilter) {
+			m.finder = typePicker(m.allUnits)
+			return m, textinput.Blink
+		}
+
+		// Referenced paths of the unit file shown in the Config view
+		if m.viewMode == ModeConfig && (key.Matches(msg, keys.OpenPath) || key.Matches(msg, keys.EditPath)) {
+			refs := m.configPaths()
+			if len(refs) == 0 {
+				m.status.setMessage("No paths referenced by this unit file")
+				return m, nil
+			}
+			if key.Matches(msg, keys.OpenPath) {
+				m.finder = pathPicker(refs, "view path…", func(path string) tea.Msg {
+					return readPath(path)
+				})
+			} else {
+				m.finder = pathPicker(refs, "edit path in $EDITOR…", func(path string) tea.Msg {
+					return editPathMsg{path: path}
+				})
+			}
+			return m, textinput.Blink
+		}
+
 		switch m.activePane {
 		case PaneList:
 			switch {
@@ -437,26 +931,218 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewMode = ModeLogs
 				m.activePane = PaneContent
 				if i, ok := m.list.SelectedItem().(item); ok {
-					m.startStreaming(i.unit.Name)
-					cmds = append(cmds, waitForLogLine(m.logChan))
+					cmds = append(cmds, m.startStreaming(i.unit.Name))
 				}
 			case key.Matches(msg, keys.Config):
 				m.viewMode = ModeConfig
 				m.activePane = PaneContent
 				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
 					cmds = append(cmds, fetchConfig(i.unit.Name))
 				}
+			case key.Matches(msg, keys.Versions):
+				m.viewMode = ModeVersions
+				m.activePane = PaneContent
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
+					cmds = append(cmds, fetchVersions(i.unit.Name))
+				}
+			case key.Matches(msg, keys.Info):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.expanded = &expandedRow{name: i.unit.Name}
+					m.busy++
+					return m, fetchExpandProps(i.unit.Name)
+				}
+			case key.Matches(msg, keys.Details):
+				m.viewMode = ModeDetails
+				m.activePane = PaneContent
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
+					m.viewport.SetContent("Loading " + i.unit.Name + "…")
+					cmds = append(cmds, fetchDetails(i.unit.Name))
+				}
+			case key.Matches(msg, keys.Explain):
+				m.viewMode = ModeExplain
+				m.activePane = PaneContent
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
+					m.viewport.SetContent("Analyzing " + i.unit.Name + "…")
+					cmds = append(cmds, fetchExplain(i.unit.Name))
+				}
+			case key.Matches(msg, keys.LogStats):
+				cmds = append(cmds, m.openLogStats())
+			case systemd.Offline() && (key.Matches(msg, keys.Schedule) || key.Matches(msg, keys.CancelScheduled) || key.Matches(msg, keys.Harden) || key.Matches(msg, keys.Stalled)):
+				m.status.setMessage(i18n.T(offlineActions))
+			case key.Matches(msg, keys.Harden):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					if !strings.HasSuffix(i.unit.Name, ".service") {
+						m.status.setMessage("Only services can be hardened")
+						return m, nil
+					}
+					m.busy++
+					m.status.setMessage("Analyzing " + i.unit.Name + "…")
+					cmds = append(cmds, fetchHardening(i.unit.Name))
+				}
+			case key.Matches(msg, keys.Stalled):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					if f := m.stallPicker(i.unit); f != nil {
+						m.finder = f
+						return m, textinput.Blink
+					}
+					m.status.setMessage(i.unit.Name + " is not starting or stopping")
+				}
+			case key.Matches(msg, keys.Schedule):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.prompt = schedulePrompt(i.unit.Name)
+					return m, textinput.Blink
+				}
+			case key.Matches(msg, keys.Scheduled):
+				m.viewMode = ModeScheduled
+				m.activePane = PaneContent
+				m.busy++
+				cmds = append(cmds, fetchScheduled(false))
+			case key.Matches(msg, keys.CancelScheduled):
+				m.busy++
+				cmds = append(cmds, fetchScheduled(true))
+			case key.Matches(msg, keys.Compare):
+				i, ok := m.list.SelectedItem().(item)
+				switch {
+				case !ok:
+				case m.compareMark == "" || m.compareMark == i.unit.Name:
+					m.compareMark = i.unit.Name
+					m.status.setMessage("Comparing " + i.unit.Name + " — press = on a second unit")
+				default:
+					m.viewMode = ModeCompare
+					m.activePane = PaneContent
+					m.busy++
+					cmds = append(cmds, fetchCompare(m.compareMark, i.unit.Name))
+					m.compareMark = ""
+				}
+			case key.Matches(msg, keys.JumpTrigger):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					if !systemd.IsTrigger(i.unit.Name) {
+						m.status.setMessage("Only socket, path and timer units trigger other units")
+						break
+					}
+					m.busy++
+					cmds = append(cmds, fetchTriggered(i.unit.Name))
+				}
+			case key.Matches(msg, keys.Note):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.prompt = notePrompt(i.unit.Name, m.notes[i.unit.Name])
+					return m, textinput.Blink
+				}
+			case key.Matches(msg, keys.Columns):
+				m.checklist = columnsChecklist(m.columns)
+				return m, nil
+			case key.Matches(msg, keys.Density):
+				m.compact = !m.compact
+				m.list.SetDelegate(m.delegate())
+				m.busy++
+				cmds = append(cmds, saveDensity(m.compact))
+			case key.Matches(msg, keys.Silence):
+				name := "*"
+				if i, ok := m.list.SelectedItem().(item); ok {
+					name = i.unit.Name
+				}
+				m.prompt = silencePrompt(name)
+				return m, textinput.Blink
+			case key.Matches(msg, keys.Export):
+				m.prompt = m.exportPrompt()
+				return m, textinput.Blink
+			case key.Matches(msg, keys.Bus):
+				m.viewMode = ModeBus
+				m.activePane = PaneContent
+				m.busy++
+				cmds = append(cmds, fetchBus)
+			case key.Matches(msg, keys.Jobs):
+				m.viewMode = ModeJobs
+				m.activePane = PaneContent
+				m.viewport.SetContent(renderJobs(m.jobs, m.viewport.Width))
+				m.viewport.GotoTop()
+				cmds = append(cmds, fetchJobs)
+			case key.Matches(msg, keys.Top):
+				cmds = append(cmds, m.openTop())
+			case key.Matches(msg, keys.Containers):
+				m.viewMode = ModeContainers
+				m.activePane = PaneContent
+				m.busy++
+				cmds = append(cmds, fetchContainers)
+			case key.Matches(msg, keys.Firewall):
+				m.viewMode = ModeFirewall
+				m.activePane = PaneContent
+				m.busy++
+				cmds = append(cmds, fetchFirewall)
+			case key.Matches(msg, keys.Drift):
+				m.viewMode = ModeDrift
+				m.activePane = PaneContent
+				m.busy++
+				cmds = append(cmds, fetchDrift(m.cfg.Baseline))
+			case key.Matches(msg, keys.Mounts):
+				m.viewMode = ModeMounts
+				m.activePane = PaneContent
+				m.busy++
+				cmds = append(cmds, fetchMounts)
+			case key.Matches(msg, keys.Messages):
+				m.viewMode = ModeMessages
+				m.activePane = PaneContent
+				m.refreshMessages()
+			case systemd.Offline() && (key.Matches(msg, keys.Start) || key.Matches(msg, keys.Stop) || key.Matches(msg, keys.Restart) ||
+				key.Matches(msg, keys.Reload) || key.Matches(msg, keys.ResetFailed) || key.Matches(msg, keys.Enable) || key.Matches(msg, keys.Drain) || key.Matches(msg, keys.Trigger)):
+				m.status.setMessage(i18n.T(offlineActions))
 			case key.Matches(msg, keys.Start):
 				if i, ok := m.list.SelectedItem().(item); ok {
-					cmds = append(cmds, performAction(systemd.StartUnit, i.unit.Name, "Started"))
+					m.busy++
+					cmds = append(cmds, timedAction("start", i.unit.Name, "Started"))
 				}
 			case key.Matches(msg, keys.Stop):
 				if i, ok := m.list.SelectedItem().(item); ok {
-					cmds = append(cmds, performAction(systemd.StopUnit, i.unit.Name, "Stopped"))
+					m.busy++
+					cmds = append(cmds, timedAction("stop", i.unit.Name, "Stopped"))
 				}
 			case key.Matches(msg, keys.Restart):
 				if i, ok := m.list.SelectedItem().(item); ok {
-					cmds = append(cmds, performAction(systemd.RestartUnit, i.unit.Name, "Restarted"))
+					m.busy++
+					cmds = append(cmds, timedAction("restart", i.unit.Name, "Restarted"))
+				}
+			case key.Matches(msg, keys.Reload):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
+					cmds = append(cmds, performAction(systemd.ReloadUnit, i.unit.Name, "Reloaded"))
+				}
+			case key.Matches(msg, keys.ResetFailed):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
+					cmds = append(cmds, performAction(systemd.ResetFailed, i.unit.Name, "Reset the failed state of"))
+				}
+			case key.Matches(msg, keys.Enable):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					m.busy++
+					if i.times.enabled == "enabled" {
+						cmds = append(cmds, performAction(systemd.DisableUnit, i.unit.Name, "Disabled"))
+					} else {
+						cmds = append(cmds, performAction(systemd.EnableUnit, i.unit.Name, "Enabled"))
+					}
+				}
+			case key.Matches(msg, keys.Drain):
+				// G is also the list's go-to-end key; the selection must stay
+				// on the unit being drained.
+				if i, ok := m.list.SelectedItem().(item); ok {
+					if d, ok := m.cfg.Drains[i.unit.Name]; ok {
+						m.dialog = drainDialog(i.unit.Name, d)
+					} else {
+						m.status.setMessage("No drain is configured for " + i.unit.Name + "; add one under \"drains\" in the config")
+					}
+				}
+				return m, tea.Batch(cmds...)
+			case key.Matches(msg, keys.Trigger):
+				if i, ok := m.list.SelectedItem().(item); ok {
+					if !systemd.IsTrigger(i.unit.Name) {
+						m.status.setMessage("Only socket, path and timer units trigger other units")
+						break
+					}
+					m.busy++
+					cmds = append(cmds, performAction(startTriggered, i.unit.Name, "Started the triggered"))
 				}
 			}
 			m.list, cmd = m.list.Update(msg)
@@ -467,6 +1153,164 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.activePane = PaneList
 				return m, nil
 			}
+			if m.viewMode == ModeLogStats && key.Matches(msg, keys.LogStats) {
+				return m, m.openLogStats()
+			}
+			if m.viewMode == ModeTop && key.Matches(msg, keys.Top) {
+				return m, m.openTop()
+			}
+			if m.viewMode == ModeTop && key.Matches(msg, keys.Enter) {
+				m.finder = topPicker(m.top)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeDetails && key.Matches(msg, keys.Accounting) {
+				i, ok := m.list.SelectedItem().(item)
+				switch {
+				case systemd.Offline():
+					m.status.setMessage(i18n.T(offlineActions))
+				case !ok:
+				case !systemd.OwnsCgroup(i.unit.Name):
+					m.status.setMessage(i.unit.Name + " has no cgroup to account")
+				default:
+					m.dialog = enableAccountingDialog(i.unit.Name)
+				}
+				return m, nil
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, m.viewport.KeyMap.PageUp) && m.viewport.AtTop() {
+				return m, m.loadOlderLogs()
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.JSONLine) {
+				m.finder = jsonLinePicker(m.visibleLogs())
+				if len(m.finder.candidates) == 0 {
+					m.finder = nil
+					m.status.setMessage("No JSON lines in the log")
+					return m, nil
+				}
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.Correlate) {
+				m.finder = correlatePicker(m.visibleLogs())
+				if len(m.finder.candidates) == 0 {
+					m.finder = nil
+					m.status.setMessage("No log lines with a timestamp")
+					return m, nil
+				}
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeBoot && key.Matches(msg, keys.Export) {
+				m.prompt = bootReportPrompt(m.bootReport)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.Trace) {
+				re, err := m.cfg.TraceRegexp()
+				if err != nil {
+					m.notifyError(fmt.Errorf("trace_pattern: %w", err))
+					return m, nil
+				}
+				m.finder = tracePicker(re, m.visibleLogs())
+				if len(m.finder.candidates) == 0 {
+					m.finder = nil
+					m.status.setMessage("No trace IDs in the log")
+					return m, nil
+				}
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.FieldFilter) {
+				m.prompt = logFilterPrompt(m.logFilter)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.LogTimes) {
+				m.logTimes = m.logTimes.next()
+				m.viewport.SetContent(m.logContent())
+				m.status.setMessage("Log timestamps: " + m.logTimes.String())
+				return m, nil
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.Split) {
+				m.splitLogs = !m.splitLogs
+				return m, nil
+			}
+			if m.viewMode == ModeLogs && key.Matches(msg, keys.AccessLog) {
+				if !isWebServer(m.streamingUnit) {
+					m.status.setMessage("The access log view is for nginx and Apache units")
+					return m, nil
+				}
+				m.accessLog = !m.accessLog
+				m.viewport.SetContent(m.logContent())
+				m.viewport.GotoBottom()
+				return m, nil
+			}
+			if m.viewMode == ModeLogs && m.accessLog && key.Matches(msg, keys.StatusClass) {
+				m.accessClass = int(msg.String()[0] - '0')
+				m.viewport.SetContent(m.logContent())
+				m.viewport.GotoBottom()
+				return m, nil
+			}
+			if m.viewMode == ModeContainers && key.Matches(msg, keys.Enter) {
+				if len(m.projects) == 0 {
+					m.status.setMessage("No compose projects")
+					return m, nil
+				}
+				m.finder = composePicker(m.projects)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeVersions && key.Matches(msg, keys.Enter) {
+				if len(m.versions.versions) < 2 {
+					m.status.setMessage("No earlier versions to roll back to")
+					return m, nil
+				}
+				if systemd.Offline() {
+					m.status.setMessage(i18n.T(offlineActions))
+					return m, nil
+				}
+				m.finder = versionPicker(m.versions.unit, m.versions.versions)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeDrift && key.Matches(msg, keys.Enter) {
+				if systemd.Offline() {
+					m.status.setMessage(i18n.T(offlineActions))
+					return m, nil
+				}
+				m.checklist = reconcileChecklist(m.drift.drifts)
+				if m.checklist == nil {
+					m.status.setMessage("Nothing to reconcile")
+				}
+				return m, nil
+			}
+			if m.viewMode == ModeFirewall && key.Matches(msg, keys.JumpTrigger) {
+				m.finder = firewallPicker(m.firewalls)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeBus && key.Matches(msg, keys.JumpTrigger) {
+				m.finder = busPicker(m.busServices)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeJobs && key.Matches(msg, keys.CancelJob) {
+				if systemd.Offline() {
+					m.status.setMessage(i18n.T(offlineActions))
+					return m, nil
+				}
+				if len(m.jobs) == 0 {
+					m.status.setMessage("No jobs queued")
+					return m, nil
+				}
+				m.finder = jobPicker(m.jobs)
+				return m, textinput.Blink
+			}
+			if m.viewMode == ModeMounts && (key.Matches(msg, keys.Remount) || key.Matches(msg, keys.Unmount) || key.Matches(msg, keys.Swap)) {
+				if systemd.Offline() {
+					m.status.setMessage(i18n.T(offlineActions))
+					return m, nil
+				}
+				switch {
+				case key.Matches(msg, keys.Swap):
+					m.finder = swapPicker(m.swaps)
+				case key.Matches(msg, keys.Remount):
+					m.finder = mountPicker(m.mounts, "remount…", func(unit string) tea.Msg { return remountMsg{unit: unit} })
+				default:
+					m.finder = mountPicker(m.mounts, "unmount…", func(unit string) tea.Msg { return unmountMsg{unit: unit} })
+				}
+				return m, textinput.Blink
+			}
 			m.viewport, cmd = m.viewport.Update(msg)
 			cmds = append(cmds, cmd)
 		}
@@ -476,7 +1320,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.help.Width = msg.Width
 
-		contentHeight := m.height - 4
+		contentHeight := m.height - 4 - actionBarHeight
 		contentWidth := m.width - 4
 
 		sidebarWidth := int(float64(contentWidth) * 0.35)
@@ -486,43 +1330,846 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		headerHeight := 2 // Text + Border
 		m.list.SetSize(sidebarWidth-2, contentHeight-4-headerHeight)
 		m.viewport.Width = mainWidth - 2
-		m.viewport.Height = contentHeight - 4
+		m.contentRows = contentHeight - 4
+		m.viewport.Height = m.contentRows - m.pinnedRows()
 
 	case []systemd.Unit:
-		m.allUnits = msg    // Store source of truth
-		m.updateListItems() // Apply filter
+		m.busy--
+		m.lastFullRefresh = time.Now()
+		cmds = append(cmds, m.receiveUnits(msg, msg, false)...)
+
+	case watchedUnitsMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			break
+		}
+		cmds = append(cmds, m.receiveUnits(mergeWatched(m.allUnits, msg.units, m.cfg.Watch), msg.units, true)...)
+
+	case list.FilterMatchesMsg:
+		m.list, cmd = m.list.Update(msg)
 		cmds = append(cmds, cmd)
 
-	case logLineMsg:
-		if string(msg) != "" {
-			m.logLines = append(m.logLines, string(msg))
-			if len(m.logLines) > 1000 {
-				m.logLines = m.logLines[len(m.logLines)-1000:]
+	case unitTimesMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			times := msg.times
+			if msg.partial {
+				times = maps.Clone(m.unitTimes)
+				if times == nil {
+					times = make(map[string]unitTimes, len(msg.times))
+				}
+				maps.Copy(times, msg.times)
 			}
-			if m.viewMode == ModeLogs {
-				m.viewport.SetContent(strings.Join(m.logLines, "\n"))
-				m.viewport.GotoBottom()
+			if m.streamingUnit != "" {
+				m.noteRestart(m.unitTimes[m.streamingUnit], times[m.streamingUnit])
 			}
+			m.unitTimes = times
+			m.alertStalls()
+			cmds = append(cmds, m.updateListItems())
 		}
-		cmds = append(cmds, waitForLogLine(m.logChan))
 
-	case configMsg:
-		m.configContent = string(msg)
-		if m.viewMode == ModeConfig {
-			m.viewport.SetContent(m.configContent)
-			m.viewport.GotoTop()
+	case refreshTickMsg:
+		// An offline journal never changes, so there is nothing to refresh.
+		if !systemd.Offline() {
+			m.busy++
+			if m.fullRefreshDue(time.Now()) {
+				cmds = append(cmds, fetchUnits)
+			} else {
+				cmds = append(cmds, fetchWatchedUnits(m.cfg.Watch))
+			}
+			cmds = append(cmds, fetchSystemState, fetchJobs, refreshTick(m.refreshInterval()))
+			if m.viewMode == ModeTop && !m.blurred {
+				cmds = append(cmds, fetchTop)
+			}
+			if m.viewMode == ModeContainers {
+				m.busy++
+				cmds = append(cmds, fetchContainers)
+			}
 		}
 
-	case statsMsg:
-		m.stats = msg
+	case errMsg:
+		m.busy--
+		m.notifyError(msg)
+
+	case logLineMsg:
+		// Lines still in flight from a replaced stream are dropped.
+		if m.streams.active(msg.id) {
+			if msg.line.Text != "" {
+				m.appendLog(msg.line)
+			}
+			m.reconnects = 0
+			cmds = append(cmds, m.streams.next())
+		}
+
+	case streamEndedMsg:
+		// Streams stopped on purpose are no longer active. Offline journals
+		// end once their backlog is read.
+		if m.streams.active(msg.id) && !systemd.Offline() {
+			slog.Warn("log stream ended", "unit", msg.unit, "attempt", m.reconnects, "err", msg.err)
+			m.reconnecting = true
+			cmds = append(cmds, scheduleReconnect(msg.unit, m.reconnects))
+		}
+
+	case logCatchUpMsg:
+		m.busy--
+		if msg.unit != m.streamingUnit {
+			break
+		}
+		if msg.err != nil {
+			// Without the lines since, start over from the recent ones.
+			slog.Warn("catching up on logs", "unit", msg.unit, "err", msg.err)
+			cmds = append(cmds, m.connectStream(msg.unit, ""))
+			break
+		}
+		if msg.skipped > 0 {
+			m.appendLogLine(fmt.Sprintf(skippedSeparator, msg.skipped))
+		}
+		m.appendLog(msg.lines...)
+		cmds = append(cmds, m.connectStream(msg.unit, m.lastCursor()))
+
+	case olderLogsMsg:
+		m.busy--
+		m.loadingOlder = false
+		switch {
+		case msg.unit != m.streamingUnit:
+		case msg.err != nil:
+			m.notifyError(msg.err)
+		case len(msg.lines) == 0:
+			m.status.setMessage("Start of the journal of " + msg.unit)
+		default:
+			m.prependLogs(msg.lines)
+		}
+
+	case reconnectMsg:
+		if msg.unit == m.streamingUnit {
+			m.reconnects++
+			slog.Info("reconnecting log stream", "unit", msg.unit, "attempt", m.reconnects)
+			cmds = append(cmds, m.connectStream(msg.unit, m.lastCursor()))
+			m.appendLogLine(reconnectSeparator)
+			m.reconnecting = false
+		}
+
+	case configMsg:
+		m.busy--
+		m.configContent = msg.content
+		m.configUnit = msg.unit
+		m.quadletSource = msg.quadlet
+		m.quadletText = msg.source
+		if m.viewMode == ModeConfig {
+			m.viewport.SetContent(highlightConfig(m.configContent, m.quadletSource, m.quadletText, m.viewport.Width))
+			m.viewport.GotoTop()
+			if m.restoreOffset > 0 {
+				m.viewport.SetYOffset(m.restoreOffset)
+				m.restoreOffset = 0
+			}
+		}
+
+	case historyFlushedMsg:
+		if msg.err != nil {
+			m.notifyError(fmt.Errorf("saving history: %w", msg.err))
+		}
+
+	case statsMsg:
+		m.stats = msg
+
+	case jobsMsg:
+		// Like the system state, this is background polling: no error toasts.
+		if msg.err == nil {
+			m.jobs = msg.jobs
+			cmds = append(cmds, m.updateListItems())
+			if m.viewMode == ModeJobs {
+				m.viewport.SetContent(renderJobs(m.jobs, m.viewport.Width))
+			}
+		}
+
+	case topMsg:
+		// Polled while the pane is open, like the job queue.
+		switch {
+		case msg.err != nil:
+			if m.viewMode == ModeTop {
+				m.viewport.SetContent("Could not read cgroup accounting: " + msg.err.Error())
+			}
+		default:
+			m.top.add(msg.usage, msg.at)
+			if m.viewMode == ModeTop {
+				m.viewport.SetContent(renderTop(m.top, m.viewport.Width))
+				// CPU load needs a second sample; take it soon rather
+				// than at the next refresh.
+				if m.top.prev == nil {
+					cmds = append(cmds, tea.Tick(time.Second, func(time.Time) tea.Msg { return fetchTop() }))
+				}
+			}
+		}
+
+	case systemStateMsg:
+		// Offline journals and managers too old to answer just get no banner.
+		if msg.err == nil {
+			m.systemState = msg.state
+		}
+
+	case timeSyncMsg:
+		// Without timedatectl (containers, other init systems) the
+		// dashboard just shows no clock line. Other failures, such as
+		// timedated timing out on activation, are retried at the next tick.
+		if clockUnavailable(msg.err) {
+			break
+		}
+		if msg.err != nil {
+			cmds = append(cmds, timeSyncTick())
+			break
+		}
+		if m.clock.Timezone != "" && !clockSkewed(m.clock) && clockSkewed(msg.status) {
+			m.toasts.info(i18n.T("The system clock is no longer synchronized"))
+		}
+		m.clock = msg.status
+		cmds = append(cmds, timeSyncTick())
+
+	case timeSyncTickMsg:
+		if m.blurred {
+			m.pausedTicks = append(m.pausedTicks, msg)
+			break
+		}
+		cmds = append(cmds, fetchTimeSync)
+
+	case batchDoneMsg:
+		m.busy--
+		m.dialog = batchReportDialog(msg)
+		m.toasts.info(m.dialog.title)
+		m.refreshMessages()
+		m.busy++
+		cmds = append(cmds, fetchUnits)
+
+	case showDialogMsg:
+		m.dialog = msg.dialog
+
+	case restartVerifiedMsg:
+		m.busy--
+		if msg.failure != "" {
+			m.notifyError(fmt.Errorf("restart of %s failed: %s", msg.unit, msg.failure))
+		} else if msg.probe != "" {
+			m.toasts.success("Restart of " + msg.unit + " verified: active and " + msg.probe + " answers")
+		} else {
+			m.toasts.success("Restart of " + msg.unit + " verified: active")
+		}
+		m.refreshMessages()
+
+	case drainDoneMsg:
+		m.busy--
+		m.recordAudit(msg.unit, "drain", msg.err)
+		m.dialog = drainReportDialog(msg)
+		if msg.err != nil {
+			m.notifyError(fmt.Errorf("draining %s: %w", msg.unit, msg.err))
+		} else {
+			m.toasts.success("Drained and stopped " + msg.unit + ".")
+		}
+		m.refreshMessages()
+		m.busy++
+		cmds = append(cmds, fetchUnits)
+
+	case groupDoneMsg:
+		m.busy--
+		for _, s := range msg.steps {
+			m.recordAudit(s.Unit, "restart with group "+msg.group, s.Err)
+		}
+		m.dialog = groupReportDialog(msg)
+		if msg.err != nil {
+			m.notifyError(fmt.Errorf("group %s: %w", msg.group, msg.err))
+		} else {
+			m.toasts.success(m.dialog.title + ".")
+		}
+		m.refreshMessages()
+		m.busy++
+		cmds = append(cmds, fetchUnits)
+
+	case globDoneMsg:
+		m.busy--
+		m.recordAudit(msg.pattern, msg.verb, msg.err)
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			break
+		}
+		m.toasts.success(fmt.Sprintf("%s %d units matching %s.", globVerbs[msg.verb], len(msg.units), msg.pattern))
+		m.refreshMessages()
+		m.busy++
+		cmds = append(cmds, fetchUnits)
+
+	case scheduleResultMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.toasts.success(msg.text)
+			m.refreshMessages()
+		}
+		if m.viewMode == ModeScheduled {
+			m.busy++
+			cmds = append(cmds, fetchScheduled(false))
+		}
+
+	case scheduledMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			break
+		}
+		if m.viewMode == ModeScheduled {
+			m.viewport.SetContent(renderScheduled(msg.actions))
+		}
+		if msg.cancel {
+			if len(msg.actions) == 0 {
+				m.status.setMessage("No scheduled actions to cancel")
+			} else {
+				m.finder = cancelPicker(msg.actions)
+				cmds = append(cmds, textinput.Blink)
+			}
+		}
+
+	case finderClosedMsg:
+		m.finder = nil
+
+	case typeFilterMsg:
+		m.typeFilter = msg.unitType
+		cmds = append(cmds, m.updateListItems())
+
+	case jumpToUnitMsg:
+		m.jumpToUnit(msg.name)
+
+	case busMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.busServices = msg.services
+			if m.viewMode == ModeBus {
+				m.viewport.SetContent(renderBus(msg.services, m.viewport.Width))
+				m.viewport.GotoTop()
+			}
+		}
+
+	case triggeredMsg:
+		m.busy--
+		switch {
+		case msg.err != nil:
+			m.notifyError(msg.err)
+		case len(msg.units) == 0:
+			m.status.setMessage(msg.name + " does not trigger any unit")
+		case len(msg.units) == 1:
+			m.jumpToUnit(msg.units[0])
+		default:
+			m.finder = newFinder("jump to triggered unit…", msg.units, func(name string) tea.Msg {
+				return jumpToUnitMsg{name: name}
+			})
+			cmds = append(cmds, textinput.Blink)
+		}
+
+	case paletteKeyMsg:
+		m.activePane = PaneList
+		return m.handle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(msg.key)})
+
+	case runPluginMsg:
+		if msg.plugin.Kind == plugin.KindAction && systemd.Offline() {
+			m.status.setMessage(i18n.T(offlineActions))
+			break
+		}
+		if msg.plugin.Kind == plugin.KindPanel {
+			m.viewMode = ModePlugin
+			m.activePane = PaneContent
+			m.viewport.SetContent("Running " + msg.plugin.Name + "…")
+		}
+		m.busy++
+		cmds = append(cmds, runPlugin(msg.plugin, msg.unit))
+
+	case dbStatsMsg:
+		m.viewMode = ModePlugin
+		m.activePane = PaneContent
+		m.viewport.SetContent("Querying " + msg.unit + "…")
+		m.busy++
+		cmds = append(cmds, fetchDBStats(msg.db, msg.unit))
+
+	case pluginMsg:
+		m.busy--
+		switch {
+		case msg.err != nil:
+			m.notifyError(msg.err)
+		case msg.plugin.Kind == plugin.KindPanel:
+			if m.viewMode == ModePlugin {
+				m.viewport.SetContent(renderPluginPanel(msg, m.viewport.Width))
+				m.viewport.GotoTop()
+			}
+		default:
+			text := msg.resp.Message
+			if text == "" {
+				text = msg.plugin.Name + " finished for " + msg.unit
+			}
+			m.toasts.success(text)
+			m.refreshMessages()
+			m.busy++
+			cmds = append(cmds, fetchUnits)
+		}
+
+	case pathContentMsg:
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else if m.viewMode == ModeConfig {
+			m.viewport.SetContent(renderPathContent(msg, m.viewport.Width))
+			m.viewport.GotoTop()
+		}
+
+	case editPathMsg:
+		if unitVersionsEnabled && m.isUnitFile(msg.path) {
+			// Keeps changes made outside vigilix apart from this edit.
+			return m, tea.Sequence(snapshotUnit(m.configUnit, "before editing "+msg.path), openInEditor(msg.path))
+		}
+		return m, openInEditor(msg.path)
+
+	case versionRecordedMsg:
+		if msg.err != nil {
+			m.notifyError(fmt.Errorf("recording a version of %s: %w", msg.unit, msg.err))
+		} else if m.viewMode == ModeVersions && m.versions.unit == msg.unit {
+			m.busy++
+			cmds = append(cmds, fetchVersions(msg.unit))
+		}
+
+	case versionsMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.versions = msg
+			if m.viewMode == ModeVersions {
+				m.viewport.SetContent(renderVersions(msg, m.viewport.Width))
+				m.viewport.GotoTop()
+			}
+		}
+
+	case editorDoneMsg:
+		switch {
+		case msg.err != nil:
+			m.notifyError(msg.err)
+		case msg.path != "" && msg.path == m.quadletSource && !systemd.Offline():
+			// Quadlet units only change once the generator runs again.
+			m.busy++
+			cmds = append(cmds, performAction(regenerate, m.configUnit, "Regenerated"))
+		case m.isUnitFile(msg.path) && !systemd.Offline():
+			m.busy++
+			cmds = append(cmds, tea.Sequence(performAction(regenerate, m.configUnit, "Reloaded"),
+				snapshotUnit(m.configUnit, "edited "+msg.path)))
+		}
+
+	case logFilterMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			break
+		}
+		m.logFilter = msg.filter
+		m.logMatches = make(map[string]bool)
+		if m.viewMode == ModeLogs {
+			m.viewport.SetContent(m.logContent())
+			m.viewport.GotoBottom()
+		}
+
+	case openBootReportMsg:
+		m.busy++
+		cmds = append(cmds, fetchBootReport)
+
+	case bootReportMsg:
+		m.busy--
+		m.bootReport = msg.report
+		m.viewMode = ModeBoot
+		m.activePane = PaneContent
+		m.viewport.SetContent(renderBootReport(msg.report, m.viewport.Width))
+		m.viewport.GotoTop()
+
+	case bootReportSavedMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.toasts.success("Saved the boot report to " + msg.path)
+			m.refreshMessages()
+		}
+
+	case traceSearchMsg:
+		m.busy++
+		cmds = append(cmds, fetchTrace(msg.id))
+
+	case correlateMsg:
+		m.busy++
+		cmds = append(cmds, fetchAround(msg.at))
+
+	case journalSliceMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			break
+		}
+		m.viewMode = ModeJournal
+		m.activePane = PaneContent
+		content, row := renderJournalSlice(msg, m.logTimes, m.viewport.Width)
+		m.viewport.SetContent(content)
+		m.viewport.SetYOffset(row - m.viewport.Height/2)
+
+	case jsonLineMsg:
+		m.dialog = jsonLineDialog(msg.text, m.height-8)
+
+	case silencesMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.silences = msg.silences
+			m.status.setMessage(msg.text)
+		}
+
+	case screenshotMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.toasts.success("Saved screenshot to " + msg.path)
+		}
+
+	case exportedMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.toasts.success(fmt.Sprintf("Exported %d units to %s", msg.count, msg.path))
+			m.refreshMessages()
+		}
+
+	case notesSavedMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.notes = msg.notes
+			cmds = append(cmds, m.updateListItems())
+		}
+
+	case compareMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else if m.viewMode == ModeCompare {
+			m.viewport.SetContent(renderCompare(msg, m.viewport.Width))
+			m.viewport.GotoTop()
+		}
+
+	case detailsMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		}
+		if msg.details.props != nil && m.viewMode == ModeDetails {
+			msg.details.note = m.notes[msg.details.name]
+			msg.details.timings = m.timings[msg.details.name]
+			msg.details.uptime = m.uptime[msg.details.name]
+			msg.details.certs = m.certs[msg.details.name]
+			msg.details.certWarning = m.cfg.CertWarning()
+			msg.details.probeURL = m.probeURL(msg.details.name)
+			msg.details.probes = m.probes[msg.details.name]
+			m.viewport.SetContent(renderDetails(msg.details, m.viewport.Width))
+			m.viewport.GotoTop()
+		}
+
+	case logStatsMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else if m.viewMode == ModeLogStats {
+			m.viewport.SetContent(renderLogStats(msg, m.viewport.Width))
+			m.viewport.GotoTop()
+		}
+
+	case mountsMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.mounts = msg.mounts
+			m.swaps = msg.swaps
+			if m.viewMode == ModeMounts {
+				m.viewport.SetContent(renderMounts(msg, m.viewport.Width))
+				m.viewport.GotoTop()
+			}
+		}
+
+	case certsMsg:
+		m.certs = msg
+		m.alertCerts()
+		cmds = append(cmds, m.updateListItems(), certTick())
+
+	case probesMsg:
+		m.addProbes(msg)
+		cmds = append(cmds, m.updateListItems(), probeTick(m.cfg.ProbeInterval()))
+
+	case probeTickMsg:
+		if m.blurred {
+			m.pausedTicks = append(m.pausedTicks, msg)
+			break
+		}
+		cmds = append(cmds, fetchProbes(m.cfg.Probes, m.cfg.ProbeInterval()))
+
+	case certTickMsg:
+		if m.blurred {
+			m.pausedTicks = append(m.pausedTicks, msg)
+			break
+		}
+		cmds = append(cmds, fetchCerts(m.cfg.Certificates))
+
+	case firewallMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.firewalls = msg.firewalls
+			if m.viewMode == ModeFirewall {
+				m.viewport.SetContent(renderFirewall(msg.firewalls, m.viewport.Width))
+				m.viewport.GotoTop()
+			}
+		}
+
+	case driftMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.drift = msg
+			if m.viewMode == ModeDrift {
+				m.viewport.SetContent(renderDrift(msg, m.viewport.Width))
+				m.viewport.GotoTop()
+			}
+		}
+
+	case reconciledMsg:
+		m.busy--
+		failed := 0
+		for _, r := range msg.results {
+			m.recordAudit(r.unit, r.verb+" (reconcile)", r.err)
+			if r.err != nil {
+				failed++
+				m.notifyError(fmt.Errorf("%s %s: %w", r.verb, r.unit, r.err))
+			}
+		}
+		if failed == 0 {
+			m.toasts.success(fmt.Sprintf("Reconciled %d changes.", len(msg.results)))
+		}
+		m.busy++
+		cmds = append(cmds, fetchUnits)
+		if m.viewMode == ModeDrift {
+			m.busy++
+			cmds = append(cmds, fetchDrift(m.cfg.Baseline))
+		}
+
+	case containersMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.projects = msg.projects
+			if m.viewMode == ModeContainers {
+				offset := m.viewport.YOffset
+				m.viewport.SetContent(renderContainers(msg, m.viewport.Width))
+				m.viewport.SetYOffset(offset)
+			}
+		}
+
+	case composeActionMsg:
+		switch {
+		case msg.verb == "logs":
+			m.viewMode = ModeLogs
+			m.activePane = PaneContent
+			cmds = append(cmds, m.startStreaming(composePrefix+msg.project.Name))
+		case msg.verb == "down":
+			m.dialog = composeDownDialog(msg.project)
+		default:
+			m.busy++
+			cmds = append(cmds, composeAction(msg.verb, msg.project))
+		}
+
+	case composeDoneMsg:
+		m.busy--
+		m.recordAudit(composePrefix+msg.project, msg.done+" compose project", msg.err)
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else {
+			m.toasts.success(msg.done + " compose project " + msg.project + ".")
+			m.refreshMessages()
+			if m.viewMode == ModeContainers {
+				m.busy++
+				cmds = append(cmds, fetchContainers)
+			}
+		}
+
+	case remountMsg:
+		m.busy++
+		cmds = append(cmds, remount(msg.unit))
+
+	case unmountMsg:
+		m.dialog = unmountDialog(msg.unit)
+
+	case cancelJobMsg:
+		m.dialog = cancelJobDialog(msg.job)
+
+	case killUnitMsg:
+		m.dialog = killDialog(msg.unit, msg.signal)
+
+	case swapToggleMsg:
+		cmd, dialog := swapToggle(msg.swap)
+		if dialog != nil {
+			m.dialog = dialog
+		} else {
+			m.busy++
+			cmds = append(cmds, cmd)
+		}
+
+	case columnsMsg:
+		m.busy--
+		m.columns = msg.columns
+		m.list.SetDelegate(m.delegate())
+		if msg.err != nil {
+			m.notifyError(fmt.Errorf("saving list columns: %w", msg.err))
+		} else {
+			m.toasts.success("List columns saved.")
+		}
+
+	case densitySavedMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(fmt.Errorf("saving the list density: %w", msg.err))
+		}
+
+	case hardenMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			break
+		}
+		m.checklist = hardeningChecklist(msg.unit, msg.security)
+		if m.checklist == nil {
+			m.status.setMessage(msg.unit + " already has every suggested protection")
+		}
+
+	case hardenedMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		}
+		if msg.path != "" {
+			m.dialog = hardenedDialog(msg)
+			m.busy++
+			cmds = append(cmds, fetchUnits)
+		}
+
+	case explainMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+		} else if m.viewMode == ModeExplain {
+			m.viewport.SetContent(renderExplain(msg.report, m.viewport.Width))
+			m.viewport.GotoTop()
+		}
+
+	case unitPropsMsg:
+		m.busy--
+		if msg.err != nil {
+			m.notifyError(msg.err)
+			m.expanded = nil
+		} else if m.expanded != nil && m.expanded.name == msg.name {
+			m.expanded.props = msg.props
+		}
+
+	case updateMsg:
+		// A failed check is not worth interrupting anyone over.
+		if msg.err == nil && msg.release != nil {
+			m.update = msg.release.Tag
+		}
+
+	case timedActionMsg:
+		m.busy--
+		m.recordAudit(msg.unit, msg.done, msg.err)
+		if msg.timings != nil {
+			m.timings = msg.timings
+		}
+		switch {
+		case msg.err != nil && msg.action == "restart":
+			m.busy++
+			cmds = append(cmds, restartFailed(msg.unit, msg.err))
+		case msg.err != nil:
+			m.notifyError(msg.err)
+		}
+		if msg.t.Total > 0 {
+			t := msg.t
+			text := fmt.Sprintf("%s %s in %s", msg.done, msg.unit,
+				describeTransition(t.Total, t.Queued(), t.Deactivating, t.Activating))
+			if msg.action == "restart" {
+				// The restart job finishing does not mean the service is
+				// up; the verdict follows once it has been checked.
+				m.toasts.info(text + ", verifying…")
+				m.busy++
+				cmds = append(cmds, verifyRestart(msg.unit, m.unitTimes[msg.unit].timeoutStart, m.cfg.Probes[msg.unit]))
+			} else {
+				m.toasts.success(text)
+			}
+			if msg.action == "stop" {
+				m.undo.add(msg.unit, "stop", time.Now())
+			}
+			m.refreshMessages()
+			m.busy++
+			cmds = append(cmds, fetchUnits)
+		}
 
 	case actionResultMsg:
+		m.busy--
+		m.recordAudit(msg.unit, msg.action+" unit", msg.err)
+		slog.Info("action", "action", msg.action, "unit", msg.unit, "err", msg.err)
 		if msg.err != nil {
-			m.statusMessage = "Error: " + msg.err.Error()
+			m.notifyError(msg.err)
 		} else {
-			m.statusMessage = msg.action + " unit."
+			m.toasts.success(msg.action + " unit.")
+			if msg.action == "Disabled" {
+				m.undo.add(msg.unit, "disable", time.Now())
+			}
+			m.refreshMessages()
+			m.busy++
 			cmds = append(cmds, fetchUnits)
+			if m.viewMode == ModeMounts {
+				m.busy++
+				cmds = append(cmds, fetchMounts)
+			}
+			if m.viewMode == ModeJobs {
+				cmds = append(cmds, fetchJobs)
+			}
+			if i, ok := m.list.SelectedItem().(item); ok && m.viewMode == ModeDetails {
+				m.busy++
+				cmds = append(cmds, fetchDetails(i.unit.Name))
+			}
+			if m.viewMode == ModeConfig && m.configUnit != "" {
+				m.busy++
+				cmds = append(cmds, fetchConfig(m.configUnit))
+			}
+			if m.viewMode == ModeVersions && m.versions.unit != "" {
+				m.busy++
+				cmds = append(cmds, fetchVersions(m.versions.unit))
+			}
+		}
+
+	case clockTickMsg:
+		m.status.tick(time.Time(msg))
+		m.toasts.expire(time.Time(msg))
+		m.undo.expire(time.Time(msg))
+		if m.dialog != nil && m.dialog.title == debugTitle {
+			m.dialog = m.debugDialog()
 		}
+		if m.viewMode == ModeLogs && m.logTimes == timesRelative && !m.accessLog {
+			m.viewport.SetContent(m.logContent())
+		}
+		cmds = append(cmds, clockTick(m.clockInterval()))
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -533,15 +2180,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func (m *model) updateListItems() {
+func (m *model) updateListItems() tea.Cmd {
 	var filtered []list.Item
 	for _, unit := range m.allUnits {
+		if m.failedOnly && unit.ActiveState != "failed" {
+			continue
+		}
+		if m.typeFilter != "" && unitType(unit.Name) != m.typeFilter {
+			continue
+		}
 		if m.devMode {
 			name := strings.ToLower(unit.Name)
 			isDev := false
-			keywords := []string{"docker", "mongo", "postgres", "mysql", "redis", "nginx", "apache", "node", "python", "go", "java", "php", "ruby", "rust", "app", "api", "service", "web", "worker", "db"}
-
-			for _, kw := range keywords {
+			for _, kw := range m.cfg.DevKeywords {
 				if strings.Contains(name, kw) {
 					isDev = true
 					break
@@ -549,41 +2200,177 @@ func (m *model) updateListItems() {
 			}
 
 			if isDev {
-				filtered = append(filtered, item{unit: unit})
+				filtered = append(filtered, m.newItem(unit))
 			}
 		} else {
-			filtered = append(filtered, item{unit: unit})
+			filtered = append(filtered, m.newItem(unit))
 		}
 	}
-	m.list.SetItems(filtered)
+	cmd := m.list.SetItems(filtered)
 
 	title := "System Units"
 	if m.devMode {
-		title = "Dev Services 🚀"
+		title = "Dev Services " + glyphs.Dev
+	}
+	if m.failedOnly {
+		title = "Failed Units"
 	}
 	m.list.Title = title
+	return cmd
 }
 
-func (m *model) startStreaming(name string) {
-	if m.streamingUnit == name {
-		return
+func (m model) newItem(unit systemd.Unit) item {
+	var cert *certs.Cert
+	if c, ok := m.expiring(unit.Name, time.Now()); ok {
+		cert = &c
+	}
+	return item{
+		unit:    unit,
+		times:   m.unitTimes[unit.Name],
+		note:    m.notes[unit.Name],
+		waiters: len(waitingOn(m.jobs, unit.Name)),
+		stalled: m.stalledFor(unit, time.Now()),
+		cert:    cert,
+		probes:  m.probes[unit.Name],
+		uptime:  m.uptime[unit.Name],
+	}
+}
+
+// unitNames lists every known unit, regardless of filters.
+func (m model) unitNames() []string {
+	names := make([]string, len(m.allUnits))
+	for i, u := range m.allUnits {
+		names[i] = u.Name
+	}
+	return names
+}
+
+// jumpToUnit selects the named unit in the list, clearing the list filter
+// and leaving dev mode if either would hide it.
+func (m *model) jumpToUnit(name string) {
+	m.activePane = PaneList
+	m.list.ResetFilter()
+
+	idx := m.listIndex(name)
+	if idx < 0 && m.devMode {
+		m.devMode = false
+		m.updateListItems() // unfiltered, so no async filtering is needed
+		m.status.setMessage("Dev Mode: false")
+		idx = m.listIndex(name)
+	}
+	if idx < 0 && m.failedOnly {
+		m.failedOnly = false
+		m.updateListItems()
+		m.status.setMessage("Failed only: false")
+		idx = m.listIndex(name)
 	}
-	if m.logCancel != nil {
-		m.logCancel()
+	if idx < 0 && m.typeFilter != "" {
+		m.typeFilter = ""
+		m.updateListItems()
+		m.status.setMessage("Showing all unit types")
+		idx = m.listIndex(name)
 	}
-	m.logLines = []string{}
+	if idx >= 0 {
+		m.list.Select(idx)
+	}
+}
+
+// listIndex returns the position of the named unit in the list items, or -1.
+func (m model) listIndex(name string) int {
+	for idx, li := range m.list.Items() {
+		if i, ok := li.(item); ok && i.unit.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// openLogStats shows the Stats pane for the selected unit. Pressing the key
+// again while it is open cycles the window: 1h → 24h → boot.
+func (m *model) openLogStats() tea.Cmd {
+	if m.viewMode == ModeLogStats {
+		m.statsWindow = (m.statsWindow + 1) % (systemd.WindowBoot + 1)
+	}
+	m.viewMode = ModeLogStats
+	m.activePane = PaneContent
+	i, ok := m.list.SelectedItem().(item)
+	if !ok {
+		return nil
+	}
+	m.busy++
+	return fetchLogStats(i.unit.Name, m.statsWindow)
+}
+
+// overlayWidth is the width used for centered dialogs.
+func (m model) overlayWidth() int {
+	width := m.width / 2
+	if width < 50 {
+		width = m.width - 4
+	}
+	return width
+}
+
+// notifyError raises an error toast and records it in the Messages pane.
+func (m *model) notifyError(err error) {
+	slog.Error("error shown", "err", err)
+	m.toasts.error(err)
+	m.refreshMessages()
+}
+
+// refreshMessages re-renders the Messages pane if it is showing.
+func (m *model) refreshMessages() {
+	if m.viewMode == ModeMessages {
+		m.viewport.SetContent(m.toasts.historyView())
+	}
+}
+
+// startStreaming shows the logs of name, returning the command receiving
+// its first line, or nil if they are already streaming.
+func (m *model) startStreaming(name string) tea.Cmd {
+	if m.streamingUnit == name {
+		return nil
+	}
+	m.stashLogs()
 	m.streamingUnit = name
-	m.logCtx, m.logCancel = context.WithCancel(context.Background())
-	m.logChan = make(chan string)
+	m.accessLog = m.accessLog && isWebServer(name)
+	m.reconnecting = false
+	m.reconnects = 0
+	if b, ok := m.logCache.take(name); ok {
+		m.logLines, m.olderLoaded = b.lines, b.olderLoaded
+		m.appendLogLine(resumeSeparator)
+		// The previous unit's stream must not feed this buffer while it
+		// catches up; the stream resumes once it has.
+		stopped := m.streams.stop()
+		m.busy++
+		return tea.Batch(stopped, catchUpLogs(name, m.lastCursor()))
+	}
+	m.logLines = []systemd.LogLine{}
+	m.olderLoaded = 0
+	return m.connectStream(name, "")
+}
 
-	go func() {
-		systemd.StreamLogs(m.logCtx, name, m.logChan)
-	}()
+// connectStream starts following name after the journal cursor, replacing
+// any running stream.
+func (m *model) connectStream(name, after string) tea.Cmd {
+	return m.streams.start(name, after, m.logFiles(name))
 }
 
 func (m model) View() string {
+	start := time.Now()
+	view := plain(m.render())
+	frames.add(time.Since(start))
+	return view
+}
+
+func (m model) render() string {
 	if m.width == 0 {
-		return "Initializing..."
+		return i18n.T("Initializing...")
+	}
+	if m.cfg.ScreenReader {
+		return m.linearView()
+	}
+	if m.cfg.Inline {
+		return m.inlineView()
 	}
 
 	// 1. DASHBOARD MODE (Keep Clean)
@@ -596,17 +2383,27 @@ func (m model) View() string {
  ╚████╔╝ ██║╚██████╔╝██║███████╗██║██╔╝ ██╗
   ╚═══╝  ╚═╝ ╚═════╝ ╚═╝╚══════╝╚═╝╚═╝  ╚═╝
 `
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-			lipgloss.JoinVertical(lipgloss.Center,
-				lipgloss.NewStyle().Foreground(purple).Render(logo),
-				lipgloss.NewStyle().Foreground(foreground).MarginTop(1).Render(fmt.Sprintf("Units: %d", len(m.allUnits))),
-				lipgloss.NewStyle().Foreground(comment).MarginTop(2).Render("Press Enter to Start"),
-			),
-		)
+		lines := []string{
+			lipgloss.NewStyle().Foreground(purple).Render(logo),
+			lipgloss.NewStyle().Foreground(foreground).MarginTop(1).Render(i18n.Tf("Units: %d", len(m.allUnits))),
+			lipgloss.NewStyle().Foreground(comment).Render(systemdVersionLabel()),
+			lipgloss.NewStyle().MarginTop(1).Render(m.healthBanner()),
+			m.clockBanner(),
+			lipgloss.NewStyle().Foreground(comment).MarginTop(2).Render(i18n.T("Press Enter to Start")),
+		}
+		if failed := len(m.failedUnits()); failed > 0 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(comment).Render(i18n.Tf("%d failed · press F to restart them", failed)))
+		}
+		dash := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			lipgloss.JoinVertical(lipgloss.Center, lines...))
+		if m.dialog != nil {
+			dash = overlayCenter(dash, m.dialog.View(m.overlayWidth()), m.width, m.height)
+		}
+		return dash
 	}
 
 	// 2. MAIN APP
-	contentHeight := m.height - 4
+	contentHeight := m.height - 4 - actionBarHeight
 	contentWidth := m.width - 4
 	sidebarWidth := int(float64(contentWidth) * 0.35)
 	mainWidth := contentWidth - sidebarWidth
@@ -662,52 +2459,62 @@ func (m model) View() string {
 		Height(contentHeight).
 		Render(sidebarContent)
 
-	// Main Panel Header
-	logsTab := inactiveTabStyle.Render(" Logs ")
-	configTab := inactiveTabStyle.Render(" Config ")
+	if m.expanded != nil {
+		box := m.expanded.view(listContentWidth)
+		y := m.expandedRowOffset(lipgloss.Height(customHeader))
+		if y+lipgloss.Height(box) >= lipgloss.Height(sidebar)-1 {
+			// Not enough room below the row; open upwards instead
+			y -= m.delegate().Height() + lipgloss.Height(box)
+		}
+		sidebar = overlay(sidebar, box, 1, y)
+	}
 
-	if m.viewMode == ModeLogs {
-		logsTab = activeTabStyle.Render(" Logs ")
-	} else if m.viewMode == ModeConfig {
-		configTab = activeTabStyle.Render(" Config ")
+	// Main Panel Header
+	var tabViews []string
+	active := 0
+	for _, t := range tabs {
+		if t.mode == ModePlugin && len(m.plugins) == 0 {
+			continue
+		}
+		label := " " + i18n.T(strings.TrimSpace(t.label)) + " "
+		if m.viewMode == t.mode {
+			active = len(tabViews)
+			tabViews = append(tabViews, activeTabStyle.Render(label))
+		} else {
+			tabViews = append(tabViews, inactiveTabStyle.Render(label))
+		}
 	}
 
 	// Right Side Status
 	headerInfo := ""
 	if i, ok := m.list.SelectedItem().(item); ok {
-		statusColor := comment
-		statusFg := foreground
-		if i.unit.ActiveState == "active" {
-			statusColor = green
-			statusFg = lipgloss.Color("#000000")
-		} else if i.unit.ActiveState == "failed" {
-			statusColor = red
-		}
-
-		statusStr := lipgloss.NewStyle().
-			Background(statusColor).
-			Foreground(statusFg).
-			Padding(0, 1).
-			Bold(true).
-			Render(strings.ToUpper(i.unit.ActiveState))
+		statusStr := stateBadge(i.unit.ActiveState)
 
 		headerInfo = fmt.Sprintf(" %s ", statusStr)
 	}
 
 	if m.streamingUnit != "" && m.viewMode == ModeLogs {
-		headerInfo += fmt.Sprintf(" %s", m.spinner.View())
+		if len(m.logFilter) > 0 {
+			headerInfo += lipgloss.NewStyle().Foreground(cyan).Render(" & " + formatLogFilter(m.logFilter))
+		}
+		if m.reconnecting {
+			headerInfo += lipgloss.NewStyle().Foreground(orange).Render(" ⟳ reconnecting")
+		} else {
+			headerInfo += fmt.Sprintf(" %s", m.spinner.View())
+		}
 	}
 
+	tabBar := tabStrip(tabViews, active, mainWidth-4-lipgloss.Width(headerInfo))
+
 	// Separator line
-	lineLen := mainWidth - lipgloss.Width(logsTab) - lipgloss.Width(configTab) - lipgloss.Width(headerInfo) - 4
+	lineLen := mainWidth - lipgloss.Width(tabBar) - lipgloss.Width(headerInfo) - 4
 	if lineLen < 0 {
 		lineLen = 0
 	}
 	line := lipgloss.NewStyle().Foreground(comment).Render(strings.Repeat("─", lineLen))
 
 	header := lipgloss.JoinHorizontal(lipgloss.Bottom,
-		logsTab,
-		configTab,
+		tabBar,
 		line,
 		headerInfo,
 	)
@@ -721,6 +2528,9 @@ func (m model) View() string {
 			Width(mainWidth - 2).
 			Render("No content loaded. Select a unit and press Enter.")
 	}
+	if rows := m.pinnedRows(); rows > 0 {
+		contentView = lipgloss.JoinVertical(lipgloss.Left, m.pinnedView(rows, m.viewport.Width), contentView)
+	}
 
 	mainStyle := panelStyle
 	if m.activePane == PaneContent {
@@ -736,17 +2546,83 @@ func (m model) View() string {
 		))
 
 	// Footer
-	helpText := "Tab: Switch | d: Dev Mode | Enter: View | s/x/r: Control"
-	statusView := lipgloss.NewStyle().Foreground(orange).Render(m.statusMessage)
-
-	footer := lipgloss.JoinHorizontal(lipgloss.Top,
-		lipgloss.NewStyle().Foreground(comment).Render(helpText),
-		lipgloss.NewStyle().PaddingLeft(2).Render("│ "+statusView),
-	)
+	footer := m.statusBarView()
 
 	body := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, mainPanel)
+	screen := lipgloss.JoinVertical(lipgloss.Left, body, m.actionBarView(), footer)
+
+	if m.dialog != nil {
+		screen = overlayCenter(screen, m.dialog.View(m.overlayWidth()), m.width, m.height)
+	}
+
+	if m.prompt != nil {
+		screen = overlayCenter(screen, m.prompt.View(m.overlayWidth()), m.width, m.height)
+	}
+	if m.checklist != nil {
+		screen = overlayCenter(screen, m.checklist.View(m.overlayWidth()), m.width, m.height)
+	}
+
+	if m.finder != nil {
+		width := m.width / 2
+		if width < 40 {
+			width = m.width - 4
+		}
+		screen = overlayCenter(screen, m.finder.View(width), m.width, m.height)
+	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, body, footer)
+	// Toasts stack in the bottom-right corner, just above the footer.
+	if toasts := m.toasts.view(); toasts != "" {
+		x := m.width - lipgloss.Width(toasts) - 2
+		y := lipgloss.Height(body) - lipgloss.Height(toasts) - 1
+		screen = overlay(screen, toasts, x, y)
+	}
+
+	return screen
+}
+
+func systemdVersionLabel() string {
+	if v := systemd.Version(); v > 0 {
+		return fmt.Sprintf("systemd %d", v)
+	}
+	return "systemd version unknown"
+}
+
+// tabStrip joins the tabs into at most width cells. When they do not fit,
+// tabs before the active one are dropped so that it stays visible, and the
+// strip is cut at the right.
+func tabStrip(tabs []string, active, width int) string {
+	start := 0
+	for start < active && lipgloss.Width(strings.Join(tabs[start:active+1], "")) > width-1 {
+		start++
+	}
+	strip := strings.Join(tabs[start:], "")
+	if start > 0 {
+		strip = "…" + strip
+	}
+	return truncate(strip, width)
+}
+
+// receiveUnits takes in a new unit list and fetches the properties of the
+// changed units: all of them, or only the watched ones when partial.
+func (m *model) receiveUnits(units, changed []systemd.Unit, partial bool) []tea.Cmd {
+	var cmds []tea.Cmd
+	m.alertFailures(m.allUnits, units)
+	if !systemd.Offline() {
+		m.recordStates(m.allUnits, units, time.Now())
+		cmds = append(cmds, m.observeUptime(units, time.Now()))
+	}
+	m.allUnits = units // Store source of truth
+	if m.restore != nil {
+		cmds = append(cmds, m.restoreSession(m.restore))
+		m.restore = nil
+	} else {
+		cmds = append(cmds, m.updateListItems()) // Apply filter
+	}
+	if !systemd.Offline() {
+		m.busy++
+		cmds = append(cmds, fetchUnitTimes(changed, partial))
+	}
+	return cmds
 }
 
 func fetchUnits() tea.Msg {
@@ -761,9 +2637,18 @@ func fetchConfig(name string) tea.Cmd {
 	return func() tea.Msg {
 		content, err := systemd.GetUnitFileContent(name)
 		if err != nil {
-			return configMsg("Error reading config: " + err.Error())
+			return configMsg{unit: name, content: "Error reading config: " + err.Error()}
+		}
+		// The Quadlet file the service was generated from is shown too.
+		src, ok := systemd.QuadletSource(name)
+		if !ok {
+			return configMsg{unit: name, content: content}
+		}
+		quadlet, err := systemd.ReadQuadlet(src)
+		if err != nil {
+			quadlet = "# cannot read: " + err.Error() + "\n"
 		}
-		return configMsg(content)
+		return configMsg{unit: name, content: content, quadlet: src, source: quadlet}
 	}
 }
 
@@ -780,22 +2665,40 @@ func fetchStats() tea.Msg {
 	}
 }
 
-func waitForLogLine(sub <-chan string) tea.Cmd {
+type triggeredMsg struct {
+	name  string
+	units []string
+	err   error
+}
+
+func fetchTriggered(name string) tea.Cmd {
 	return func() tea.Msg {
-		if sub == nil {
-			return nil
-		}
-		line, ok := <-sub
-		if !ok {
-			return nil
+		units, err := systemd.Triggers(name)
+		return triggeredMsg{name: name, units: units, err: err}
+	}
+}
+
+// startTriggered starts whatever a socket, path or timer unit activates,
+// as if it had fired.
+func startTriggered(name string) error {
+	targets, err := systemd.Triggers(name)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("%s does not trigger any unit", name)
+	}
+	for _, t := range targets {
+		if err := systemd.StartUnit(t); err != nil {
+			return fmt.Errorf("starting %s: %w", t, err)
 		}
-		return logLineMsg(line)
 	}
+	return nil
 }
 
 func performAction(actionFunc func(string) error, name, actionName string) tea.Cmd {
 	return func() tea.Msg {
 		err := actionFunc(name)
-		return actionResultMsg{err: err, action: actionName}
+		return actionResultMsg{err: err, action: actionName, unit: name}
 	}
 }
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoWindow is how long a stop or disable can be undone.
+const undoWindow = 10 * time.Second
+
+// maxUndo bounds the action journal.
+const maxUndo = 20
+
+// undoEntry is an action in the journal and how to revert it.
+type undoEntry struct {
+	unit    string
+	action  string // "stop" or "disable"
+	expires time.Time
+}
+
+// undoJournal records the stops and disables made from the UI, newest
+// last, so that an accidental one can be reverted for a few seconds.
+type undoJournal struct {
+	entries []undoEntry
+}
+
+func (j *undoJournal) add(unit, action string, now time.Time) {
+	j.entries = append(j.entries, undoEntry{unit: unit, action: action, expires: now.Add(undoWindow)})
+	if len(j.entries) > maxUndo {
+		j.entries = j.entries[len(j.entries)-maxUndo:]
+	}
+}
+
+// expire drops the entries that can no longer be undone.
+func (j *undoJournal) expire(now time.Time) {
+	kept := j.entries[:0]
+	for _, e := range j.entries {
+		if now.Before(e.expires) {
+			kept = append(kept, e)
+		}
+	}
+	j.entries = kept
+}
+
+// latest is the newest entry that can still be undone.
+func (j undoJournal) latest(now time.Time) (undoEntry, bool) {
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		if now.Before(j.entries[i].expires) {
+			return j.entries[i], true
+		}
+	}
+	return undoEntry{}, false
+}
+
+// pop removes the newest entry that can still be undone and returns it.
+func (j *undoJournal) pop(now time.Time) (undoEntry, bool) {
+	e, ok := j.latest(now)
+	if ok {
+		for i := len(j.entries) - 1; i >= 0; i-- {
+			if j.entries[i] == e {
+				j.entries = append(j.entries[:i], j.entries[i+1:]...)
+				break
+			}
+		}
+	}
+	return e, ok
+}
+
+// prompt is the undo offer shown in the action bar, with the seconds left.
+func (e undoEntry) prompt(now time.Time) string {
+	left := int(e.expires.Sub(now).Round(time.Second).Seconds())
+	return fmt.Sprintf("%s undo %s %s (%ds)", keys.Undo.Help().Key, e.action, e.unit, left)
+}
+
+// undo reverts the entry: a stopped unit is started again, a disabled one
+// enabled again.
+func (e undoEntry) undo() tea.Cmd {
+	if e.action == "disable" {
+		return performAction(systemd.EnableUnit, e.unit, "Enabled")
+	}
+	return timedAction("start", e.unit, "Started")
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type refreshTickMsg time.Time
+
+// refreshTick schedules the next background refresh of the unit list and its
+// timestamps.
+func refreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return refreshTickMsg(t)
+	})
+}
+
+// unitTimes are the state transition timestamps of a single unit, along
+// with the trigger units that start it on demand and its start and stop
+// timeouts (zero when infinite). The unit file state and memory use for the
+// list columns come with the same query.
+type unitTimes struct {
+	activeEnter  time.Time
+	stateChange  time.Time
+	triggeredBy  []string
+	timeoutStart time.Duration
+	timeoutStop  time.Duration
+	enabled      string
+	memory       uint64
+	hasMemory    bool
+}
+
+// unitTimesMsg carries the properties of every unit, or of some of them
+// to merge into the known ones when partial.
+type unitTimesMsg struct {
+	times   map[string]unitTimes
+	partial bool
+	err     error
+}
+
+func fetchUnitTimes(units []systemd.Unit, partial bool) tea.Cmd {
+	names := make([]string, len(units))
+	for i, u := range units {
+		names[i] = u.Name
+	}
+	return func() tea.Msg {
+		props, err := systemd.ShowUnitsProperties(names, "ActiveEnterTimestamp", "StateChangeTimestamp", "TriggeredBy", "TimeoutStartUSec", "TimeoutStopUSec", "UnitFileState", "MemoryCurrent")
+		if err != nil {
+			return unitTimesMsg{err: err}
+		}
+		times := make(map[string]unitTimes, len(props))
+		for name, p := range props {
+			var t unitTimes
+			t.activeEnter, _ = systemd.ParseTimestamp(p["ActiveEnterTimestamp"])
+			t.stateChange, _ = systemd.ParseTimestamp(p["StateChangeTimestamp"])
+			t.triggeredBy = strings.Fields(p["TriggeredBy"])
+			t.timeoutStart, _ = systemd.ParseTimespan(p["TimeoutStartUSec"])
+			t.timeoutStop, _ = systemd.ParseTimespan(p["TimeoutStopUSec"])
+			t.enabled = p["UnitFileState"]
+			t.memory, t.hasMemory = systemd.ParseCounter(p["MemoryCurrent"])
+			times[name] = t
+		}
+		return unitTimesMsg{times: times, partial: partial}
+	}
+}
+
+// relativeState describes how long the unit has been in its current state,
+// e.g. "up 3d 4h" or "failed 12m ago". It is empty when systemd has no
+// timestamp for the unit.
+func (i item) relativeState(now time.Time) string {
+	if i.unit.ActiveState == "active" {
+		if t := i.times.activeEnter; !t.IsZero() {
+			return "up " + humanDuration(now.Sub(t))
+		}
+		return ""
+	}
+	// Socket- and path-activated services idle until needed; that is
+	// not a problem worth timing.
+	if i.unit.ActiveState == "inactive" && len(i.times.triggeredBy) > 0 {
+		return "on demand via " + i.times.triggeredBy[0]
+	}
+	if t := i.times.stateChange; !t.IsZero() {
+		return fmt.Sprintf("%s %s ago", i.unit.ActiveState, humanDuration(now.Sub(t)))
+	}
+	return ""
+}
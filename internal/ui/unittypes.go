@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeIcons are the list icons by unit type, for units no icon rule
+// matches, with their two-letter ASCII stand-ins.
+var typeIcons = map[string][2]string{
+	"service":   {"🔧", "sv"},
+	"socket":    {"🔌", "so"},
+	"timer":     {"⏰", "tm"},
+	"mount":     {"💾", "mt"},
+	"automount": {"📂", "am"},
+	"target":    {"🎯", "tg"},
+	"path":      {"📁", "pa"},
+	"device":    {"💽", "dv"},
+	"slice":     {"🍰", "sl"},
+	"scope":     {"🔭", "sc"},
+	"swap":      {"🔄", "sw"},
+}
+
+// unitType is the type suffix of a unit name without the dot, e.g.
+// "timer".
+func unitType(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// typeIcon is the icon of the unit's type, or the generic one.
+func typeIcon(name string) string {
+	icons, ok := typeIcons[unitType(name)]
+	switch {
+	case !ok && glyphs.ascii:
+		return "[]"
+	case !ok:
+		return "📦"
+	case glyphs.ascii:
+		return icons[1]
+	}
+	return icons[0]
+}
+
+// typeFilterMsg limits the list to one unit type; "" shows all.
+type typeFilterMsg struct{ unitType string }
+
+const allTypes = "all types"
+
+// typePicker offers the unit types present, with how many units each has.
+func typePicker(units []systemd.Unit) *finder {
+	counts := make(map[string]int)
+	for _, u := range units {
+		counts[unitType(u.Name)]++
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return counts[types[i]] > counts[types[j]] })
+
+	picks := map[string]string{allTypes: ""}
+	labels := []string{allTypes}
+	for _, t := range types {
+		label := fmt.Sprintf("%s %s  %d", typeIcon("x."+t), t, counts[t])
+		picks[label] = t
+		labels = append(labels, label)
+	}
+	return newFinder("filter by unit type…", labels, func(label string) tea.Msg {
+		return typeFilterMsg{unitType: picks[label]}
+	})
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"context"
+	"time"
+	"vigilix/internal/update"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type updateMsg struct {
+	release *update.Release
+	err     error
+}
+
+// checkUpdate asks GitHub once for a newer release. It only runs when the
+// user opted in with check_updates.
+func checkUpdate() tea.Msg {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	r, err := update.Check(ctx)
+	return updateMsg{release: r, err: err}
+}
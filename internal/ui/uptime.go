@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// uptimeSaveEvery is how often the availability history is written while
+// running, so a crash or kill loses little of it.
+const uptimeSaveEvery = time.Minute
+
+// availabilityWindows are the periods availability is reported over.
+var availabilityWindows = []struct {
+	label  string
+	window time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// observeUptime records every unit's state in the availability history and
+// saves it now and then, together with the history store when that is on.
+// Refreshes further apart than a few intervals (the UI was not running, or
+// the machine slept) leave a gap.
+func (m *model) observeUptime(units []systemd.Unit, now time.Time) tea.Cmd {
+	maxGap := 3 * m.cfg.RefreshInterval()
+	for _, u := range units {
+		m.uptime.Observe(u.Name, u.ActiveState == "active" || u.ActiveState == "reloading", now, maxGap)
+	}
+	if now.Sub(m.uptimeSaved) < uptimeSaveEvery {
+		return nil
+	}
+	m.uptimeSaved = now
+	if historyEnabled {
+		return flushHistory(m.takeHistory())
+	}
+	if err := config.SaveUptime(m.uptime, now); err != nil {
+		m.notifyError(fmt.Errorf("saving availability history: %w", err))
+	}
+	return nil
+}
+
+// saveUptime writes the availability history on exit.
+func (m *model) saveUptime() error {
+	if historyEnabled {
+		return writeHistory(m.takeHistory())
+	}
+	return config.SaveUptime(m.uptime, time.Now())
+}
+
+// availability formats the share of observed time a unit was up, e.g.
+// "99.93%", or "–" when it was not observed in the window.
+func availability(spans []config.Span, window time.Duration, now time.Time) string {
+	ratio, observed := config.Availability(spans, window, now)
+	if observed == 0 {
+		return "–"
+	}
+	return fmt.Sprintf("%.2f%%", ratio*100)
+}
+
+// renderAvailability lists availability per window with how much of it was
+// observed, since only time spent watching counts.
+func renderAvailability(spans []config.Span, now time.Time, label, value lipgloss.Style) string {
+	if len(spans) == 0 {
+		return lipgloss.NewStyle().Foreground(comment).Render("Not observed yet; availability is tracked while vigilix runs.") + "\n"
+	}
+	var b strings.Builder
+	for _, w := range availabilityWindows {
+		ratio, observed := config.Availability(spans, w.window, now)
+		if observed == 0 {
+			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render(w.label), " ", value.Render("not observed")) + "\n")
+			continue
+		}
+		color := green
+		switch {
+		case ratio < 0.99:
+			color = red
+		case ratio < 0.999:
+			color = yellow
+		}
+		pct := lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("%7.3f%%", ratio*100))
+		note := lipgloss.NewStyle().Foreground(comment).Render(fmt.Sprintf(" over %s observed", humanDuration(observed)))
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render(w.label), " ", pct+note) + "\n")
+	}
+	return b.String()
+}
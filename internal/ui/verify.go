@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+	"vigilix/internal/explain"
+	"vigilix/internal/rollout"
+	"vigilix/internal/supervise"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// defaultVerifyTimeout bounds the verification of units whose start
+	// timeout is infinite.
+	defaultVerifyTimeout = 90 * time.Second
+	// restartSettle is how long a restarted unit must then stay active,
+	// which catches services that crash right after starting.
+	restartSettle = 2 * time.Second
+)
+
+// restartVerifiedMsg reports whether a restarted unit came back: failure
+// is empty when it is active, its probe answers and it stayed up.
+type restartVerifiedMsg struct {
+	unit, probe string
+	failure     string
+}
+
+// verifyRestart checks that a restarted unit becomes active within its
+// start timeout and that its probe, if one is configured, answers.
+func verifyRestart(unit string, timeout time.Duration, probe string) tea.Cmd {
+	if timeout <= 0 {
+		timeout = defaultVerifyTimeout
+	}
+	return func() tea.Msg {
+		msg := restartVerifiedMsg{unit: unit, probe: probe}
+		if err := rollout.WaitHealthy(supervise.Context(), unit, probe, timeout); err != nil {
+			// An active unit whose probe fails is described by the probe.
+			msg.failure = err.Error()
+			if props, _ := systemd.ShowProperties(unit, "ActiveState"); props["ActiveState"] != "active" {
+				msg.failure = explain.Failure(unit)
+			}
+			return msg
+		}
+		time.Sleep(restartSettle)
+		props, err := systemd.ShowProperties(unit, "ActiveState")
+		if err != nil {
+			msg.failure = err.Error()
+		} else if props["ActiveState"] != "active" {
+			msg.failure = fmt.Sprintf("stopped again after starting: %s", explain.Failure(unit))
+		}
+		return msg
+	}
+}
+
+// restartFailed decodes why `systemctl restart` itself failed: a unit
+// that did not come up is described by its exit status and last log line,
+// anything else, such as missing privileges, by systemctl's error.
+func restartFailed(unit string, err error) tea.Cmd {
+	return func() tea.Msg {
+		msg := restartVerifiedMsg{unit: unit, failure: err.Error()}
+		if props, _ := systemd.ShowProperties(unit, "ActiveState"); props["ActiveState"] != "" && props["ActiveState"] != "active" {
+			msg.failure = explain.Failure(unit)
+		}
+		return msg
+	}
+}
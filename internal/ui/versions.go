@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"log/slog"
+	"strings"
+	"vigilix/internal/systemd"
+	"vigilix/internal/unitgit"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// unitVersionsEnabled mirrors the unit_versions setting for commands that
+// run outside the model.
+var unitVersionsEnabled bool
+
+type versionsMsg struct {
+	unit     string
+	versions []unitgit.Version
+	diff     string
+	err      error
+}
+
+func fetchVersions(unit string) tea.Cmd {
+	return func() tea.Msg {
+		versions, err := unitgit.Versions(unit)
+		if err != nil {
+			return versionsMsg{unit: unit, err: err}
+		}
+		diff, err := unitgit.Diff(unit)
+		return versionsMsg{unit: unit, versions: versions, diff: diff, err: err}
+	}
+}
+
+// recordVersion snapshots the unit's files when versioning is on. It runs
+// in commands that change unit files, after the change.
+func recordVersion(unit, message string) error {
+	if !unitVersionsEnabled {
+		return nil
+	}
+	files, err := systemd.UnitFiles(unit)
+	if err != nil {
+		return err
+	}
+	changed, err := unitgit.Snapshot(unit, files, message)
+	if changed {
+		slog.Info("unit version recorded", "unit", unit, "message", message)
+	}
+	return err
+}
+
+// versionRecordedMsg reports a snapshot taken around an edit.
+type versionRecordedMsg struct {
+	unit string
+	err  error
+}
+
+func snapshotUnit(unit, message string) tea.Cmd {
+	return func() tea.Msg {
+		return versionRecordedMsg{unit: unit, err: recordVersion(unit, message)}
+	}
+}
+
+// isUnitFile reports whether path is one of the files shown in the Config
+// view, as opposed to a path they reference.
+func (m model) isUnitFile(path string) bool {
+	for _, f := range unitFilePaths(m.configContent) {
+		if f == path {
+			return true
+		}
+	}
+	return false
+}
+
+// versionPicker lists the unit's earlier versions to roll back to; the
+// newest is the current state.
+func versionPicker(unit string, versions []unitgit.Version) *finder {
+	picks := make(map[string]unitgit.Version)
+	var labels []string
+	for _, v := range versions[1:] {
+		label := v.Time.Format("2006-01-02 15:04:05") + "  " + v.Subject + "  " + v.Rev[:7]
+		picks[label] = v
+		labels = append(labels, label)
+	}
+	return newFinder("roll back "+unit+" to…", labels, func(label string) tea.Msg {
+		return showDialogMsg{dialog: rollbackDialog(unit, picks[label])}
+	})
+}
+
+func rollbackDialog(unit string, v unitgit.Version) *dialog {
+	when := v.Time.Format("2006-01-02 15:04:05")
+	return &dialog{
+		title: "Roll back " + unit + " to " + when + "?",
+		lines: []string{
+			"Its unit file and drop-ins are written back as they were (" + v.Subject + "),",
+			"drop-ins added since are removed and the manager is reloaded.",
+			"Restart the unit afterwards to apply them.",
+		},
+		confirm: func() tea.Msg {
+			// Keep the current state first so the rollback can be undone.
+			if err := recordVersion(unit, "before rolling back to "+when); err != nil {
+				return actionResultMsg{err: err, action: "Rolled back", unit: unit}
+			}
+			changed, err := unitgit.Restore(unit, v.Rev)
+			if err == nil && len(changed) > 0 {
+				err = systemd.DaemonReload()
+			}
+			if err == nil {
+				err = recordVersion(unit, "rolled back to "+when)
+			}
+			return actionResultMsg{err: err, action: "Rolled back", unit: unit}
+		},
+	}
+}
+
+// renderVersions shows the unit's snapshots as patches, newest first.
+func renderVersions(msg versionsMsg, width int) string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Versions of "+msg.unit) + "\n")
+	if !unitVersionsEnabled {
+		b.WriteString(dim.Render("Set \"unit_versions\": true in the config to keep a version of the unit file and drop-ins on every edit made through vigilix.") + "\n")
+		return b.String()
+	}
+	if len(msg.versions) == 0 {
+		b.WriteString(dim.Render("No versions yet; one is kept whenever the unit file or a drop-in is edited through vigilix.") + "\n")
+		return b.String()
+	}
+	b.WriteString(dim.Render("enter: roll back to an earlier version") + "\n\n")
+
+	add := lipgloss.NewStyle().Foreground(green)
+	del := lipgloss.NewStyle().Foreground(red)
+	hunk := lipgloss.NewStyle().Foreground(cyan)
+	for _, line := range strings.Split(msg.diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "commit "):
+			b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(orange).Render(rule("── "+strings.TrimPrefix(line, "commit ")+" ", width)))
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			b.WriteString(dim.Render(line))
+		case strings.HasPrefix(line, "diff --git"), strings.HasPrefix(line, "index "),
+			strings.HasPrefix(line, "new file"), strings.HasPrefix(line, "deleted file"):
+			continue
+		case strings.HasPrefix(line, "@@"):
+			b.WriteString(hunk.Render(line))
+		case strings.HasPrefix(line, "+"):
+			b.WriteString(add.Render(line))
+		case strings.HasPrefix(line, "-"):
+			b.WriteString(del.Render(line))
+		default:
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"vigilix/internal/systemd"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchedUnitsMsg is a refresh of the units on the watch list only.
+type watchedUnitsMsg struct {
+	units []systemd.Unit
+	err   error
+}
+
+func fetchWatchedUnits(patterns []string) tea.Cmd {
+	return func() tea.Msg {
+		units, err := systemd.ListUnits(patterns...)
+		return watchedUnitsMsg{units: units, err: err}
+	}
+}
+
+// fullRefreshDue reports whether the next background refresh should cover
+// every unit rather than only the watched ones.
+func (m model) fullRefreshDue(now time.Time) bool {
+	return len(m.cfg.Watch) == 0 || now.Sub(m.lastFullRefresh) >= m.cfg.FullRefreshInterval()
+}
+
+// mergeWatched replaces the watched units in all with their fresh state.
+// Watched units that went away are dropped, new ones added, and the list
+// stays sorted by name like systemctl's.
+func mergeWatched(all, fresh []systemd.Unit, patterns []string) []systemd.Unit {
+	merged := make([]systemd.Unit, 0, len(all)+len(fresh))
+	for _, u := range all {
+		if !systemd.MatchesAny(patterns, u.Name) {
+			merged = append(merged, u)
+		}
+	}
+	merged = append(merged, fresh...)
+	slices.SortFunc(merged, func(a, b systemd.Unit) int { return strings.Compare(a.Name, b.Name) })
+	return merged
+}
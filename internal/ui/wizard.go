@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"vigilix/internal/config"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wizardStep is one question of the first-run wizard. Steps either offer a
+// fixed set of options or take free text.
+type wizardStep struct {
+	title   string
+	hint    string
+	options []string
+	apply   func(c *config.Config, value string)
+	current func(c config.Config) string
+}
+
+var refreshChoices = []string{"2", "5", "10", "30"}
+
+func wizardSteps() []wizardStep {
+	return []wizardStep{
+		{
+			title:   "Theme",
+			hint:    "The preview switches as you move.",
+			options: themeNames(),
+			apply:   func(c *config.Config, v string) { c.Theme = v },
+			current: func(c config.Config) string { return c.Theme },
+		},
+		{
+			title:   "Default scope",
+			hint:    "system manages machine services (usually needs sudo); user manages your own.",
+			options: []string{"system", "user"},
+			apply:   func(c *config.Config, v string) { c.Scope = v },
+			current: func(c config.Config) string { return c.Scope },
+		},
+		{
+			title: "Dev Mode keywords",
+			hint:  "Comma-separated name fragments shown when Dev Mode (d) is on.",
+			apply: func(c *config.Config, v string) {
+				c.DevKeywords = nil
+				for _, kw := range strings.Split(v, ",") {
+					if kw = strings.ToLower(strings.TrimSpace(kw)); kw != "" {
+						c.DevKeywords = append(c.DevKeywords, kw)
+					}
+				}
+			},
+			current: func(c config.Config) string { return strings.Join(c.DevKeywords, ", ") },
+		},
+		{
+			title:   "Refresh interval (seconds)",
+			hint:    "How often the unit list is reloaded in the background.",
+			options: refreshChoices,
+			apply:   func(c *config.Config, v string) { c.RefreshSeconds, _ = strconv.Atoi(v) },
+			current: func(c config.Config) string { return strconv.Itoa(c.RefreshSeconds) },
+		},
+		{
+			title:   "Failure alerts",
+			hint:    "Pop up a notification when a unit enters the failed state.",
+			options: []string{"on", "off"},
+			apply:   func(c *config.Config, v string) { c.Notifications = v == "on" },
+			current: func(c config.Config) string {
+				if c.Notifications {
+					return "on"
+				}
+				return "off"
+			},
+		},
+		{
+			title:   "Update check",
+			hint:    "Ask GitHub for newer releases on startup and show them in the footer.",
+			options: []string{"off", "on"},
+			apply:   func(c *config.Config, v string) { c.CheckUpdates = v == "on" },
+			current: func(c config.Config) string {
+				if c.CheckUpdates {
+					return "on"
+				}
+				return "off"
+			},
+		},
+	}
+}
+
+// wizard is a standalone program run on first launch. It walks through the
+// steps and leaves the resulting config in cfg.
+type wizard struct {
+	steps   []wizardStep
+	step    int
+	choice  int
+	input   textinput.Model
+	cfg     config.Config
+	done    bool
+	aborted bool
+	width   int
+}
+
+func newWizard() wizard {
+	ti := textinput.New()
+	ti.Prompt = "❯ "
+	w := wizard{steps: wizardSteps(), input: ti, cfg: config.Default()}
+	w.enterStep()
+	return w
+}
+
+// enterStep positions the cursor on the step's current value.
+func (w *wizard) enterStep() {
+	s := w.steps[w.step]
+	value := s.current(w.cfg)
+	if s.options == nil {
+		w.input.SetValue(value)
+		w.input.CursorEnd()
+		w.input.Focus()
+		return
+	}
+	w.input.Blur()
+	w.choice = 0
+	for i, o := range s.options {
+		if o == value {
+			w.choice = i
+		}
+	}
+}
+
+func (w wizard) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (w wizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		w.width = msg.Width
+		return w, nil
+
+	case tea.KeyMsg:
+		s := w.steps[w.step]
+		switch msg.String() {
+		case "ctrl+c":
+			w.aborted = true
+			return w, tea.Quit
+		case "esc":
+			if w.step == 0 {
+				// Skip the wizard entirely and keep the defaults.
+				w.cfg, w.done = config.Default(), true
+				setTheme(w.cfg.Theme)
+				return w, tea.Quit
+			}
+			w.step--
+			w.enterStep()
+			return w, nil
+		case "enter":
+			if s.options == nil {
+				s.apply(&w.cfg, w.input.Value())
+			} else {
+				s.apply(&w.cfg, s.options[w.choice])
+			}
+			if w.step == len(w.steps)-1 {
+				w.done = true
+				return w, tea.Quit
+			}
+			w.step++
+			w.enterStep()
+			return w, textinput.Blink
+		}
+
+		if s.options == nil {
+			var cmd tea.Cmd
+			w.input, cmd = w.input.Update(msg)
+			return w, cmd
+		}
+		switch msg.String() {
+		case "up", "k", "left", "h":
+			w.choice = (w.choice - 1 + len(s.options)) % len(s.options)
+		case "down", "j", "right", "l", "tab":
+			w.choice = (w.choice + 1) % len(s.options)
+		}
+		if w.step == 0 {
+			s.apply(&w.cfg, s.options[w.choice])
+			setTheme(w.cfg.Theme)
+		}
+		return w, nil
+	}
+
+	var cmd tea.Cmd
+	w.input, cmd = w.input.Update(msg)
+	return w, cmd
+}
+
+func (w wizard) View() string {
+	if w.done || w.aborted {
+		return ""
+	}
+	s := w.steps[w.step]
+	heading := lipgloss.NewStyle().Bold(true).Foreground(cyan)
+	dim := lipgloss.NewStyle().Foreground(comment)
+
+	body := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(pink).Render("Welcome to Vigilix") +
+			dim.Render(fmt.Sprintf("  ·  setup %d/%d", w.step+1, len(w.steps))),
+		"",
+		heading.Render(s.title),
+		"",
+	}
+	if s.options == nil {
+		body = append(body, w.input.View())
+	} else {
+		for i, o := range s.options {
+			if i == w.choice {
+				body = append(body, lipgloss.NewStyle().Bold(true).Foreground(green).Render("● "+o))
+			} else {
+				body = append(body, baseStyle.Render("○ "+o))
+			}
+		}
+	}
+
+	back := "esc: back"
+	if w.step == 0 {
+		back = "esc: skip and use defaults"
+	}
+	body = append(body, "", dim.Render(s.hint), "", dim.Render("↑/↓: choose · enter: next · "+back))
+
+	width := 60
+	if w.width > 0 && w.width-4 < width {
+		width = w.width - 4
+	}
+	return panelStyle.Copy().
+		BorderForeground(purple).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(body, "\n")) + "\n"
+}
+
+// RunWizard asks the first-run questions and writes the answers to the
+// config file. Skipping the wizard writes the defaults so it is not shown
+// again. If the user quits with ctrl+c, nothing is written and ok is false.
+func RunWizard() (cfg config.Config, ok bool, err error) {
+	final, err := tea.NewProgram(newWizard()).Run()
+	if err != nil {
+		return config.Default(), false, err
+	}
+	w := final.(wizard)
+	if w.aborted {
+		return config.Default(), false, nil
+	}
+	return w.cfg, true, config.Save(w.cfg)
+}
@@ -0,0 +1,225 @@
+// Package unitgit keeps the versions of unit files and drop-ins changed
+// through vigilix in a local git repository, so edits can be reviewed as
+// diffs and rolled back. Each unit has a directory in the repository
+// holding copies of its files under their absolute paths, e.g.
+// nginx.service/etc/systemd/system/nginx.service.d/override.conf.
+package unitgit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"vigilix/internal/config"
+	"vigilix/internal/systemd"
+)
+
+// Version is one snapshot of a unit's files.
+type Version struct {
+	Rev     string
+	Time    time.Time
+	Subject string
+}
+
+// Dir is the repository, in the state directory.
+func Dir() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "units"), nil
+}
+
+// git runs git in the repository under a fixed identity, so commits work
+// without any git configuration, and returns its output.
+func git(dir string, args ...string) (string, error) {
+	args = append([]string{"-C", dir, "-c", "user.name=vigilix", "-c", "user.email=vigilix@localhost", "-c", "commit.gpgsign=false"}, args...)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// open returns the repository, creating it on first use.
+func open() (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", errors.New("git is not installed")
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return dir, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	_, err = git(dir, "init", "--quiet")
+	return dir, err
+}
+
+// Snapshot records the current content of a unit's files, replacing the
+// previous snapshot so removed drop-ins show as deleted. Only the
+// administrator's files are kept (see systemd.IsAdminPath); vendor unit
+// files and runtime drop-ins are never rolled back. It reports whether
+// anything changed since the last one.
+func Snapshot(unit string, files []string, message string) (bool, error) {
+	dir, err := open()
+	if err != nil {
+		return false, err
+	}
+	unitDir := filepath.Join(dir, unit)
+	if err := os.RemoveAll(unitDir); err != nil {
+		return false, err
+	}
+	for _, f := range files {
+		if !systemd.IsAdminPath(f) {
+			continue
+		}
+		data, err := os.ReadFile(f)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		copyPath := filepath.Join(unitDir, f)
+		if err := os.MkdirAll(filepath.Dir(copyPath), 0o700); err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(copyPath, data, 0o600); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := git(dir, "add", "--all", "--", unit); err != nil {
+		return false, err
+	}
+	if status, err := git(dir, "status", "--porcelain", "--", unit); err != nil || status == "" {
+		return false, err
+	}
+	_, err = git(dir, "commit", "--quiet", "--message", unit+": "+message, "--", unit)
+	return err == nil, err
+}
+
+// Versions lists a unit's snapshots, newest first.
+func Versions(unit string) ([]Version, error) {
+	dir, err := open()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git(dir, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, nil // no commits yet
+	}
+	out, err := git(dir, "log", "--format=%H%x09%ct%x09%s", "--", unit)
+	if err != nil {
+		return nil, err
+	}
+	var versions []Version
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		f := strings.SplitN(line, "\t", 3)
+		if len(f) < 3 {
+			continue
+		}
+		var secs int64
+		fmt.Sscan(f[1], &secs)
+		versions = append(versions, Version{
+			Rev:     f[0],
+			Time:    time.Unix(secs, 0),
+			Subject: strings.TrimPrefix(f[2], unit+": "),
+		})
+	}
+	return versions, nil
+}
+
+// Diff is the patch of every snapshot of the unit, newest first, with
+// paths shown as on the system.
+func Diff(unit string) (string, error) {
+	dir, err := open()
+	if err != nil {
+		return "", err
+	}
+	if _, err := git(dir, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return "", nil
+	}
+	out, err := git(dir, "log", "--patch", "--no-color", "--format=commit %h %ci%n%s%n", "--relative="+unit, "--", unit)
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// Restore writes the unit's files back as they were in version rev and
+// removes the ones created since. Files outside the administrator's
+// directories, which older snapshots may hold, are left alone. It returns
+// the paths it changed; the caller reloads the manager and takes a new
+// snapshot.
+func Restore(unit, rev string) ([]string, error) {
+	dir, err := open()
+	if err != nil {
+		return nil, err
+	}
+	out, err := git(dir, "ls-tree", "-r", "--name-only", rev, "--", unit)
+	if err != nil {
+		return nil, err
+	}
+	then := make(map[string]bool)
+	for _, p := range strings.Split(strings.TrimSpace(out), "\n") {
+		if path := strings.TrimPrefix(p, unit); p != "" && systemd.IsAdminPath(path) {
+			then[path] = true
+		}
+	}
+	if len(then) == 0 {
+		return nil, fmt.Errorf("version %.7s has no files of %s", rev, unit)
+	}
+
+	var changed []string
+	for path := range then {
+		data, err := git(dir, "show", rev+":"+unit+path)
+		if err != nil {
+			return changed, err
+		}
+		if current, err := os.ReadFile(path); err == nil && string(current) == data {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return changed, err
+		}
+		if err := systemd.WriteUnitFile(path, []byte(data)); err != nil {
+			return changed, err
+		}
+		changed = append(changed, path)
+	}
+
+	// Files of the latest snapshot that did not exist back then.
+	unitDir := filepath.Join(dir, unit)
+	err = filepath.WalkDir(unitDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		path := strings.TrimPrefix(p, unitDir)
+		if then[path] || !systemd.IsAdminPath(path) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		changed = append(changed, path)
+		return nil
+	})
+	return changed, err
+}
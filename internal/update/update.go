@@ -0,0 +1,273 @@
+// Package update checks GitHub releases for newer versions of vigilix and
+// replaces the running binary with a verified download.
+//
+// Releases are expected to carry one archive-free binary per platform named
+// vigilix_<os>_<arch> (e.g. vigilix_linux_amd64), a checksums.txt file in
+// `sha256sum` format covering them, and checksums.txt.sig, a signify
+// signature of it (`minisign -S -l` writes the same format, as
+// checksums.txt.minisig).
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const releasesURL = "https://api.github.com/repos/LOVENISH87/vigilix/releases/latest"
+
+// Version is set at build time with
+// -ldflags "-X vigilix/internal/update.Version=v1.2.3".
+var Version string
+
+// PublicKey is the key release checksums are signed with: the base64 line
+// of the signify or minisign public key file. Release builds embed it with
+// -ldflags "-X vigilix/internal/update.PublicKey=RWQ…"; builds without it
+// refuse to update themselves.
+var PublicKey string
+
+// Current returns the running version: the build-time Version, else the
+// module version recorded by `go install …@vX`, else "dev". Builds from an
+// untagged or modified checkout count as "dev".
+func Current() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		v := info.Main.Version
+		if v != "" && v != "(devel)" && !strings.HasPrefix(v, "v0.0.0-") && !strings.HasSuffix(v, "+dirty") {
+			return v
+		}
+	}
+	return "dev"
+}
+
+// Release is a published version.
+type Release struct {
+	Tag    string `json:"tag_name"`
+	URL    string `json:"html_url"`
+	Assets []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Latest fetches the newest release.
+func Latest(ctx context.Context) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("checking for updates: %s", resp.Status)
+	}
+
+	var r Release
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Newer reports whether release version latest is newer than current.
+// Development builds never consider themselves outdated.
+func Newer(current, latest string) bool {
+	c, ok1 := parseSemver(current)
+	l, ok2 := parseSemver(latest)
+	if !ok1 || !ok2 {
+		return false
+	}
+	for i := range c {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parseSemver reads "v1.2.3" (pre-release and build suffixes are ignored).
+func parseSemver(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "-")
+	v, _, _ = strings.Cut(v, "+")
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// Check returns the latest release if it is newer than the running version,
+// or nil.
+func Check(ctx context.Context) (*Release, error) {
+	r, err := Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !Newer(Current(), r.Tag) {
+		return nil, nil
+	}
+	return r, nil
+}
+
+func (r *Release) asset(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.URL
+		}
+	}
+	return ""
+}
+
+// Apply downloads the binary for this platform, verifies it against the
+// release's checksums.txt, whose signature must match PublicKey, and
+// atomically replaces the executable at exe.
+func (r *Release) Apply(ctx context.Context, exe string) error {
+	if PublicKey == "" {
+		return errors.New("this build has no release signing key; install updates from the release page")
+	}
+	name := fmt.Sprintf("vigilix_%s_%s", runtime.GOOS, runtime.GOARCH)
+	binURL, sumsURL := r.asset(name), r.asset("checksums.txt")
+	sigURL := cmp.Or(r.asset("checksums.txt.sig"), r.asset("checksums.txt.minisig"))
+	if binURL == "" {
+		return fmt.Errorf("release %s has no binary for %s/%s", r.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	if sumsURL == "" || sigURL == "" {
+		return fmt.Errorf("release %s has no signed checksums.txt; refusing to install an unverified binary", r.Tag)
+	}
+
+	sums, err := download(ctx, sumsURL)
+	if err != nil {
+		return err
+	}
+	sig, err := download(ctx, sigURL)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(sums, sig, PublicKey); err != nil {
+		return fmt.Errorf("checksums.txt of %s: %w; not installing", r.Tag, err)
+	}
+	want, err := checksumFor(sums, name)
+	if err != nil {
+		return err
+	}
+	bin, err := download(ctx, binURL)
+	if err != nil {
+		return err
+	}
+	got := sha256.Sum256(bin)
+	if hex.EncodeToString(got[:]) != want {
+		return fmt.Errorf("checksum mismatch for %s; not installing", name)
+	}
+
+	// Write next to the target so the final rename stays on one file system.
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".vigilix-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bin); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), exe)
+}
+
+// verifySignature checks a signify signature file, or a minisign one in
+// its legacy format, against the public key. Both are Ed25519 over the
+// message itself, tagged with the key's random 8-byte number.
+func verifySignature(message, sigFile []byte, publicKey string) error {
+	pub, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(pub) != 2+8+ed25519.PublicKeySize || string(pub[:2]) != "Ed" {
+		return errors.New("malformed public key")
+	}
+	var sig []byte
+	for _, line := range bytes.Split(sigFile, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || bytes.HasPrefix(line, []byte("untrusted comment:")) {
+			continue
+		}
+		sig, err = base64.StdEncoding.DecodeString(string(line))
+		break
+	}
+	switch {
+	case err != nil || len(sig) != 2+8+ed25519.SignatureSize:
+		return errors.New("malformed signature")
+	case string(sig[:2]) == "ED":
+		return errors.New("prehashed minisign signatures are not supported; sign with minisign -S -l or signify")
+	case string(sig[:2]) != "Ed":
+		return errors.New("unknown signature algorithm")
+	case !bytes.Equal(sig[2:10], pub[2:10]):
+		return errors.New("signed with another key")
+	case !ed25519.Verify(pub[10:], message, sig[10:]):
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// checksumFor finds a file's hash in `sha256sum` output.
+func checksumFor(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(sums)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", errors.New("checksums.txt does not list " + name)
+}
@@ -0,0 +1,204 @@
+// Package watch follows units without a UI and reports their state
+// transitions and notable journal lines as one stream of events.
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+	"vigilix/internal/systemd"
+)
+
+// Kind tells state events from log events.
+type Kind string
+
+const (
+	KindState Kind = "state"
+	KindLog   Kind = "log"
+)
+
+// State is a unit's active and sub state.
+type State struct {
+	Active string
+	Sub    string
+}
+
+func (s State) String() string {
+	if s.Active == "" {
+		return "unknown"
+	}
+	return s.Active + " (" + s.Sub + ")"
+}
+
+// Event is a single thing worth reporting about a unit.
+type Event struct {
+	Time time.Time
+	Kind Kind
+	Unit string
+
+	// State events. From is the zero State for the initial report.
+	From, To State
+
+	// Log events.
+	Priority int
+	Message  string
+}
+
+// Options configure Run.
+type Options struct {
+	// Units to follow; every unit when empty.
+	Units []string
+	// How often unit states are polled.
+	Interval time.Duration
+	// Journal lines at this priority or more severe are reported.
+	MaxPriority int
+	// Report every unit's current state when starting, not only changes.
+	Initial bool
+}
+
+// Run reports events to out until ctx is cancelled or following the journal
+// fails. It never closes out.
+func Run(ctx context.Context, opts Options, out chan<- Event) error {
+	units := make([]string, len(opts.Units))
+	for i, u := range opts.Units {
+		units[i] = systemd.UnitName(u)
+	}
+
+	logs := make(chan systemd.JournalEntry)
+	journalErr := make(chan error, 1)
+	go func() { journalErr <- systemd.FollowJournal(ctx, units, opts.MaxPriority, logs) }()
+
+	send := func(e Event) bool {
+		select {
+		case out <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	prev, err := poll(units)
+	if err != nil {
+		return err
+	}
+	if opts.Initial {
+		now := time.Now()
+		for _, u := range sortedNames(units, prev) {
+			if !send(Event{Time: now, Kind: KindState, Unit: u, To: prev[u]}) {
+				return nil
+			}
+		}
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err := <-journalErr:
+			if err != nil {
+				return err
+			}
+			journalErr = nil // journalctl exited cleanly; keep polling states
+
+		case entry := <-logs:
+			e := Event{Time: entry.Time, Kind: KindLog, Unit: entry.Unit, Priority: entry.Priority, Message: entry.Message}
+			if !send(e) {
+				return nil
+			}
+
+		case now := <-ticker.C:
+			next, err := poll(units)
+			if err != nil {
+				return err
+			}
+			for _, u := range sortedNames(units, next) {
+				from, known := prev[u]
+				if !known || from == next[u] {
+					continue
+				}
+				if !send(Event{Time: now, Kind: KindState, Unit: u, From: from, To: next[u]}) {
+					return nil
+				}
+			}
+			prev = next
+		}
+	}
+}
+
+// poll fetches the current state of the units, or of every unit when none
+// are given.
+func poll(units []string) (map[string]State, error) {
+	states := make(map[string]State)
+	if len(units) == 0 {
+		all, err := systemd.ListUnits()
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range all {
+			states[u.Name] = State{Active: u.ActiveState, Sub: u.SubState}
+		}
+		return states, nil
+	}
+
+	props, err := systemd.ShowUnitsProperties(units, "ActiveState", "SubState")
+	if err != nil {
+		return nil, err
+	}
+	for name, p := range props {
+		states[name] = State{Active: p["ActiveState"], Sub: p["SubState"]}
+	}
+	return states, nil
+}
+
+// sortedNames returns the units to report on in a stable order: the
+// requested order when units were named, alphabetical otherwise.
+func sortedNames(units []string, states map[string]State) []string {
+	if len(units) > 0 {
+		return units
+	}
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MarshalJSON encodes the event as a flat object with only the fields that
+// apply to its kind, one per line of `vigilix events --json`:
+//
+//	{"time":"…","type":"state","unit":"nginx.service","from":{"active":"active","sub":"running"},"to":{…}}
+//	{"time":"…","type":"log","unit":"nginx.service","priority":3,"priority_name":"err","message":"…"}
+func (e Event) MarshalJSON() ([]byte, error) {
+	type state struct {
+		Active string `json:"active"`
+		Sub    string `json:"sub"`
+	}
+	out := struct {
+		Time         time.Time `json:"time"`
+		Type         Kind      `json:"type"`
+		Unit         string    `json:"unit"`
+		From         *state    `json:"from,omitempty"`
+		To           *state    `json:"to,omitempty"`
+		Priority     *int      `json:"priority,omitempty"`
+		PriorityName string    `json:"priority_name,omitempty"`
+		Message      string    `json:"message,omitempty"`
+	}{Time: e.Time, Type: e.Kind, Unit: e.Unit}
+
+	switch e.Kind {
+	case KindState:
+		if e.From != (State{}) {
+			out.From = &state{e.From.Active, e.From.Sub}
+		}
+		out.To = &state{e.To.Active, e.To.Sub}
+	case KindLog:
+		out.Priority = &e.Priority
+		out.PriorityName = systemd.PriorityName(e.Priority)
+		out.Message = e.Message
+	}
+	return json.Marshal(out)
+}